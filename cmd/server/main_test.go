@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestMaskDBURL(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{
+			name: "url with password",
+			dsn:  "postgres://ledger:s3cret@db:5432/ledger?sslmode=disable",
+			want: "postgres://ledger:***@db:5432/ledger?sslmode=disable",
+		},
+		{
+			name: "url without password",
+			dsn:  "postgres://ledger@db/ledger",
+			want: "postgres://ledger@db/ledger",
+		},
+		{
+			name: "url with password parameter",
+			dsn:  "postgres://db/ledger?password=s3cret&user=ledger",
+			want: "postgres://db/ledger?password=***&user=ledger",
+		},
+		{
+			name: "unparseable url",
+			dsn:  "postgres://ledger:s3cret@db:port/ledger",
+			want: "***",
+		},
+		{
+			name: "key=value",
+			dsn:  "host=db user=ledger password=s3cret dbname=ledger",
+			want: "host=db user=ledger password=*** dbname=ledger",
+		},
+		{
+			name: "key=value quoted with spaces",
+			dsn:  "host=db password='s3 cr\\'et' dbname=ledger",
+			want: "host=db password=*** dbname=ledger",
+		},
+		{
+			name: "key=value spaced and upper case",
+			dsn:  "host=db PASSWORD = s3cret",
+			want: "host=db PASSWORD = ***",
+		},
+		{
+			name: "key=value without password",
+			dsn:  "host=db user=ledger",
+			want: "host=db user=ledger",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskDBURL(tt.dsn); got != tt.want {
+				t.Fatalf("maskDBURL(%q) = %q, want %q", tt.dsn, got, tt.want)
+			}
+		})
+	}
+}
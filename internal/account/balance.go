@@ -0,0 +1,146 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Balance storage names accepted by BalanceStorageFor
+const (
+	BalanceStorageBigint  = "bigint"
+	BalanceStorageNumeric = "numeric"
+)
+
+// BalanceStorage adapts the repository to the SQL type of the accounts'
+// balance_cents column. Balances are whole cents in Go either way; a
+// deployment that moved the column to NUMERIC for headroom reads it through
+// NumericBalances, which converts back to cents and fails loudly on a value
+// that no longer fits rather than truncating it.
+type BalanceStorage interface {
+	// Name is the storage's BALANCE_STORAGE value
+	Name() string
+	// Column renders an expression reading the balance column col as BIGINT
+	// cents, for selects scanned into int64 fields
+	Column(col string) string
+	// Cents converts a balance value read from the database to cents
+	Cents(src any) (int64, error)
+}
+
+// BigintBalances reads a BIGINT balance_cents column as is
+var BigintBalances BalanceStorage = bigintBalances{}
+
+// NumericBalances reads a NUMERIC balance_cents column holding whole cents
+var NumericBalances BalanceStorage = numericBalances{}
+
+// BalanceStorageFor returns the BalanceStorage named name
+func BalanceStorageFor(name string) (BalanceStorage, error) {
+	switch name {
+	case BalanceStorageBigint:
+		return BigintBalances, nil
+	case BalanceStorageNumeric:
+		return NumericBalances, nil
+	}
+	return nil, fmt.Errorf("unknown balance storage %q", name)
+}
+
+// DetectBalanceStorage picks the BalanceStorage matching how
+// accounts.balance_cents is typed in db's schema. A NUMERIC column must have
+// scale 0, since a fractional cent has no int64 representation.
+func DetectBalanceStorage(ctx context.Context, db *sqlx.DB) (BalanceStorage, error) {
+	var column struct {
+		DataType string `db:"data_type"`
+		Scale    *int64 `db:"numeric_scale"`
+	}
+	query := `SELECT data_type, numeric_scale FROM information_schema.columns
+	          WHERE table_schema = current_schema() AND table_name = 'accounts' AND column_name = 'balance_cents'`
+	if err := db.GetContext(ctx, &column, query); err != nil {
+		return nil, fmt.Errorf("failed to inspect accounts.balance_cents: %w", err)
+	}
+	switch column.DataType {
+	case "bigint":
+		return BigintBalances, nil
+	case "numeric":
+		if column.Scale != nil && *column.Scale != 0 {
+			return nil, fmt.Errorf("accounts.balance_cents is NUMERIC with scale %d; balances must be whole cents (scale 0)", *column.Scale)
+		}
+		return NumericBalances, nil
+	}
+	return nil, fmt.Errorf("accounts.balance_cents has unsupported type %s", column.DataType)
+}
+
+type bigintBalances struct{}
+
+func (bigintBalances) Name() string             { return BalanceStorageBigint }
+func (bigintBalances) Column(col string) string { return col }
+func (bigintBalances) Cents(src any) (int64, error) {
+	// SUMs and other aggregates come back NUMERIC even over a BIGINT column
+	return centsFromValue(src)
+}
+
+type numericBalances struct{}
+
+func (numericBalances) Name() string { return BalanceStorageNumeric }
+
+// Column casts rather than rounds: Postgres raises "bigint out of range" on
+// a balance past int64, and DetectBalanceStorage rules out fractions
+func (numericBalances) Column(col string) string {
+	return col + `::BIGINT`
+}
+
+func (numericBalances) Cents(src any) (int64, error) {
+	return centsFromValue(src)
+}
+
+// centsFromValue converts a driver value to cents. NUMERIC values arrive as
+// decimal text, which may carry a zero fraction such as "1250.00".
+func centsFromValue(src any) (int64, error) {
+	switch v := src.(type) {
+	case int64:
+		return v, nil
+	case []byte:
+		return ParseCents(string(v))
+	case string:
+		return ParseCents(v)
+	case nil:
+		return 0, fmt.Errorf("balance is NULL")
+	}
+	return 0, fmt.Errorf("cannot read balance from %T", src)
+}
+
+// ParseCents converts decimal text to a whole number of cents. It fails if
+// the value has a fractional part or is outside the int64 range.
+func ParseCents(s string) (int64, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return 0, fmt.Errorf("balance %q is not a number", s)
+	}
+	if !r.IsInt() {
+		return 0, fmt.Errorf("balance %s is not a whole number of cents", s)
+	}
+	n := r.Num()
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("balance %s: %w", s, ErrBalanceOutOfRange)
+	}
+	return n.Int64(), nil
+}
+
+// centsScanner scans a single balance through a BalanceStorage into dst.
+// sqlx only scans into pointers, so it is always used as *centsScanner.
+type centsScanner struct {
+	storage BalanceStorage
+	dst     *int64
+}
+
+// Scan implements sql.Scanner
+func (c *centsScanner) Scan(src any) error {
+	cents, err := c.storage.Cents(src)
+	if err != nil {
+		return err
+	}
+	*c.dst = cents
+	return nil
+}
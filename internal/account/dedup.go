@@ -0,0 +1,55 @@
+package account
+
+import (
+	"container/list"
+	"sync"
+)
+
+// recentIDs is a bounded LRU set of recently processed job IDs.
+// It only remembers the last `size` IDs and lives in memory, so dedup is
+// best-effort: a replay after the ID has been evicted, or after a restart,
+// is processed again.
+type recentIDs struct {
+	size  int
+	mu    sync.Mutex
+	order *list.List
+	index map[string]*list.Element
+}
+
+func newRecentIDs(size int) *recentIDs {
+	return &recentIDs{
+		size:  size,
+		order: list.New(),
+		index: make(map[string]*list.Element, size),
+	}
+}
+
+// seen records id and reports whether it was already present
+func (r *recentIDs) seen(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.index[id]; ok {
+		r.order.MoveToFront(el)
+		return true
+	}
+
+	r.index[id] = r.order.PushFront(id)
+	if r.order.Len() > r.size {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.index, oldest.Value.(string))
+	}
+	return false
+}
+
+// forget removes id so a later job with the same ID is processed again
+func (r *recentIDs) forget(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if el, ok := r.index[id]; ok {
+		r.order.Remove(el)
+		delete(r.index, id)
+	}
+}
@@ -0,0 +1,132 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecentIDsEvictsLeastRecent(t *testing.T) {
+	r := newRecentIDs(3)
+	for _, id := range []string{"a", "b", "c"} {
+		if r.seen(id) {
+			t.Fatalf("%s reported seen on first sight", id)
+		}
+	}
+
+	// Seeing a again makes b the least recent, so d evicts b
+	if !r.seen("a") {
+		t.Fatal("a not remembered")
+	}
+	r.seen("d")
+
+	for id, want := range map[string]bool{"a": true, "c": true, "d": true} {
+		if _, ok := r.index[id]; ok != want {
+			t.Fatalf("%s remembered = %v, want %v", id, ok, want)
+		}
+	}
+	if r.seen("b") {
+		t.Fatal("evicted b still reported seen")
+	}
+	if r.order.Len() != 3 || len(r.index) != 3 {
+		t.Fatalf("holding %d IDs (%d indexed), want 3", r.order.Len(), len(r.index))
+	}
+}
+
+func TestRecentIDsForget(t *testing.T) {
+	r := newRecentIDs(3)
+	r.seen("a")
+	r.seen("b")
+
+	r.forget("a")
+	r.forget("missing")
+	if r.order.Len() != 1 || len(r.index) != 1 {
+		t.Fatalf("holding %d IDs (%d indexed) after forget, want 1", r.order.Len(), len(r.index))
+	}
+	if r.seen("a") {
+		t.Fatal("forgotten a still reported seen")
+	}
+	if !r.seen("b") {
+		t.Fatal("b lost by forgetting a")
+	}
+}
+
+func TestRecentIDsConcurrent(t *testing.T) {
+	r := newRecentIDs(64)
+	var first atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				id := fmt.Sprint(j % 32)
+				if !r.seen(id) {
+					first.Add(1)
+				}
+				if j%50 == 0 {
+					r.forget(fmt.Sprint((j + 1) % 32))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r.order.Len() != len(r.index) || r.order.Len() > 64 {
+		t.Fatalf("list holds %d IDs but index %d, bound 64", r.order.Len(), len(r.index))
+	}
+	// Every ID was new at least once; forgetting only adds to that
+	if first.Load() < 32 {
+		t.Fatalf("%d first sightings, want at least 32", first.Load())
+	}
+}
+
+func TestPoolDeliversDuplicateOnce(t *testing.T) {
+	var mu sync.Mutex
+	delivered := make(map[string]int)
+	pool := NewNotificationWorkerPool(16, 8, WithSender(func(ctx context.Context, n Notification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered[n.ID]++
+		return nil
+	}))
+	pool.Start(1)
+	for _, id := range []string{"n-1", "n-2", "n-1", "n-1", "n-2"} {
+		pool.Enqueue(Notification{ID: id, AccountID: "acc-1"})
+	}
+	if err := pool.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if delivered["n-1"] != 1 || delivered["n-2"] != 1 {
+		t.Fatalf("deliveries %v, want each ID once", delivered)
+	}
+}
+
+func TestPoolForgetsDeadLetteredID(t *testing.T) {
+	var calls atomic.Int64
+	dead := &deadLetterRecorder{}
+	pool := NewNotificationWorkerPool(16, 8,
+		WithSender(func(ctx context.Context, n Notification) error {
+			// The first delivery fails; its retry from the dead-letter
+			// store succeeds
+			if calls.Add(1) == 1 {
+				return errors.New("provider down")
+			}
+			return nil
+		}),
+		WithMaxAttempts(1),
+		WithDeadLetterStore(dead),
+	)
+	pool.Start(1)
+	pool.Enqueue(Notification{ID: "n-1", AccountID: "acc-1"})
+	pool.Enqueue(Notification{ID: "n-1", AccountID: "acc-1"})
+	if err := pool.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if calls.Load() != 2 || dead.count() != 1 {
+		t.Fatalf("%d delivery attempts and %d dead letters, want the retry let through after one failure", calls.Load(), dead.count())
+	}
+}
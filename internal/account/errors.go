@@ -0,0 +1,203 @@
+package account
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"apex-ledger/internal/platform/database"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres SQLSTATE codes the repository maps to typed errors
+const pgUniqueViolation = "23505"
+
+// ErrAccountExists is returned when creating an account whose ID is taken
+var ErrAccountExists = errors.New("account already exists")
+
+// ErrAccountNotFound is matched, via errors.Is, by the error returned when an
+// account doesn't exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// accountNotFoundError reads "account <id> not found" and matches
+// ErrAccountNotFound
+type accountNotFoundError struct {
+	id string
+}
+
+func (e *accountNotFoundError) Error() string {
+	return fmt.Sprintf("account %s not found", e.id)
+}
+
+func (e *accountNotFoundError) Is(target error) bool {
+	return target == ErrAccountNotFound
+}
+
+// accountNotFound returns the error for a missing account id
+func accountNotFound(id string) error {
+	return &accountNotFoundError{id: id}
+}
+
+// ErrInvalidAccountID is returned when a client-supplied account ID doesn't
+// match the configured ID pattern
+var ErrInvalidAccountID = errors.New("invalid account ID")
+
+// ErrOwnerRequired is returned when creating an account without an owner,
+// as when the caller's token carries no subject and none is named
+var ErrOwnerRequired = errors.New("account owner is required")
+
+// ErrAccountLimitReached is returned when an owner already holds the
+// maximum number of accounts
+var ErrAccountLimitReached = errors.New("account limit reached")
+
+// ErrAccountNotActive is returned when a transfer touches an account that
+// is still pending activation
+var ErrAccountNotActive = errors.New("account is not active")
+
+// ErrInvalidAccountType is returned when creating an account with an unknown type
+var ErrInvalidAccountType = errors.New("invalid account type")
+
+// ErrBalanceSign is returned when a change would leave an asset account
+// below zero or a liability account above zero
+var ErrBalanceSign = errors.New("balance would violate the account type's sign")
+
+// ErrInvalidNotificationChannel is returned for a notification channel
+// other than email, sms or webhook
+var ErrInvalidNotificationChannel = errors.New("invalid notification channel")
+
+// ErrSequenceMismatch is returned when a transfer's expected account
+// sequence no longer matches the account, because another change was
+// applied since the client read it
+var ErrSequenceMismatch = errors.New("account sequence mismatch")
+
+// ErrCurrencyAssertion is returned when a transfer names a currency for an
+// account that doesn't hold it
+var ErrCurrencyAssertion = errors.New("account currency does not match the asserted currency")
+
+// ErrCurrencyLocked is returned when changing the currency of an account with a non-zero balance
+var ErrCurrencyLocked = errors.New("currency can only be changed on a zero-balance account")
+
+// Cross-currency errors
+var (
+	ErrFXDisabled    = errors.New("cross-currency transfers are disabled")
+	ErrQuoteNotFound = errors.New("conversion quote not found")
+	ErrQuoteExpired  = errors.New("conversion quote has expired")
+	ErrQuoteMismatch = errors.New("conversion quote does not match the transfer currencies")
+	// ErrRateUnavailable is returned when the exchange-rate source can't be
+	// reached or gives an unusable answer
+	ErrRateUnavailable = errors.New("exchange rate source unavailable")
+)
+
+// Reversal errors
+var (
+	ErrTransactionNotFound  = errors.New("transaction not found")
+	ErrNotReversible        = errors.New("only same-currency transfers can be reversed")
+	ErrReversalExceedsTotal = errors.New("reversal exceeds the remaining reversible amount")
+)
+
+// Deposit errors
+var (
+	ErrDuplicateReference = errors.New("external reference already recorded")
+	ErrReferenceConflict  = errors.New("external reference already used for a different deposit")
+)
+
+// Hierarchy errors
+var (
+	ErrParentNotFound = errors.New("parent account not found")
+	ErrParentCycle    = errors.New("parent would create a cycle in the account hierarchy")
+)
+
+// ErrInvalidDenomination is returned when an amount is not a multiple of its
+// currency's configured minimum denomination
+var ErrInvalidDenomination = errors.New("amount is not a multiple of the currency's minimum denomination")
+
+// ErrAmountOverflow is returned when summing amounts would overflow int64
+var ErrAmountOverflow = errors.New("amount overflows int64")
+
+// ErrReasonRequired is returned when a balance adjustment has no reason
+var ErrReasonRequired = errors.New("adjustment reason is required")
+
+// ErrInvalidCategory is returned for a transfer category that is too long
+// or not in the configured set
+var ErrInvalidCategory = errors.New("invalid transaction category")
+
+// ErrNonPositiveAmount is returned when a debit or credit amount is zero or negative
+var ErrNonPositiveAmount = errors.New("amount must be positive")
+
+// isUniqueViolation reports whether err is a Postgres unique-constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
+
+// isUniqueViolationOf reports whether err violates the named unique constraint or index
+func isUniqueViolationOf(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+}
+
+// CurrencyMismatchError is returned when money would move between different
+// currencies without an explicit conversion
+type CurrencyMismatchError struct {
+	FromCurrency string
+	ToCurrency   string
+}
+
+func (e *CurrencyMismatchError) Error() string {
+	return fmt.Sprintf("currency mismatch: %s != %s", e.FromCurrency, e.ToCurrency)
+}
+
+// InsufficientFundsError is returned when a debit exceeds the available balance
+type InsufficientFundsError struct {
+	AccountID           string
+	BalanceCents        int64
+	OverdraftLimitCents int64
+	RequiredCents       int64
+}
+
+func (e *InsufficientFundsError) Error() string {
+	if e.OverdraftLimitCents > 0 {
+		return fmt.Sprintf("insufficient funds in account %s: balance %d, overdraft limit %d, required %d", e.AccountID, e.BalanceCents, e.OverdraftLimitCents, e.RequiredCents)
+	}
+	return fmt.Sprintf("insufficient funds in account %s: balance %d, required %d", e.AccountID, e.BalanceCents, e.RequiredCents)
+}
+
+// ShortfallCents is how much more the account needs to cover the debit
+func (e *InsufficientFundsError) ShortfallCents() int64 {
+	return e.RequiredCents - (e.BalanceCents + e.OverdraftLimitCents)
+}
+
+// IsTransient reports whether err is a database failure that is likely to
+// succeed if retried later: a connection failure (see
+// database.IsConnectionFailure) or a call refused by the open circuit breaker
+func IsTransient(err error) bool {
+	return database.IsConnectionFailure(err) || errors.Is(err, database.ErrCircuitOpen)
+}
+
+// IsLockTimeout reports whether err is Postgres giving up on a row lock after
+// lock_timeout (SQLSTATE 55P03), meaning another transaction held the lock
+func IsLockTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "55P03"
+}
+
+// IsStatementTimeout reports whether err is Postgres cancelling a statement
+// that ran past statement_timeout (SQLSTATE 57014, which a cancel request
+// also raises, with a different message)
+func IsStatementTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "57014" && strings.Contains(pgErr.Message, "statement timeout")
+}
+
+// IsSerializationFailure reports whether err is Postgres aborting a
+// transaction because it conflicted with a concurrent one (SQLSTATE 40001,
+// or 40P01 for a deadlock). The whole transaction can be retried.
+func IsSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "40001" || pgErr.Code == "40P01")
+}
+
+// ErrBalanceOutOfRange is returned when a stored balance doesn't fit in an
+// int64 number of cents, as can happen once balance_cents is NUMERIC
+var ErrBalanceOutOfRange = errors.New("balance out of range")
@@ -1,239 +1,2264 @@
-package account
-
-import (
-	"context"
-	"fmt"
-	"strings"
-
-	"apex-ledger/pkg/api"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-)
-
-// Service defines the interface for ledger operations
-type Service interface {
-	PerformTransfer(ctx context.Context, from, to string, amount int64) (string, error)
-	GetBalance(ctx context.Context, accountID string) (*Account, error)
-	CreateAccount(ctx context.Context, id string, balanceCents int64, currency string) (*Account, error)
-	GetAccount(ctx context.Context, accountID string) (*Account, error)
-	UpdateAccount(ctx context.Context, accountID string, currency string) (*Account, error)
-	DeleteAccount(ctx context.Context, accountID string) error
-	ListAccounts(ctx context.Context, limit, offset int) ([]Account, int, error)
-}
-
-// Handler implements the gRPC LedgerService
-type Handler struct {
-	api.UnimplementedLedgerServiceServer
-	service Service
-}
-
-// NewHandler creates a new account handler
-func NewHandler(s Service) *Handler {
-	return &Handler{service: s}
-}
-
-// Transfer handles the Transfer gRPC call
-func (h *Handler) Transfer(ctx context.Context, req *api.TransferRequest) (*api.TransferResponse, error) {
-	// 1. Basic Validation
-	if req.FromAccountId == "" {
-		return nil, status.Error(codes.InvalidArgument, "from_account_id is required")
-	}
-	if req.ToAccountId == "" {
-		return nil, status.Error(codes.InvalidArgument, "to_account_id is required")
-	}
-	if req.AmountCents <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
-	}
-	if req.Currency == "" {
-		return nil, status.Error(codes.InvalidArgument, "currency is required")
-	}
-
-	// 2. Call Service Layer
-	txID, err := h.service.PerformTransfer(ctx, req.FromAccountId, req.ToAccountId, req.AmountCents)
-	if err != nil {
-		// Map internal errors to appropriate gRPC codes
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-		if strings.Contains(err.Error(), "insufficient funds") {
-			return nil, status.Error(codes.FailedPrecondition, err.Error())
-		}
-		if strings.Contains(err.Error(), "currency mismatch") || strings.Contains(err.Error(), "cannot be empty") {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "transfer failed: %v", err)
-	}
-
-	return &api.TransferResponse{
-		TransactionId: txID,
-		Status:        "SUCCESS",
-	}, nil
-}
-
-// GetBalance handles the GetBalance gRPC call
-func (h *Handler) GetBalance(ctx context.Context, req *api.BalanceRequest) (*api.BalanceResponse, error) {
-	// 1. Basic Validation
-	if req.AccountId == "" {
-		return nil, status.Error(codes.InvalidArgument, "account_id is required")
-	}
-
-	// 2. Call Service Layer
-	acc, err := h.service.GetBalance(ctx, req.AccountId)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get balance: %v", err)
-	}
-
-	return &api.BalanceResponse{
-		BalanceCents: acc.BalanceCents,
-		Currency:     acc.Currency,
-	}, nil
-}
-
-// CreateAccount handles the CreateAccount gRPC call
-func (h *Handler) CreateAccount(ctx context.Context, req *api.CreateAccountRequest) (*api.CreateAccountResponse, error) {
-	// Validation
-	if req.Currency == "" {
-		return nil, status.Error(codes.InvalidArgument, "currency is required")
-	}
-
-	// Set defaults
-	id := req.Id // If empty, service will generate UUID
-	balanceCents := req.InitialBalanceCents
-	if balanceCents < 0 {
-		return nil, status.Error(codes.InvalidArgument, "initial balance cannot be negative")
-	}
-
-	// Call service
-	acc, err := h.service.CreateAccount(ctx, id, balanceCents, req.Currency)
-	if err != nil {
-		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "duplicate") {
-			return nil, status.Error(codes.AlreadyExists, err.Error())
-		}
-		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must be") {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "failed to create account: %v", err)
-	}
-
-	return &api.CreateAccountResponse{
-		AccountId:     acc.ID,
-		BalanceCents: acc.BalanceCents,
-		Currency:     acc.Currency,
-		Status:       "CREATED",
-	}, nil
-}
-
-// GetAccount handles the GetAccount gRPC call
-func (h *Handler) GetAccount(ctx context.Context, req *api.GetAccountRequest) (*api.GetAccountResponse, error) {
-	// Validation
-	if req.AccountId == "" {
-		return nil, status.Error(codes.InvalidArgument, "account_id is required")
-	}
-
-	// Call service
-	acc, err := h.service.GetAccount(ctx, req.AccountId)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get account: %v", err)
-	}
-
-	return &api.GetAccountResponse{
-		AccountId:     acc.ID,
-		BalanceCents:  acc.BalanceCents,
-		Currency:      acc.Currency,
-		CreatedAt:     acc.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:     acc.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}, nil
-}
-
-// UpdateAccount handles the UpdateAccount gRPC call
-func (h *Handler) UpdateAccount(ctx context.Context, req *api.UpdateAccountRequest) (*api.UpdateAccountResponse, error) {
-	// Validation
-	if req.AccountId == "" {
-		return nil, status.Error(codes.InvalidArgument, "account_id is required")
-	}
-	if req.Currency == "" {
-		return nil, status.Error(codes.InvalidArgument, "currency is required")
-	}
-
-	// Call service
-	acc, err := h.service.UpdateAccount(ctx, req.AccountId, req.Currency)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must be") {
-			return nil, status.Error(codes.InvalidArgument, err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update account: %v", err)
-	}
-
-	return &api.UpdateAccountResponse{
-		AccountId: acc.ID,
-		Currency:  acc.Currency,
-		Status:    "UPDATED",
-	}, nil
-}
-
-// DeleteAccount handles the DeleteAccount gRPC call
-func (h *Handler) DeleteAccount(ctx context.Context, req *api.DeleteAccountRequest) (*api.DeleteAccountResponse, error) {
-	// Validation
-	if req.AccountId == "" {
-		return nil, status.Error(codes.InvalidArgument, "account_id is required")
-	}
-
-	// Call service
-	err := h.service.DeleteAccount(ctx, req.AccountId)
-	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			return nil, status.Error(codes.NotFound, err.Error())
-		}
-		return nil, status.Errorf(codes.Internal, "failed to delete account: %v", err)
-	}
-
-	return &api.DeleteAccountResponse{
-		AccountId: req.AccountId,
-		Status:    "DELETED",
-	}, nil
-}
-
-// ListAccounts handles the ListAccounts gRPC call
-func (h *Handler) ListAccounts(ctx context.Context, req *api.ListAccountsRequest) (*api.ListAccountsResponse, error) {
-	// Set defaults
-	limit := int(req.Limit)
-	if limit <= 0 {
-		limit = 100
-	}
-	offset := int(req.Offset)
-	if offset < 0 {
-		offset = 0
-	}
-
-	// Call service
-	accounts, total, err := h.service.ListAccounts(ctx, limit, offset)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list accounts: %v", err)
-	}
-
-	// Convert to response
-	accountResponses := make([]*api.GetAccountResponse, len(accounts))
-	for i, acc := range accounts {
-		accountResponses[i] = &api.GetAccountResponse{
-			AccountId:    acc.ID,
-			BalanceCents: acc.BalanceCents,
-			Currency:     acc.Currency,
-			CreatedAt:    acc.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt:    acc.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		}
-	}
-
-	return &api.ListAccountsResponse{
-		Accounts: accountResponses,
-		Total:    int32(total),
-	}, nil
-}
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"apex-ledger/internal/auth"
+	"apex-ledger/internal/platform/database"
+	"apex-ledger/internal/platform/grpcerr"
+	"apex-ledger/pkg/api"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service defines the interface for ledger operations
+type Service interface {
+	PerformTransfer(ctx context.Context, from, to string, amount int64) (string, error)
+	PerformTransferWithOptions(ctx context.Context, from, to string, amount int64, opts TransferOptions) (string, int64, error)
+	GetBalance(ctx context.Context, accountID string) (*Account, error)
+	CreateAccount(ctx context.Context, id, ownerID string, balanceCents int64, currency, accountType string) (*Account, error)
+	ListCurrencies() []Currency
+	WatchBalance(ctx context.Context, accountID string, send func(BalanceUpdate) error) error
+	GetAccount(ctx context.Context, accountID string) (*Account, error)
+	UpdateAccount(ctx context.Context, accountID string, currency string) (*Account, error)
+	DeleteAccount(ctx context.Context, accountID string) error
+	ActivateAccount(ctx context.Context, accountID string) (*Account, error)
+	SetNotificationPreference(ctx context.Context, accountID, channel string) (*Account, error)
+	SetAccountSensitive(ctx context.Context, accountID string, sensitive bool) (*Account, error)
+	ListAccounts(ctx context.Context, limit, offset int) ([]Account, int64, error)
+	GetTransactionHistory(ctx context.Context, accountID, category string, pageSize int, pageToken string) ([]Transaction, string, error)
+	ReadEvents(ctx context.Context, accountID string, fromSeq int64, limit int) ([]LedgerEvent, error)
+	ListAccountsAfter(ctx context.Context, afterID, currency string, limit int) ([]Account, error)
+	ListTransactionsAfter(ctx context.Context, filter TransactionFilter, after *HistoryCursor, limit int) ([]Transaction, error)
+	GetAccountsByOwner(ctx context.Context, ownerID string, limit, offset int) ([]Account, int64, error)
+	AdjustBalance(ctx context.Context, accountID string, deltaCents int64, reason, actorID string) (string, *Account, error)
+	BulkAdjustBalance(ctx context.Context, adjs []BalanceAdjustment, actorID string) ([]AdjustmentResult, error)
+	ReverseTransfer(ctx context.Context, transactionID string, amountCents int64, reason, actorID string) (reversal, original *Transaction, err error)
+	ReverseTransfersInWindow(ctx context.Context, from, to time.Time, accountID, reason, actorID string) ([]WindowReversal, error)
+	GetTransfer(ctx context.Context, transactionID string) (*Transaction, error)
+	GetTransaction(ctx context.Context, transactionID string) (*Transaction, error)
+	Deposit(ctx context.Context, accountID string, amountCents int64, currency, ref, actorID string) (t *Transaction, duplicate bool, err error)
+	ImportAccounts(ctx context.Context, accs []Account) ([]error, error)
+	BatchTransfer(ctx context.Context, entries []TransferEntry) ([]string, error)
+	GetConversionQuote(ctx context.Context, fromCurrency, toCurrency string, amount int64) (*ConversionQuote, error)
+	CrossCurrencyTransfer(ctx context.Context, fromID, toID string, amount int64, quoteID string) (*Transaction, error)
+	ListAccountsByCurrency(ctx context.Context, currency string, limit, offset int) ([]Account, int64, int64, error)
+	BatchGetBalance(ctx context.Context, accountIDs []string) (found []Account, notFound, invalid []string, err error)
+	LookupAccounts(ctx context.Context, accountIDs []string) ([]Account, error)
+	GetBalanceAsOf(ctx context.Context, accountID string, asOf time.Time) (*Account, int64, error)
+	SetParentAccount(ctx context.Context, accountID, parentID string) (*Account, error)
+	GetAggregateBalance(ctx context.Context, accountID string) ([]CurrencyBalance, int, error)
+	ListDeadLetters(ctx context.Context, pageSize int, pageToken string) ([]DeadLetter, string, error)
+	RetryDeadLetters(ctx context.Context, ids []int64, limit int) ([]DeadLetter, error)
+	QueryAuditLog(ctx context.Context, filter AuditFilter, pageSize int, pageToken string) ([]AuditEntry, string, error)
+}
+
+// exportPageSize is the number of accounts read and sent per export chunk,
+// unless the page-size ceiling is lower (see exportPage)
+const exportPageSize = 500
+
+// importBatchSize is the number of streamed records inserted per transaction
+const importBatchSize = 1000
+
+// adjustBatchSize is the number of uploaded adjustments applied per transaction
+const adjustBatchSize = 500
+
+// adjustCSVHeader is the header row BulkAdjustBalance uploads must start with
+var adjustCSVHeader = []string{"account_id", "delta_cents", "reason"}
+
+// DefaultMaxPageSize is the most rows one page of a listing RPC returns
+// unless configured otherwise
+const DefaultMaxPageSize = 1000
+
+// maxBatchBalanceIDs caps the number of accounts one BatchGetBalance may read
+const maxBatchBalanceIDs = 1000
+
+// ServerInfo is the public description of the running server
+type ServerInfo struct {
+	Version   string
+	StartedAt time.Time
+	Features  []string
+}
+
+// Handler implements the gRPC LedgerService
+type Handler struct {
+	api.UnimplementedLedgerServiceServer
+	service    Service
+	info       ServerInfo
+	timeLayout string
+
+	// crossCurrency reports whether CrossCurrencyTransfer is enabled
+	crossCurrency bool
+
+	// jwtKeys is rotated by RotateJWTSecret; rotationGrace is the default
+	// time the outgoing secret stays valid
+	jwtKeys       *auth.Keyring
+	rotationGrace time.Duration
+
+	// maxPageSize caps listing page sizes; larger requests are clamped, or
+	// rejected when rejectLargePages is set
+	maxPageSize      int
+	rejectLargePages bool
+
+	// redaction hides account fields from callers who don't own the account
+	redaction RedactionPolicy
+}
+
+// HandlerOption configures optional Handler behaviour
+type HandlerOption func(*Handler)
+
+// WithServerInfo sets what GetServerInfo reports
+func WithServerInfo(info ServerInfo) HandlerOption {
+	return func(h *Handler) {
+		h.info = info
+	}
+}
+
+// WithCrossCurrency tells clients declined for a currency mismatch that
+// CrossCurrencyTransfer is available, instead of asking them to convert first
+func WithCrossCurrency() HandlerOption {
+	return func(h *Handler) {
+		h.crossCurrency = true
+	}
+}
+
+// WithJWTKeyring lets RotateJWTSecret rotate keys, keeping outgoing secrets
+// valid for grace unless the request says otherwise
+func WithJWTKeyring(keys *auth.Keyring, grace time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.jwtKeys = keys
+		h.rotationGrace = grace
+	}
+}
+
+// WithTimestampLayout sets the default Go time layout for string timestamps
+// in responses; see ParseTimestampFormat
+func WithTimestampLayout(layout string) HandlerOption {
+	return func(h *Handler) {
+		h.timeLayout = layout
+	}
+}
+
+// WithMaxPageSize caps the page size of listing RPCs at n (default
+// DefaultMaxPageSize). A larger request is served n rows with
+// page_size_clamped set, or fails with InvalidArgument if reject is true.
+func WithMaxPageSize(n int, reject bool) HandlerOption {
+	return func(h *Handler) {
+		h.maxPageSize = n
+		h.rejectLargePages = reject
+	}
+}
+
+// WithRedactionPolicy hides the account fields policy lists from callers
+// who neither own the account nor hold a role that reveals them
+func WithRedactionPolicy(policy RedactionPolicy) HandlerOption {
+	return func(h *Handler) {
+		h.redaction = policy
+	}
+}
+
+// NewHandler creates a new account handler
+func NewHandler(s Service, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		service:    s,
+		info:       ServerInfo{Version: "dev", StartedAt: time.Now()},
+		timeLayout: time.RFC3339,
+
+		maxPageSize: DefaultMaxPageSize,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// GetServerInfo handles the GetServerInfo gRPC call. It is served without
+// authentication, so it must only expose non-sensitive details.
+func (h *Handler) GetServerInfo(ctx context.Context, req *api.GetServerInfoRequest) (*api.GetServerInfoResponse, error) {
+	return &api.GetServerInfoResponse{
+		Version:       h.info.Version,
+		UptimeSeconds: int64(time.Since(h.info.StartedAt).Seconds()),
+		StartedAt:     formatTime(h.info.StartedAt, h.timeLayout),
+		Features:      h.info.Features,
+	}, nil
+}
+
+// ListCurrencies handles the ListCurrencies gRPC call
+func (h *Handler) ListCurrencies(ctx context.Context, req *api.ListCurrenciesRequest) (*api.ListCurrenciesResponse, error) {
+	currencies := h.service.ListCurrencies()
+	resp := &api.ListCurrenciesResponse{
+		Currencies: make([]*api.Currency, len(currencies)),
+	}
+	for i, c := range currencies {
+		resp.Currencies[i] = &api.Currency{
+			Code:     c.Code,
+			Exponent: int32(c.Exponent),
+			Name:     c.Name,
+		}
+	}
+	return resp, nil
+}
+
+// Transfer handles the Transfer gRPC call
+func (h *Handler) Transfer(ctx context.Context, req *api.TransferRequest) (*api.TransferResponse, error) {
+	// 1. Basic Validation
+	if req.FromAccountId == "" {
+		return nil, fieldViolation("from_account_id", "from_account_id is required")
+	}
+	if req.ToAccountId == "" {
+		return nil, fieldViolation("to_account_id", "to_account_id is required")
+	}
+	if err := validateAmount("amount_cents", req.AmountCents); err != nil {
+		return nil, err
+	}
+	if req.Currency == "" {
+		return nil, fieldViolation("currency", "currency is required")
+	}
+
+	// 2. Call Service Layer
+	opts := TransferOptions{
+		ExpectedFromSeq: req.ExpectedFromSequence,
+		Category:        req.Category,
+		FromCurrency:    req.FromCurrency,
+		ToCurrency:      req.ToCurrency,
+	}
+	txID, fromSeq, err := h.service.PerformTransferWithOptions(ctx, req.FromAccountId, req.ToAccountId, req.AmountCents, opts)
+	if err != nil {
+		// Map internal errors to appropriate gRPC codes
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		var insufficient *InsufficientFundsError
+		if errors.As(err, &insufficient) {
+			return nil, insufficientFundsStatus(insufficient)
+		}
+		if errors.Is(err, ErrAccountNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, ErrBalanceSign) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, ErrSequenceMismatch) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+		if errors.Is(err, ErrCurrencyAssertion) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, ErrInvalidCategory) {
+			return nil, fieldViolation("category", err.Error())
+		}
+		if errors.Is(err, ErrInvalidDenomination) {
+			return nil, fieldViolation("amount_cents", err.Error())
+		}
+		var mismatch *CurrencyMismatchError
+		if errors.As(err, &mismatch) {
+			return nil, h.currencyMismatchStatus(mismatch)
+		}
+		if strings.Contains(err.Error(), "cannot be empty") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, internalError(err, "transfer failed")
+	}
+
+	resp := &api.TransferResponse{
+		TransactionId:  txID,
+		Status:         "SUCCESS",
+		TransferStatus: api.TransferStatus_TRANSFER_STATUS_SETTLED,
+	}
+	if req.ExpectedFromSequence != nil {
+		resp.FromSequence = fromSeq
+	}
+	return resp, nil
+}
+
+// BatchTransfer handles the BatchTransfer gRPC call
+func (h *Handler) BatchTransfer(ctx context.Context, req *api.BatchTransferRequest) (*api.BatchTransferResponse, error) {
+	// Validation
+	if len(req.Transfers) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one transfer is required")
+	}
+	entries := make([]TransferEntry, len(req.Transfers))
+	for i, t := range req.Transfers {
+		if t.FromAccountId == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "transfers[%d]: from_account_id is required", i)
+		}
+		if t.ToAccountId == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "transfers[%d]: to_account_id is required", i)
+		}
+		if err := validateAmount(fmt.Sprintf("transfers[%d].amount_cents", i), t.AmountCents); err != nil {
+			return nil, err
+		}
+		if t.Currency == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "transfers[%d]: currency is required", i)
+		}
+		entries[i] = TransferEntry{
+			FromID:       t.FromAccountId,
+			ToID:         t.ToAccountId,
+			AmountCents:  t.AmountCents,
+			Category:     t.Category,
+			FromCurrency: t.FromCurrency,
+			ToCurrency:   t.ToCurrency,
+		}
+	}
+
+	// Call service
+	txIDs, err := h.service.BatchTransfer(ctx, entries)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		var insufficient *InsufficientFundsError
+		if errors.As(err, &insufficient) {
+			return nil, insufficientFundsStatus(insufficient)
+		}
+		if errors.Is(err, ErrAccountNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, ErrBalanceSign) || errors.Is(err, ErrCurrencyAssertion) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		var mismatch *CurrencyMismatchError
+		if errors.As(err, &mismatch) {
+			return nil, h.currencyMismatchStatus(mismatch)
+		}
+		if strings.Contains(err.Error(), "same account") || errors.Is(err, ErrAmountOverflow) || errors.Is(err, ErrInvalidDenomination) ||
+			errors.Is(err, ErrInvalidCategory) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, internalError(err, "batch transfer failed")
+	}
+
+	return &api.BatchTransferResponse{
+		TransactionIds: txIDs,
+		Status:         "SUCCESS",
+		TransferStatus: api.TransferStatus_TRANSFER_STATUS_SETTLED,
+	}, nil
+}
+
+// GetConversionQuote handles the GetConversionQuote gRPC call
+func (h *Handler) GetConversionQuote(ctx context.Context, req *api.ConversionQuoteRequest) (*api.ConversionQuoteResponse, error) {
+	// Validation
+	if req.FromCurrency == "" || req.ToCurrency == "" {
+		return nil, status.Error(codes.InvalidArgument, "from_currency and to_currency are required")
+	}
+	if err := validateAmount("amount_cents", req.AmountCents); err != nil {
+		return nil, err
+	}
+
+	// Call service
+	q, err := h.service.GetConversionQuote(ctx, req.FromCurrency, req.ToCurrency, req.AmountCents)
+	if err != nil {
+		return nil, fxStatus(err, "failed to get quote")
+	}
+
+	return &api.ConversionQuoteResponse{
+		QuoteId:              q.ID,
+		FromCurrency:         q.FromCurrency,
+		ToCurrency:           q.ToCurrency,
+		AmountCents:          q.AmountCents,
+		ConvertedAmountCents: q.ConvertedCents,
+		Rate:                 q.Rate,
+		ExpiresAt:            formatTime(q.ExpiresAt, h.timeLayout),
+	}, nil
+}
+
+// CrossCurrencyTransfer handles the CrossCurrencyTransfer gRPC call
+func (h *Handler) CrossCurrencyTransfer(ctx context.Context, req *api.CrossCurrencyTransferRequest) (*api.CrossCurrencyTransferResponse, error) {
+	// Validation
+	if req.FromAccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "from_account_id is required")
+	}
+	if req.ToAccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "to_account_id is required")
+	}
+	if err := validateAmount("amount_cents", req.AmountCents); err != nil {
+		return nil, err
+	}
+
+	// Call service
+	t, err := h.service.CrossCurrencyTransfer(ctx, req.FromAccountId, req.ToAccountId, req.AmountCents, req.QuoteId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") && !errors.Is(err, ErrQuoteNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		var insufficient *InsufficientFundsError
+		if errors.As(err, &insufficient) {
+			return nil, insufficientFundsStatus(insufficient)
+		}
+		if errors.Is(err, ErrAccountNotActive) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, ErrBalanceSign) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, fxStatus(err, "transfer failed")
+	}
+
+	resp := &api.CrossCurrencyTransferResponse{
+		TransactionId:  t.ID,
+		Status:         "SUCCESS",
+		TransferStatus: api.TransferStatus_TRANSFER_STATUS_SETTLED,
+	}
+	if t.ConvertedAmountCents != nil {
+		resp.ConvertedAmountCents = *t.ConvertedAmountCents
+	}
+	if t.ExchangeRate != nil {
+		resp.Rate = *t.ExchangeRate
+	}
+	resp.Rounding = t.FXRounding
+	if t.FXRoundingAdjustment != nil {
+		resp.RoundingAdjustment = *t.FXRoundingAdjustment
+	}
+	return resp, nil
+}
+
+// GetBalance handles the GetBalance gRPC call
+func (h *Handler) GetBalance(ctx context.Context, req *api.BalanceRequest) (*api.BalanceResponse, error) {
+	// 1. Basic Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	// 2. Call Service Layer
+	acc, err := h.service.GetBalance(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to get balance")
+	}
+
+	return &api.BalanceResponse{
+		BalanceCents: acc.BalanceCents,
+		Currency:     acc.Currency,
+	}, nil
+}
+
+// GetBalanceAsOf handles the GetBalanceAsOf gRPC call
+func (h *Handler) GetBalanceAsOf(ctx context.Context, req *api.BalanceAsOfRequest) (*api.BalanceAsOfResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	asOf, err := time.Parse(time.RFC3339Nano, req.AsOf)
+	if err != nil {
+		return nil, fieldViolation("as_of", "must be an RFC 3339 timestamp")
+	}
+
+	// Call service
+	acc, balance, err := h.service.GetBalanceAsOf(ctx, req.AccountId, asOf)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to get balance")
+	}
+
+	return &api.BalanceAsOfResponse{
+		AccountId:    acc.ID,
+		BalanceCents: balance,
+		Currency:     acc.Currency,
+		AsOf:         formatTime(asOf, h.timeLayout),
+		AsOfUnixMs:   asOf.UnixMilli(),
+	}, nil
+}
+
+// BatchGetBalance handles the BatchGetBalance gRPC call
+func (h *Handler) BatchGetBalance(ctx context.Context, req *api.BatchGetBalanceRequest) (*api.BatchGetBalanceResponse, error) {
+	// Validation
+	if len(req.AccountIds) == 0 {
+		return nil, fieldViolation("account_ids", "at least one account ID is required")
+	}
+	if len(req.AccountIds) > maxBatchBalanceIDs {
+		return nil, fieldViolation("account_ids", fmt.Sprintf("at most %d account IDs may be requested at once", maxBatchBalanceIDs))
+	}
+
+	// Call service; malformed IDs, empty ones included, come back in invalid
+	accs, notFound, invalid, err := h.service.BatchGetBalance(ctx, req.AccountIds)
+	if err != nil {
+		return nil, internalError(err, "failed to get balances")
+	}
+
+	balances := make([]*api.AccountBalance, len(accs))
+	for i, acc := range accs {
+		balances[i] = &api.AccountBalance{
+			AccountId:    acc.ID,
+			BalanceCents: acc.BalanceCents,
+			Currency:     acc.Currency,
+		}
+	}
+
+	return &api.BatchGetBalanceResponse{
+		Balances:    balances,
+		NotFoundIds: notFound,
+		InvalidIds:  invalid,
+	}, nil
+}
+
+// CreateAccount handles the CreateAccount gRPC call
+func (h *Handler) CreateAccount(ctx context.Context, req *api.CreateAccountRequest) (*api.CreateAccountResponse, error) {
+	// Set defaults; an empty currency falls back to the service default
+	id := req.Id // If empty, service will generate UUID
+	balanceCents := req.InitialBalanceCents
+	if balanceCents < 0 {
+		return nil, fieldViolation("initial_balance_cents", "initial balance cannot be negative")
+	}
+
+	// Accounts belong to the caller. Only admins may name another owner:
+	// anyone else could otherwise charge accounts to a stranger, or spread
+	// them over throwaway owners to get past the per-owner cap
+	ownerID := req.OwnerId
+	if ownerID != "" {
+		if err := authorizeOwner(ctx, ownerID); err != nil {
+			return nil, err
+		}
+	} else if user, ok := auth.UserFromContext(ctx); ok {
+		ownerID = user.ID
+	}
+
+	// Call service
+	acc, err := h.service.CreateAccount(ctx, id, ownerID, balanceCents, req.Currency, req.AccountType)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAccountType) {
+			return nil, fieldViolation("account_type", err.Error())
+		}
+		if errors.Is(err, ErrBalanceSign) {
+			return nil, fieldViolation("initial_balance_cents", err.Error())
+		}
+		if errors.Is(err, ErrAccountExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		if errors.Is(err, ErrAccountLimitReached) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if errors.Is(err, ErrInvalidAccountID) {
+			return nil, fieldViolation("id", err.Error())
+		}
+		if errors.Is(err, ErrOwnerRequired) {
+			return nil, fieldViolation("owner_id", "owner_id is required when the caller's token has no subject")
+		}
+		if strings.Contains(err.Error(), "currency") {
+			return nil, fieldViolation("currency", err.Error())
+		}
+		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must be") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, internalError(err, "failed to create account")
+	}
+
+	return &api.CreateAccountResponse{
+		AccountId:     acc.ID,
+		BalanceCents:  acc.BalanceCents,
+		Currency:      acc.Currency,
+		Status:        "CREATED",
+		OwnerId:       acc.OwnerID,
+		AccountStatus: acc.Status,
+		AccountType:   acc.Type,
+	}, nil
+}
+
+// GetAccount handles the GetAccount gRPC call
+func (h *Handler) GetAccount(ctx context.Context, req *api.GetAccountRequest) (*api.GetAccountResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	acc, err := h.service.GetAccount(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to get account")
+	}
+
+	return h.accountResponse(ctx, acc, layout), nil
+}
+
+// UpdateAccount handles the UpdateAccount gRPC call
+func (h *Handler) UpdateAccount(ctx context.Context, req *api.UpdateAccountRequest) (*api.UpdateAccountResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, fieldViolation("account_id", "account_id is required")
+	}
+	if req.Currency == "" {
+		return nil, fieldViolation("currency", "currency is required")
+	}
+
+	// Call service
+	acc, err := h.service.UpdateAccount(ctx, req.AccountId, req.Currency)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, ErrCurrencyLocked) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if strings.Contains(err.Error(), "currency") {
+			return nil, fieldViolation("currency", err.Error())
+		}
+		if strings.Contains(err.Error(), "required") || strings.Contains(err.Error(), "must be") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, internalError(err, "failed to update account")
+	}
+
+	return &api.UpdateAccountResponse{
+		AccountId: acc.ID,
+		Currency:  acc.Currency,
+		Status:    "UPDATED",
+	}, nil
+}
+
+// DeleteAccount handles the DeleteAccount gRPC call
+func (h *Handler) DeleteAccount(ctx context.Context, req *api.DeleteAccountRequest) (*api.DeleteAccountResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	// Call service
+	err := h.service.DeleteAccount(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, internalError(err, "failed to delete account")
+	}
+
+	return &api.DeleteAccountResponse{
+		AccountId: req.AccountId,
+		Status:    "DELETED",
+	}, nil
+}
+
+// ActivateAccount handles the ActivateAccount gRPC call. Only admins can
+// activate an account, typically once its owner has passed KYC.
+func (h *Handler) ActivateAccount(ctx context.Context, req *api.ActivateAccountRequest) (*api.ActivateAccountResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.AccountId == "" {
+		return nil, fieldViolation("account_id", "account_id is required")
+	}
+
+	// Call service
+	acc, err := h.service.ActivateAccount(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, internalError(err, "failed to activate account")
+	}
+
+	return &api.ActivateAccountResponse{
+		AccountId:     acc.ID,
+		AccountStatus: acc.Status,
+	}, nil
+}
+
+// SetNotificationPreference handles the SetNotificationPreference gRPC
+// call. Owners may set it for their own accounts, admins for any.
+func (h *Handler) SetNotificationPreference(ctx context.Context, req *api.SetNotificationPreferenceRequest) (*api.SetNotificationPreferenceResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, fieldViolation("account_id", "account_id is required")
+	}
+	acc, err := h.service.GetAccount(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to get account")
+	}
+	if err := authorizeOwner(ctx, acc.OwnerID); err != nil {
+		return nil, err
+	}
+
+	// Call service
+	acc, err = h.service.SetNotificationPreference(ctx, req.AccountId, req.Channel)
+	if err != nil {
+		if errors.Is(err, ErrInvalidNotificationChannel) {
+			return nil, fieldViolation("channel", err.Error())
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, internalError(err, "failed to set notification preference")
+	}
+
+	return &api.SetNotificationPreferenceResponse{
+		AccountId: acc.ID,
+		Channel:   acc.NotificationChannel,
+	}, nil
+}
+
+// SetAccountSensitive handles the SetAccountSensitive gRPC call. Only admins
+// can change which accounts have their reads audited.
+func (h *Handler) SetAccountSensitive(ctx context.Context, req *api.SetAccountSensitiveRequest) (*api.SetAccountSensitiveResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.AccountId == "" {
+		return nil, fieldViolation("account_id", "account_id is required")
+	}
+
+	// Call service
+	acc, err := h.service.SetAccountSensitive(ctx, req.AccountId, req.Sensitive)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, internalError(err, "failed to set sensitive flag")
+	}
+
+	return &api.SetAccountSensitiveResponse{
+		AccountId: acc.ID,
+		Sensitive: acc.Sensitive,
+	}, nil
+}
+
+// ListAccounts handles the ListAccounts gRPC call
+func (h *Handler) ListAccounts(ctx context.Context, req *api.ListAccountsRequest) (*api.ListAccountsResponse, error) {
+	// Set defaults
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 100
+	}
+	limit, clamped, err := h.pageSize("limit", limit)
+	if err != nil {
+		return nil, err
+	}
+	offset := int(req.Offset)
+	if offset < 0 {
+		offset = 0
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	accounts, total, err := h.service.ListAccounts(ctx, limit, offset)
+	if err != nil {
+		return nil, internalError(err, "failed to list accounts")
+	}
+
+	// Convert to response
+	accountResponses := make([]*api.GetAccountResponse, len(accounts))
+	for i := range accounts {
+		accountResponses[i] = h.accountResponse(ctx, &accounts[i], layout)
+	}
+
+	return &api.ListAccountsResponse{
+		Accounts:        accountResponses,
+		Total:           total,
+		PageSizeClamped: clamped,
+	}, nil
+}
+
+// GetTransactionHistory handles the GetTransactionHistory gRPC call
+func (h *Handler) GetTransactionHistory(ctx context.Context, req *api.TransactionHistoryRequest) (*api.TransactionHistoryResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, clamped, err := h.pageSize("page_size", int(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	txns, nextToken, err := h.service.GetTransactionHistory(ctx, req.AccountId, req.Category, pageSize, req.PageToken)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		if strings.Contains(err.Error(), "invalid page token") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, internalError(err, "failed to get transaction history")
+	}
+
+	// Convert to response
+	transactions := make([]*api.Transaction, len(txns))
+	for i := range txns {
+		transactions[i] = toTransactionResponse(&txns[i], layout)
+	}
+
+	return &api.TransactionHistoryResponse{
+		Transactions:    transactions,
+		NextPageToken:   nextToken,
+		PageSizeClamped: clamped,
+	}, nil
+}
+
+// ReadEvents handles the ReadEvents gRPC call
+func (h *Handler) ReadEvents(ctx context.Context, req *api.ReadEventsRequest) (*api.ReadEventsResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	if req.FromSeq < 0 {
+		return nil, fieldViolation("from_seq", "must not be negative")
+	}
+	if req.Limit < 0 {
+		return nil, fieldViolation("limit", "must not be negative")
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	events, err := h.service.ReadEvents(ctx, req.AccountId, req.FromSeq, int(req.Limit))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to read events")
+	}
+
+	// Convert to response
+	resp := &api.ReadEventsResponse{
+		Events:  make([]*api.LedgerEvent, len(events)),
+		LastSeq: req.FromSeq,
+	}
+	for i, e := range events {
+		out := &api.LedgerEvent{
+			AccountId:     e.AccountID,
+			Seq:           e.Seq,
+			TransactionId: e.TransactionID,
+			Kind:          e.Kind,
+			DeltaCents:    e.DeltaCents,
+			Currency:      e.Currency,
+			CreatedAt:     formatTime(e.CreatedAt, layout),
+		}
+		if e.BalanceAfter != nil {
+			out.BalanceAfter = *e.BalanceAfter
+			out.HasBalanceAfter = true
+		}
+		resp.Events[i] = out
+		resp.LastSeq = e.Seq
+	}
+	return resp, nil
+}
+
+// GetAccountStatement handles the GetAccountStatement gRPC call
+func (h *Handler) GetAccountStatement(ctx context.Context, req *api.TransactionHistoryRequest) (*api.AccountStatementResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, clamped, err := h.pageSize("page_size", int(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	txns, nextToken, err := h.service.GetTransactionHistory(ctx, req.AccountId, "", pageSize, req.PageToken)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		if strings.Contains(err.Error(), "invalid page token") {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, internalError(err, "failed to get account statement")
+	}
+
+	// Convert to response
+	entries := make([]*api.StatementEntry, len(txns))
+	for i := range txns {
+		entry := &api.StatementEntry{Transaction: toTransactionResponse(&txns[i], layout)}
+		if balance, ok := txns[i].BalanceAfter(req.AccountId); ok {
+			entry.BalanceAfterCents = &balance
+		}
+		entries[i] = entry
+	}
+
+	return &api.AccountStatementResponse{
+		AccountId:       req.AccountId,
+		Entries:         entries,
+		NextPageToken:   nextToken,
+		PageSizeClamped: clamped,
+	}, nil
+}
+
+// exportedTransaction is the JSON form of a transaction in
+// ExportTransactions; optional fields are omitted when unset
+type exportedTransaction struct {
+	ID                    string   `json:"id"`
+	Kind                  string   `json:"kind"`
+	FromAccountID         string   `json:"from_account_id,omitempty"`
+	ToAccountID           string   `json:"to_account_id,omitempty"`
+	AmountCents           int64    `json:"amount_cents"`
+	Currency              string   `json:"currency"`
+	ConvertedAmountCents  *int64   `json:"converted_amount_cents,omitempty"`
+	ConvertedCurrency     string   `json:"converted_currency,omitempty"`
+	ExchangeRate          *float64 `json:"exchange_rate,omitempty"`
+	FromBalanceAfter      *int64   `json:"from_balance_after,omitempty"`
+	ToBalanceAfter        *int64   `json:"to_balance_after,omitempty"`
+	ReversedCents         int64    `json:"reversed_cents,omitempty"`
+	ReversesTransactionID string   `json:"reverses_transaction_id,omitempty"`
+	ExternalReference     string   `json:"external_reference,omitempty"`
+	Category              string   `json:"category,omitempty"`
+	Reason                string   `json:"reason,omitempty"`
+	ActorID               string   `json:"actor_id,omitempty"`
+	CreatedAt             string   `json:"created_at"`
+}
+
+// lineSender is an io.Writer that sends each write as one
+// ExportTransactionsLine. json.Encoder writes each encoded value, newline
+// included, in a single call, so every message carries exactly one line.
+type lineSender struct {
+	stream api.LedgerService_ExportTransactionsServer
+}
+
+func (w lineSender) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&api.ExportTransactionsLine{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ExportTransactions handles the ExportTransactions gRPC call. Admins may
+// export everything; other callers must name an account they own.
+func (h *Handler) ExportTransactions(req *api.ExportTransactionsRequest, stream api.LedgerService_ExportTransactionsServer) error {
+	ctx := stream.Context()
+
+	// Validation
+	filter := TransactionFilter{AccountID: req.AccountId, Currency: strings.ToUpper(req.Currency)}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339Nano, req.Since)
+		if err != nil {
+			return fieldViolation("since", "must be an RFC 3339 timestamp")
+		}
+		filter.Since = since
+	}
+	if req.Until != "" {
+		until, err := time.Parse(time.RFC3339Nano, req.Until)
+		if err != nil {
+			return fieldViolation("until", "must be an RFC 3339 timestamp")
+		}
+		filter.Until = until
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return fieldViolation("until", "until must be after since")
+	}
+
+	if user, ok := auth.UserFromContext(ctx); !ok || !user.IsAdmin() {
+		if req.AccountId == "" {
+			return status.Error(codes.PermissionDenied, "admin role required to export all accounts' transactions")
+		}
+		acc, err := h.service.GetAccount(ctx, req.AccountId)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				return status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+			}
+			return internalError(err, "failed to get account")
+		}
+		if err := authorizeOwner(ctx, acc.OwnerID); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(lineSender{stream: stream})
+	page := h.exportPage()
+	var after *HistoryCursor
+	for {
+		// Stop promptly if the client has gone away; the next query would
+		// be cancelled with ctx anyway
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		txns, err := h.service.ListTransactionsAfter(ctx, filter, after, page)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return internalError(err, "failed to export transactions")
+		}
+
+		for _, t := range txns {
+			err := enc.Encode(exportedTransaction{
+				ID:                    t.ID,
+				Kind:                  t.Kind,
+				FromAccountID:         t.FromAccountID,
+				ToAccountID:           t.ToAccountID,
+				AmountCents:           t.AmountCents,
+				Currency:              t.Currency,
+				ConvertedAmountCents:  t.ConvertedAmountCents,
+				ConvertedCurrency:     t.ConvertedCurrency,
+				ExchangeRate:          t.ExchangeRate,
+				FromBalanceAfter:      t.FromBalanceAfter,
+				ToBalanceAfter:        t.ToBalanceAfter,
+				ReversedCents:         t.ReversedCents,
+				ReversesTransactionID: t.ReversesTransactionID,
+				ExternalReference:     t.ExternalReference,
+				Category:              t.Category,
+				Reason:                t.Reason,
+				ActorID:               t.ActorID,
+				CreatedAt:             t.CreatedAt.UTC().Format(time.RFC3339Nano),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(txns) < page {
+			return nil
+		}
+		last := txns[len(txns)-1]
+		after = &HistoryCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// WatchBalance handles the WatchBalance gRPC call. Callers may only watch
+// their own accounts unless they are admins.
+func (h *Handler) WatchBalance(req *api.WatchBalanceRequest, stream api.LedgerService_WatchBalanceServer) error {
+	ctx := stream.Context()
+
+	// Validation
+	if req.AccountId == "" {
+		return status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return err
+	}
+
+	acc, err := h.service.GetAccount(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return internalError(err, "failed to get account")
+	}
+	if err := authorizeOwner(ctx, acc.OwnerID); err != nil {
+		return err
+	}
+
+	err = h.service.WatchBalance(ctx, req.AccountId, func(u BalanceUpdate) error {
+		return stream.Send(&api.BalanceUpdate{
+			AccountId:     u.AccountID,
+			BalanceCents:  u.BalanceCents,
+			Currency:      u.Currency,
+			Seq:           u.Seq,
+			Snapshot:      u.Snapshot,
+			DeltaCents:    u.DeltaCents,
+			TransactionId: u.TransactionID,
+			Kind:          u.Kind,
+			At:            formatTime(u.At, layout),
+		})
+	})
+	// A client disconnect ends the watch; report it as such rather than as
+	// a server failure
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return internalError(err, "failed to watch balance")
+	}
+	return nil
+}
+
+// ExportAccounts handles the ExportAccounts gRPC call. It is an admin-only
+// finance export, since it lists the balance of every account.
+// Accounts are paged through by ID so the export never buffers more than one chunk.
+func (h *Handler) ExportAccounts(req *api.ExportAccountsRequest, stream api.LedgerService_ExportAccountsServer) error {
+	ctx := stream.Context()
+	if _, err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"id", "balance", "currency", "created_at"})
+
+	page := h.exportPage()
+	afterID := ""
+	for {
+		// Stop promptly if the client has gone away
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		accounts, err := h.service.ListAccountsAfter(ctx, afterID, req.Currency, page)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return internalError(err, "failed to export accounts")
+		}
+
+		for _, acc := range accounts {
+			w.Write([]string{
+				acc.ID,
+				strconv.FormatInt(acc.BalanceCents, 10),
+				acc.Currency,
+				formatTime(acc.CreatedAt, h.timeLayout),
+			})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return internalError(err, "failed to encode accounts")
+		}
+
+		if buf.Len() > 0 {
+			if err := stream.Send(&api.ExportAccountsChunk{Data: buf.Bytes()}); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+
+		if len(accounts) < page {
+			return nil
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+}
+
+// ListAccountsByCurrency handles the ListAccountsByCurrency gRPC call
+func (h *Handler) ListAccountsByCurrency(ctx context.Context, req *api.ListAccountsByCurrencyRequest) (*api.ListAccountsByCurrencyResponse, error) {
+	// Validation
+	if req.Currency == "" {
+		return nil, fieldViolation("currency", "currency is required")
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, clamped, err := h.pageSize("limit", int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	accounts, total, totalBalance, err := h.service.ListAccountsByCurrency(ctx, req.Currency, limit, int(req.Offset))
+	if err != nil {
+		return nil, internalError(err, "failed to list accounts")
+	}
+
+	// Convert to response
+	accountResponses := make([]*api.GetAccountResponse, len(accounts))
+	for i := range accounts {
+		accountResponses[i] = h.accountResponse(ctx, &accounts[i], layout)
+	}
+
+	return &api.ListAccountsByCurrencyResponse{
+		Currency:          req.Currency,
+		Accounts:          accountResponses,
+		Total:             total,
+		TotalBalanceCents: totalBalance,
+		PageSizeClamped:   clamped,
+	}, nil
+}
+
+// GetAccountsByOwner handles the GetAccountsByOwner gRPC call
+func (h *Handler) GetAccountsByOwner(ctx context.Context, req *api.GetAccountsByOwnerRequest) (*api.ListAccountsResponse, error) {
+	// Validation
+	if req.OwnerId == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner_id is required")
+	}
+	if err := authorizeOwner(ctx, req.OwnerId); err != nil {
+		return nil, err
+	}
+
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, clamped, err := h.pageSize("limit", int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	accounts, total, err := h.service.GetAccountsByOwner(ctx, req.OwnerId, limit, int(req.Offset))
+	if err != nil {
+		return nil, internalError(err, "failed to list accounts")
+	}
+
+	// Convert to response
+	accountResponses := make([]*api.GetAccountResponse, len(accounts))
+	for i := range accounts {
+		accountResponses[i] = h.accountResponse(ctx, &accounts[i], layout)
+	}
+
+	return &api.ListAccountsResponse{
+		Accounts:        accountResponses,
+		Total:           total,
+		PageSizeClamped: clamped,
+	}, nil
+}
+
+// AdjustBalance handles the AdjustBalance gRPC call. Only admins may adjust
+// balances; the caller is recorded as the actor on the adjustment.
+func (h *Handler) AdjustBalance(ctx context.Context, req *api.AdjustBalanceRequest) (*api.AdjustBalanceResponse, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	if req.DeltaCents == 0 {
+		return nil, status.Error(codes.InvalidArgument, "delta_cents must be non-zero")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	// Call service
+	txID, acc, err := h.service.AdjustBalance(ctx, req.AccountId, req.DeltaCents, req.Reason, user.ID)
+	if err != nil {
+		var insufficient *InsufficientFundsError
+		if errors.As(err, &insufficient) {
+			return nil, insufficientFundsStatus(insufficient)
+		}
+		if errors.Is(err, ErrBalanceSign) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to adjust balance")
+	}
+
+	return &api.AdjustBalanceResponse{
+		TransactionId: txID,
+		AccountId:     acc.ID,
+		BalanceCents:  acc.BalanceCents,
+		Currency:      acc.Currency,
+	}, nil
+}
+
+// chunkReader is an io.Reader over the data of the BulkAdjustBalanceChunks a
+// client streams, so the upload can be parsed as one CSV document
+type chunkReader struct {
+	stream api.LedgerService_BulkAdjustBalanceServer
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// BulkAdjustBalance handles the BulkAdjustBalance gRPC call. Only admins may
+// adjust balances. The upload is parsed as it arrives and applied in
+// transactions of adjustBatchSize rows; a row that can't be applied is
+// reported in its result, and only a malformed upload or a fatal error
+// aborts the call, leaving earlier batches applied.
+func (h *Handler) BulkAdjustBalance(stream api.LedgerService_BulkAdjustBalanceServer) error {
+	ctx := stream.Context()
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+
+	r := csv.NewReader(&chunkReader{stream: stream})
+	r.FieldsPerRecord = len(adjustCSVHeader)
+	header, err := r.Read()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload is empty")
+	}
+	if err != nil {
+		return uploadError(err)
+	}
+	if !slices.Equal(header, adjustCSVHeader) {
+		return status.Errorf(codes.InvalidArgument, "header must be %s", strings.Join(adjustCSVHeader, ","))
+	}
+
+	// A row whose delta doesn't parse is kept in its place with the parse
+	// error and never reaches the service
+	type uploadRow struct {
+		adj BalanceAdjustment
+		err error
+	}
+	resp := &api.BulkAdjustBalanceResponse{}
+	rows := make([]uploadRow, 0, adjustBatchSize)
+
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+		adjs := make([]BalanceAdjustment, 0, len(rows))
+		for _, row := range rows {
+			if row.err == nil {
+				adjs = append(adjs, row.adj)
+			}
+		}
+		var results []AdjustmentResult
+		if len(adjs) > 0 {
+			var err error
+			results, err = h.service.BulkAdjustBalance(ctx, adjs, user.ID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				return internalError(err, fmt.Sprintf("bulk adjustment aborted after %d rows", len(resp.Results)))
+			}
+		}
+		for _, row := range rows {
+			result := &api.AdjustmentResult{
+				Index:     int64(len(resp.Results)),
+				AccountId: row.adj.AccountID,
+			}
+			rowErr := row.err
+			if rowErr == nil {
+				res := results[0]
+				results = results[1:]
+				result.TransactionId = res.TransactionID
+				result.BalanceCents = res.BalanceCents
+				rowErr = res.Err
+			}
+			if rowErr != nil {
+				resp.Failed++
+				result.Error = rowErr.Error()
+			} else {
+				resp.Applied++
+			}
+			resp.Results = append(resp.Results, result)
+		}
+		rows = rows[:0]
+		return nil
+	}
+
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return uploadError(err)
+		}
+
+		adj := BalanceAdjustment{AccountID: strings.TrimSpace(row[0]), Reason: row[2]}
+		delta, err := strconv.ParseInt(strings.TrimSpace(row[1]), 10, 64)
+		if err != nil {
+			rows = append(rows, uploadRow{adj: adj, err: fmt.Errorf("delta_cents %q is not a whole number of cents", row[1])})
+		} else {
+			adj.DeltaCents = delta
+			rows = append(rows, uploadRow{adj: adj})
+		}
+		if len(rows) == adjustBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// uploadError maps a failure reading a CSV upload to a status: malformed
+// CSV is the client's error, anything else came from the stream itself
+func uploadError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return status.Errorf(codes.InvalidArgument, "malformed CSV: %v", parseErr)
+	}
+	return err
+}
+
+// ReverseTransfer handles the ReverseTransfer gRPC call. Only admins may
+// reverse transfers; an amount_cents of zero reverses the remaining amount.
+func (h *Handler) ReverseTransfer(ctx context.Context, req *api.ReverseTransferRequest) (*api.ReverseTransferResponse, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.TransactionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+	if req.AmountCents < 0 {
+		return nil, fieldViolation("amount_cents", "must not be negative")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	// Call service
+	reversal, original, err := h.service.ReverseTransfer(ctx, req.TransactionId, req.AmountCents, req.Reason, user.ID)
+	if err != nil {
+		var insufficient *InsufficientFundsError
+		switch {
+		case errors.As(err, &insufficient):
+			return nil, insufficientFundsStatus(insufficient)
+		case errors.Is(err, ErrTransactionNotFound):
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("transaction %s not found", req.TransactionId))
+		case errors.Is(err, ErrReversalExceedsTotal):
+			return nil, fieldViolation("amount_cents", err.Error())
+		case errors.Is(err, ErrNotReversible), errors.Is(err, ErrBalanceSign), errors.Is(err, ErrAccountNotActive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, internalError(err, "failed to reverse transfer")
+	}
+
+	return &api.ReverseTransferResponse{
+		ReversalTransactionId:    reversal.ID,
+		OriginalTransactionId:    original.ID,
+		AmountCents:              reversal.AmountCents,
+		RemainingReversibleCents: original.ReversibleCents(),
+	}, nil
+}
+
+// ReverseTransfersInWindow handles the ReverseTransfersInWindow gRPC call (admin only)
+func (h *Handler) ReverseTransfersInWindow(ctx context.Context, req *api.ReverseTransfersInWindowRequest) (*api.ReverseTransfersInWindowResponse, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validation
+	from, err := time.Parse(time.RFC3339Nano, req.From)
+	if err != nil {
+		return nil, fieldViolation("from", "must be an RFC 3339 timestamp")
+	}
+	to, err := time.Parse(time.RFC3339Nano, req.To)
+	if err != nil {
+		return nil, fieldViolation("to", "must be an RFC 3339 timestamp")
+	}
+	if !from.Before(to) {
+		return nil, fieldViolation("to", "must be after from")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	// Call service
+	results, err := h.service.ReverseTransfersInWindow(ctx, from, to, req.AccountId, req.Reason, user.ID)
+	if err != nil {
+		return nil, internalError(err, "failed to reverse transfers")
+	}
+
+	resp := &api.ReverseTransfersInWindowResponse{
+		Results: make([]*api.WindowReversal, len(results)),
+	}
+	for i, r := range results {
+		out := &api.WindowReversal{
+			TransactionId:         r.TransactionID,
+			ReversalTransactionId: r.ReversalID,
+			AmountCents:           r.AmountCents,
+		}
+		if r.Err != nil {
+			out.SkipReason = windowSkipReason(r.Err)
+			out.Error = r.Err.Error()
+			resp.SkippedCount++
+		} else {
+			resp.ReversedCount++
+		}
+		resp.Results[i] = out
+	}
+	return resp, nil
+}
+
+// windowSkipReason classifies why a transfer in a bulk reversal was skipped
+func windowSkipReason(err error) string {
+	var insufficient *InsufficientFundsError
+	switch {
+	case errors.As(err, &insufficient):
+		return "insufficient_funds"
+	case errors.Is(err, ErrNotReversible), errors.Is(err, ErrReversalExceedsTotal):
+		return "not_reversible"
+	}
+	return "error"
+}
+
+// minJWTSecretBytes is the shortest secret RotateJWTSecret accepts, the
+// HS256 key size
+const minJWTSecretBytes = 32
+
+// RotateJWTSecret handles the RotateJWTSecret gRPC call. The rotation only
+// applies to this server process; every replica must be rotated, and
+// JWT_SECRET updated so a restart doesn't revert it.
+func (h *Handler) RotateJWTSecret(ctx context.Context, req *api.RotateJWTSecretRequest) (*api.RotateJWTSecretResponse, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if h.jwtKeys == nil {
+		return nil, status.Error(codes.FailedPrecondition, "JWT secret rotation is not configured")
+	}
+
+	// Validation
+	if len(req.NewSecret) < minJWTSecretBytes {
+		return nil, fieldViolation("new_secret", fmt.Sprintf("new_secret must be at least %d bytes", minJWTSecretBytes))
+	}
+	if req.GraceSeconds < 0 {
+		return nil, fieldViolation("grace_seconds", "grace_seconds must not be negative")
+	}
+	grace := h.rotationGrace
+	if req.GraceSeconds > 0 {
+		grace = time.Duration(req.GraceSeconds) * time.Second
+	}
+
+	until := h.jwtKeys.Rotate(req.NewSecret, grace)
+	log.Printf("JWT secret rotated by %s", user.ID)
+
+	resp := &api.RotateJWTSecretResponse{}
+	if !until.IsZero() {
+		resp.PreviousValidUntil = until.UTC().Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+// GetTransferStatus handles the GetTransferStatus gRPC call. Like
+// GetTransaction, it is open to admins and to owners of either account.
+func (h *Handler) GetTransferStatus(ctx context.Context, req *api.GetTransferStatusRequest) (*api.GetTransferStatusResponse, error) {
+	// Validation
+	if req.TransactionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+
+	// Call service
+	t, err := h.service.GetTransfer(ctx, req.TransactionId)
+	if err != nil {
+		if errors.Is(err, ErrTransactionNotFound) {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("transfer %s not found", req.TransactionId))
+		}
+		return nil, internalError(err, "failed to get transfer status")
+	}
+	if err := h.authorizeTransaction(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return &api.GetTransferStatusResponse{
+		TransactionId: t.ID,
+		Status:        transferStatus(t),
+		AmountCents:   t.AmountCents,
+		Currency:      t.Currency,
+		ReversedCents: t.ReversedCents,
+		CreatedAt:     formatTime(t.CreatedAt, h.timeLayout),
+	}, nil
+}
+
+// GetTransaction handles the GetTransaction gRPC call. Admins can read any
+// transaction, everyone else only those touching an account they own.
+func (h *Handler) GetTransaction(ctx context.Context, req *api.GetTransactionRequest) (*api.GetTransactionResponse, error) {
+	// Validation
+	if req.TransactionId == "" {
+		return nil, fieldViolation("transaction_id", "transaction_id is required")
+	}
+	layout, err := h.requestTimeLayout(req.TimestampFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call service
+	t, err := h.service.GetTransaction(ctx, req.TransactionId)
+	if err != nil {
+		if errors.Is(err, ErrTransactionNotFound) {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("transaction %s not found", req.TransactionId))
+		}
+		return nil, internalError(err, "failed to get transaction")
+	}
+	if err := h.authorizeTransaction(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return &api.GetTransactionResponse{
+		Transaction: toTransactionResponse(t, layout),
+		Reversed:    t.ReversedCents > 0 && t.ReversibleCents() == 0,
+	}, nil
+}
+
+// authorizeTransaction allows admins to read any transaction and everyone
+// else only those whose sending or receiving account they own
+func (h *Handler) authorizeTransaction(ctx context.Context, t *Transaction) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "caller identity missing")
+	}
+	if user.IsAdmin() {
+		return nil
+	}
+	var ids []string
+	for _, id := range []string{t.FromAccountID, t.ToAccountID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 0 {
+		accounts, err := h.service.LookupAccounts(ctx, ids)
+		if err != nil {
+			return internalError(err, "failed to get transaction")
+		}
+		for _, acc := range accounts {
+			if user.ID != "" && acc.OwnerID == user.ID {
+				return nil
+			}
+		}
+	}
+	return status.Error(codes.PermissionDenied, "cannot access transactions of another owner")
+}
+
+// transferStatus reports a recorded transfer's lifecycle state. Transfers are
+// applied synchronously, so anything recorded has settled unless it has
+// since been reversed in full.
+func transferStatus(t *Transaction) api.TransferStatus {
+	if t.ReversedCents > 0 && t.ReversibleCents() == 0 {
+		return api.TransferStatus_TRANSFER_STATUS_REVERSED
+	}
+	return api.TransferStatus_TRANSFER_STATUS_SETTLED
+}
+
+// Deposit handles the Deposit gRPC call. Only admins (e.g. the service
+// account receiving payment webhooks) may deposit.
+func (h *Handler) Deposit(ctx context.Context, req *api.DepositRequest) (*api.DepositResponse, error) {
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	if err := validateAmount("amount_cents", req.AmountCents); err != nil {
+		return nil, err
+	}
+
+	// Call service
+	t, duplicate, err := h.service.Deposit(ctx, req.AccountId, req.AmountCents, req.Currency, req.ExternalReference, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrReferenceConflict):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, ErrBalanceSign), errors.Is(err, ErrAccountNotActive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		case strings.Contains(err.Error(), "currency mismatch"):
+			return nil, fieldViolation("currency", err.Error())
+		}
+		return nil, internalError(err, "deposit failed")
+	}
+
+	resp := &api.DepositResponse{
+		TransactionId: t.ID,
+		AccountId:     t.ToAccountID,
+		AmountCents:   t.AmountCents,
+		Currency:      t.Currency,
+		Duplicate:     duplicate,
+	}
+	if balance, ok := t.BalanceAfter(t.ToAccountID); ok {
+		resp.BalanceCents = balance
+	}
+	return resp, nil
+}
+
+// SetParentAccount handles the SetParentAccount gRPC call. Only admins may
+// change the account hierarchy.
+func (h *Handler) SetParentAccount(ctx context.Context, req *api.SetParentAccountRequest) (*api.SetParentAccountResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	if req.ParentId == req.AccountId {
+		return nil, fieldViolation("parent_id", "an account cannot be its own parent")
+	}
+
+	// Call service
+	acc, err := h.service.SetParentAccount(ctx, req.AccountId, req.ParentId)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrParentCycle):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, ErrParentNotFound):
+			return nil, fieldViolation("parent_id", err.Error())
+		case strings.Contains(err.Error(), "not found"):
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to set parent account")
+	}
+
+	return &api.SetParentAccountResponse{
+		AccountId: acc.ID,
+		ParentId:  acc.ParentID,
+	}, nil
+}
+
+// GetAggregateBalance handles the GetAggregateBalance gRPC call
+func (h *Handler) GetAggregateBalance(ctx context.Context, req *api.AggregateBalanceRequest) (*api.AggregateBalanceResponse, error) {
+	// Validation
+	if req.AccountId == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+
+	// Call service
+	totals, count, err := h.service.GetAggregateBalance(ctx, req.AccountId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("account %s not found", req.AccountId))
+		}
+		return nil, internalError(err, "failed to get aggregate balance")
+	}
+
+	resp := &api.AggregateBalanceResponse{
+		AccountId:    req.AccountId,
+		Balances:     make([]*api.CurrencyBalance, len(totals)),
+		AccountCount: int32(count),
+	}
+	for i, t := range totals {
+		resp.Balances[i] = &api.CurrencyBalance{
+			Currency:     t.Currency,
+			BalanceCents: t.BalanceCents,
+			AccountCount: int32(t.AccountCount),
+		}
+	}
+	return resp, nil
+}
+
+// ListDeadLetters handles the ListDeadLetters gRPC call. Dead letters carry
+// other users' notifications, so only admins may read them.
+func (h *Handler) ListDeadLetters(ctx context.Context, req *api.ListDeadLettersRequest) (*api.ListDeadLettersResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.PageSize < 0 {
+		return nil, fieldViolation("page_size", "page_size must not be negative")
+	}
+
+	// Call service
+	letters, nextToken, err := h.service.ListDeadLetters(ctx, int(req.PageSize), req.PageToken)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid page token") {
+			return nil, fieldViolation("page_token", err.Error())
+		}
+		return nil, internalError(err, "failed to list dead letters")
+	}
+
+	resp := &api.ListDeadLettersResponse{
+		DeadLetters:   make([]*api.DeadLetter, len(letters)),
+		NextPageToken: nextToken,
+	}
+	for i := range letters {
+		resp.DeadLetters[i] = h.toDeadLetterResponse(&letters[i])
+	}
+	return resp, nil
+}
+
+// RetryDeadLetters handles the RetryDeadLetters gRPC call (admin only)
+func (h *Handler) RetryDeadLetters(ctx context.Context, req *api.RetryDeadLettersRequest) (*api.RetryDeadLettersResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.Limit < 0 {
+		return nil, fieldViolation("limit", "limit must not be negative")
+	}
+
+	// Call service
+	letters, err := h.service.RetryDeadLetters(ctx, req.Ids, int(req.Limit))
+	if err != nil {
+		if strings.Contains(err.Error(), "disabled") {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, internalError(err, "failed to retry dead letters")
+	}
+
+	resp := &api.RetryDeadLettersResponse{
+		Retried:    int32(len(letters)),
+		RetriedIds: make([]int64, len(letters)),
+	}
+	for i, d := range letters {
+		resp.RetriedIds[i] = d.ID
+	}
+	return resp, nil
+}
+
+func (h *Handler) toDeadLetterResponse(d *DeadLetter) *api.DeadLetter {
+	return &api.DeadLetter{
+		Id:             d.ID,
+		NotificationId: d.NotificationID,
+		AccountId:      d.AccountID,
+		Message:        d.Message,
+		Reason:         d.Reason,
+		Attempts:       int32(d.Attempts),
+		CreatedAt:      formatTime(d.CreatedAt, h.timeLayout),
+	}
+}
+
+// QueryAuditLog handles the QueryAuditLog gRPC call (admin only)
+func (h *Handler) QueryAuditLog(ctx context.Context, req *api.QueryAuditLogRequest) (*api.QueryAuditLogResponse, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validation
+	if req.PageSize < 0 {
+		return nil, fieldViolation("page_size", "page_size must not be negative")
+	}
+	filter := AuditFilter{
+		ActorID:   req.ActorId,
+		AccountID: req.AccountId,
+		Operation: req.Operation,
+		ReadsOnly: req.ReadsOnly,
+	}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339Nano, req.Since)
+		if err != nil {
+			return nil, fieldViolation("since", "must be an RFC 3339 timestamp")
+		}
+		filter.Since = since
+	}
+	if req.Until != "" {
+		until, err := time.Parse(time.RFC3339Nano, req.Until)
+		if err != nil {
+			return nil, fieldViolation("until", "must be an RFC 3339 timestamp")
+		}
+		filter.Until = until
+	}
+
+	// Call service
+	entries, nextToken, err := h.service.QueryAuditLog(ctx, filter, int(req.PageSize), req.PageToken)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid page token") {
+			return nil, fieldViolation("page_token", err.Error())
+		}
+		return nil, internalError(err, "failed to query audit log")
+	}
+
+	resp := &api.QueryAuditLogResponse{
+		Entries:       make([]*api.AuditEntry, len(entries)),
+		NextPageToken: nextToken,
+	}
+	for i := range entries {
+		resp.Entries[i] = h.toAuditEntryResponse(&entries[i])
+	}
+	return resp, nil
+}
+
+// toAuditEntryResponse converts an AuditEntry into its API representation
+func (h *Handler) toAuditEntryResponse(e *AuditEntry) *api.AuditEntry {
+	return &api.AuditEntry{
+		Id:            e.ID,
+		ActorId:       e.ActorID,
+		Operation:     e.Operation,
+		AccountIds:    e.AccountIDs,
+		TransactionId: e.TransactionID,
+		Outcome:       e.Outcome,
+		Error:         e.Error,
+		CreatedAt:     formatTime(e.CreatedAt, h.timeLayout),
+	}
+}
+
+// ImportAccounts handles the ImportAccounts gRPC call. It is admin only: each
+// record names its owner and opening balance, so an import can create money.
+// Records are inserted in batches of importBatchSize as they arrive; bad
+// records are reported in the summary and only a fatal error aborts the import.
+func (h *Handler) ImportAccounts(stream api.LedgerService_ImportAccountsServer) error {
+	ctx := stream.Context()
+	user, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	defaultOwner := user.ID
+
+	resp := &api.ImportAccountsResponse{}
+	batch := make([]Account, 0, importBatchSize)
+	var offset int64
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		failures, err := h.service.ImportAccounts(ctx, batch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return internalError(err, fmt.Sprintf("import aborted after %d records", resp.Created))
+		}
+		for i, ferr := range failures {
+			if ferr == nil {
+				resp.Created++
+				continue
+			}
+			resp.Failed++
+			resp.Failures = append(resp.Failures, &api.ImportFailure{
+				Index:     offset + int64(i),
+				AccountId: batch[i].ID,
+				Error:     ferr.Error(),
+			})
+		}
+		offset += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		rec, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		ownerID := rec.OwnerId
+		if ownerID == "" {
+			ownerID = defaultOwner
+		}
+		batch = append(batch, Account{
+			ID:           rec.AccountId,
+			OwnerID:      ownerID,
+			BalanceCents: rec.BalanceCents,
+			Currency:     rec.Currency,
+			Type:         rec.AccountType,
+		})
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// transientRetryDelay is the retry hint sent with UNAVAILABLE for transient database failures
+const transientRetryDelay = time.Second
+
+// internalError maps an unexpected service error to a status. Transient
+// database failures become UNAVAILABLE with a retry hint, lock timeouts and
+// serialization conflicts become ABORTED, statements cancelled by the
+// server's statement_timeout become DEADLINE_EXCEEDED and everything else is
+// INTERNAL.
+func internalError(err error, action string) error {
+	if IsLockTimeout(err) {
+		return status.Errorf(codes.Aborted, "%s: timed out waiting for an account lock, retry the request", action)
+	}
+	if IsSerializationFailure(err) {
+		return status.Errorf(codes.Aborted, "%s: conflicted with a concurrent transaction, retry the request", action)
+	}
+	if errors.Is(err, database.ErrPoolExhausted) {
+		return status.Errorf(codes.ResourceExhausted, "%s: no database connection available, retry the request", action)
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return status.Errorf(status.FromContextError(err).Code(), "%s: %v", action, err)
+	}
+	if IsStatementTimeout(err) {
+		return status.Errorf(codes.DeadlineExceeded, "%s: database statement timed out", action)
+	}
+	if IsTransient(err) {
+		return grpcerr.Unavailable(fmt.Sprintf("%s: database temporarily unavailable", action), transientRetryDelay)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", action, err)
+}
+
+// fieldViolation builds an INVALID_ARGUMENT status carrying a
+// google.rpc.BadRequest detail naming the offending request field, so clients
+// can attach the error to the right form input
+func fieldViolation(field, description string) error {
+	st := status.New(codes.InvalidArgument, description)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// validateAmount rejects a zero or negative amount of money to move with the
+// same INVALID_ARGUMENT field violation on every RPC that takes one
+func validateAmount(field string, cents int64) error {
+	if cents <= 0 {
+		return fieldViolation(field, field+" must be positive")
+	}
+	return nil
+}
+
+// fxStatus maps cross-currency errors to gRPC statuses
+func fxStatus(err error, action string) error {
+	switch {
+	case errors.Is(err, ErrFXDisabled), errors.Is(err, ErrQuoteExpired), errors.Is(err, ErrQuoteMismatch):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrQuoteNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrRateUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case strings.Contains(err.Error(), "no exchange rate"):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case strings.Contains(err.Error(), "cannot be empty"), strings.Contains(err.Error(), "must differ"),
+		strings.Contains(err.Error(), "share currency"), strings.Contains(err.Error(), "same account"),
+		strings.Contains(err.Error(), "converts to nothing"), errors.Is(err, ErrAmountOverflow):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return internalError(err, action)
+}
+
+// insufficientFundsStatus builds a FAILED_PRECONDITION status carrying the
+// shortfall so clients can read it without parsing the message
+func insufficientFundsStatus(e *InsufficientFundsError) error {
+	st := status.New(codes.FailedPrecondition, e.Error())
+	detailed, err := st.WithDetails(&api.InsufficientFundsDetail{
+		AccountId:           e.AccountID,
+		BalanceCents:        e.BalanceCents,
+		RequiredCents:       e.RequiredCents,
+		ShortfallCents:      e.ShortfallCents(),
+		OverdraftLimitCents: e.OverdraftLimitCents,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// errorDomain identifies this service in google.rpc.ErrorInfo details
+const errorDomain = "apex-ledger"
+
+// currencyMismatchReason is the google.rpc.ErrorInfo reason for a transfer
+// between accounts in different currencies
+const currencyMismatchReason = "CURRENCY_MISMATCH"
+
+// currencyMismatchStatus builds an INVALID_ARGUMENT status carrying a
+// google.rpc.ErrorInfo with reason CURRENCY_MISMATCH and both currencies, so
+// clients can prompt the user to convert first instead of parsing messages
+func (h *Handler) currencyMismatchStatus(e *CurrencyMismatchError) error {
+	msg := fmt.Sprintf("cannot transfer between %s and %s accounts: convert the funds first", e.FromCurrency, e.ToCurrency)
+	fx := "disabled"
+	if h.crossCurrency {
+		msg = fmt.Sprintf("cannot transfer between %s and %s accounts: use CrossCurrencyTransfer", e.FromCurrency, e.ToCurrency)
+		fx = "enabled"
+	}
+	st := status.New(codes.InvalidArgument, msg)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: currencyMismatchReason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"from_currency":           e.FromCurrency,
+			"to_currency":             e.ToCurrency,
+			"cross_currency_transfer": fx,
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// pageSize applies the configured page-size ceiling to a requested size,
+// reporting whether it was clamped; field names the request field in the
+// error when oversized pages are rejected
+func (h *Handler) pageSize(field string, requested int) (int, bool, error) {
+	if requested <= h.maxPageSize {
+		return requested, false, nil
+	}
+	if h.rejectLargePages {
+		return 0, false, fieldViolation(field, fmt.Sprintf("%s must be at most %d", field, h.maxPageSize))
+	}
+	return h.maxPageSize, true, nil
+}
+
+// exportPage is the number of rows an export reads per query: exportPageSize,
+// or the page-size ceiling if that is lower, so a short page still means the
+// export is complete
+func (h *Handler) exportPage() int {
+	return min(exportPageSize, h.maxPageSize)
+}
+
+// authorizeOwner allows admins to act on any owner and everyone else only on themselves
+func authorizeOwner(ctx context.Context, ownerID string) error {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "caller identity missing")
+	}
+	// A token without a subject owns nothing, not the accounts without an owner
+	if user.IsAdmin() || (user.ID != "" && user.ID == ownerID) {
+		return nil
+	}
+	return status.Error(codes.PermissionDenied, "cannot access accounts of another owner")
+}
+
+// requireAdmin returns the caller if they hold the admin role
+func requireAdmin(ctx context.Context) (*auth.User, error) {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller identity missing")
+	}
+	if !user.IsAdmin() {
+		return nil, status.Error(codes.PermissionDenied, "admin role required")
+	}
+	return user, nil
+}
+
+// toTransactionResponse converts a Transaction into its API representation,
+// formatting timestamps with layout
+func toTransactionResponse(t *Transaction, layout string) *api.Transaction {
+	resp := &api.Transaction{
+		TransactionId:         t.ID,
+		FromAccountId:         t.FromAccountID,
+		ToAccountId:           t.ToAccountID,
+		AmountCents:           t.AmountCents,
+		Currency:              t.Currency,
+		CreatedAt:             formatTime(t.CreatedAt, layout),
+		CreatedAtUnixMs:       t.CreatedAt.UnixMilli(),
+		Kind:                  t.Kind,
+		Reason:                t.Reason,
+		ReversedCents:         t.ReversedCents,
+		ReversesTransactionId: t.ReversesTransactionID,
+		ExternalReference:     t.ExternalReference,
+		Category:              t.Category,
+	}
+	if t.ConvertedAmountCents != nil {
+		resp.ConvertedAmountCents = *t.ConvertedAmountCents
+		resp.ConvertedCurrency = t.ConvertedCurrency
+	}
+	if t.ExchangeRate != nil {
+		resp.ExchangeRate = *t.ExchangeRate
+	}
+	resp.FxRounding = t.FXRounding
+	if t.FXRoundingAdjustment != nil {
+		resp.FxRoundingAdjustment = *t.FXRoundingAdjustment
+	}
+	return resp
+}
+
+// accountResponse is toAccountResponse with the fields the caller may not
+// see redacted
+func (h *Handler) accountResponse(ctx context.Context, acc *Account, layout string) *api.GetAccountResponse {
+	resp := toAccountResponse(acc, layout)
+	user, _ := auth.UserFromContext(ctx)
+	h.redaction.Redact(user, acc.OwnerID, resp)
+	return resp
+}
+
+// toAccountResponse converts an Account into its API representation,
+// formatting timestamps with layout
+func toAccountResponse(acc *Account, layout string) *api.GetAccountResponse {
+	return &api.GetAccountResponse{
+		AccountId:           acc.ID,
+		BalanceCents:        acc.BalanceCents,
+		Currency:            acc.Currency,
+		CreatedAt:           formatTime(acc.CreatedAt, layout),
+		UpdatedAt:           formatTime(acc.UpdatedAt, layout),
+		CreatedAtUnixMs:     acc.CreatedAt.UnixMilli(),
+		UpdatedAtUnixMs:     acc.UpdatedAt.UnixMilli(),
+		OwnerId:             acc.OwnerID,
+		ParentId:            acc.ParentID,
+		AccountStatus:       acc.Status,
+		Sequence:            acc.EventSeq,
+		AccountType:         acc.Type,
+		NotificationChannel: acc.NotificationChannel,
+		Sensitive:           acc.Sensitive,
+	}
+}
+
+// timestampFormats are the named formats accepted by ParseTimestampFormat
+var timestampFormats = map[string]string{
+	"":            time.RFC3339,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"datetime":    time.DateTime,
+}
+
+// ParseTimestampFormat resolves a timestamp format name ("rfc3339",
+// "rfc3339nano", "datetime") or a custom Go layout containing the reference
+// year 2006 to a layout. "" means the default, RFC 3339.
+func ParseTimestampFormat(format string) (string, error) {
+	if layout, ok := timestampFormats[strings.ToLower(format)]; ok {
+		return layout, nil
+	}
+	if strings.Contains(format, "2006") {
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown timestamp format %q", format)
+}
+
+// requestTimeLayout picks the layout for a response: the request's
+// timestamp_format if set, otherwise the handler default
+func (h *Handler) requestTimeLayout(format string) (string, error) {
+	if format == "" {
+		return h.timeLayout, nil
+	}
+	layout, err := ParseTimestampFormat(format)
+	if err != nil {
+		return "", fieldViolation("timestamp_format", err.Error())
+	}
+	return layout, nil
+}
+
+// formatTime renders t in UTC so responses don't depend on the server's timezone
+func formatTime(t time.Time, layout string) string {
+	return t.UTC().Format(layout)
+}
@@ -0,0 +1,809 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/auth"
+	"apex-ledger/internal/platform/database"
+	"apex-ledger/pkg/api"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeService answers the handler's calls with err; methods it doesn't
+// override panic via the nil Service
+type fakeService struct {
+	Service
+	err error
+	// total is the account count ListAccounts reports
+	total int64
+}
+
+func (f *fakeService) ListAccounts(ctx context.Context, limit, offset int) ([]Account, int64, error) {
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	return []Account{{ID: "acc-a", Currency: "USD"}}, f.total, nil
+}
+
+func (f *fakeService) CreateAccount(ctx context.Context, id, ownerID string, balanceCents int64, currency, accountType string) (*Account, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &Account{ID: id, OwnerID: ownerID, BalanceCents: balanceCents, Currency: currency, Type: accountType}, nil
+}
+
+func (f *fakeService) UpdateAccount(ctx context.Context, id, currency string) (*Account, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &Account{ID: id, Currency: currency}, nil
+}
+
+func (f *fakeService) PerformTransferWithOptions(ctx context.Context, from, to string, amount int64, opts TransferOptions) (string, int64, error) {
+	if f.err != nil {
+		return "", 0, f.err
+	}
+	return "tx-1", 0, nil
+}
+
+// fieldViolations decodes the BadRequest detail of an INVALID_ARGUMENT error
+// into field -> description
+func fieldViolations(t *testing.T, err error) map[string]string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+	violations := make(map[string]string)
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.FieldViolations {
+				violations[v.Field] = v.Description
+			}
+		}
+	}
+	if len(violations) == 0 {
+		t.Fatalf("%v carries no BadRequest field violations", err)
+	}
+	return violations
+}
+
+func TestFieldViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		call       func(h *Handler) error
+		field      string
+	}{
+		{
+			name: "create account negative balance",
+			call: func(h *Handler) error {
+				_, err := h.CreateAccount(context.Background(), &api.CreateAccountRequest{Currency: "USD", InitialBalanceCents: -1})
+				return err
+			},
+			field: "initial_balance_cents",
+		},
+		{
+			name:       "create account unsupported currency",
+			serviceErr: fmt.Errorf("currency XYZ is not supported"),
+			call: func(h *Handler) error {
+				_, err := h.CreateAccount(context.Background(), &api.CreateAccountRequest{Currency: "XYZ"})
+				return err
+			},
+			field: "currency",
+		},
+		{
+			name:       "create account invalid type",
+			serviceErr: fmt.Errorf("type %q: %w", "bogus", ErrInvalidAccountType),
+			call: func(h *Handler) error {
+				_, err := h.CreateAccount(context.Background(), &api.CreateAccountRequest{Currency: "USD", AccountType: "bogus"})
+				return err
+			},
+			field: "account_type",
+		},
+		{
+			name:       "create account invalid ID",
+			serviceErr: fmt.Errorf("bad id: %w", ErrInvalidAccountID),
+			call: func(h *Handler) error {
+				_, err := h.CreateAccount(context.Background(), &api.CreateAccountRequest{Id: "??", Currency: "USD"})
+				return err
+			},
+			field: "id",
+		},
+		{
+			name:       "create account without owner",
+			serviceErr: ErrOwnerRequired,
+			call: func(h *Handler) error {
+				_, err := h.CreateAccount(auth.ContextWithUser(context.Background(), &auth.User{}), &api.CreateAccountRequest{Currency: "USD"})
+				return err
+			},
+			field: "owner_id",
+		},
+		{
+			name: "transfer missing source",
+			call: func(h *Handler) error {
+				_, err := h.Transfer(context.Background(), &api.TransferRequest{ToAccountId: "acc-b", AmountCents: 1, Currency: "USD"})
+				return err
+			},
+			field: "from_account_id",
+		},
+		{
+			name: "transfer non-positive amount",
+			call: func(h *Handler) error {
+				_, err := h.Transfer(context.Background(), &api.TransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", Currency: "USD"})
+				return err
+			},
+			field: "amount_cents",
+		},
+		{
+			name: "transfer missing currency",
+			call: func(h *Handler) error {
+				_, err := h.Transfer(context.Background(), &api.TransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: 1})
+				return err
+			},
+			field: "currency",
+		},
+		{
+			name:       "transfer invalid category",
+			serviceErr: fmt.Errorf("category too long: %w", ErrInvalidCategory),
+			call: func(h *Handler) error {
+				_, err := h.Transfer(context.Background(), &api.TransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: 1, Currency: "USD"})
+				return err
+			},
+			field: "category",
+		},
+		{
+			name: "update account missing ID",
+			call: func(h *Handler) error {
+				_, err := h.UpdateAccount(context.Background(), &api.UpdateAccountRequest{Currency: "USD"})
+				return err
+			},
+			field: "account_id",
+		},
+		{
+			name:       "update account unsupported currency",
+			serviceErr: fmt.Errorf("currency XYZ is not supported"),
+			call: func(h *Handler) error {
+				_, err := h.UpdateAccount(context.Background(), &api.UpdateAccountRequest{AccountId: "acc-a", Currency: "XYZ"})
+				return err
+			},
+			field: "currency",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&fakeService{err: tt.serviceErr})
+			violations := fieldViolations(t, tt.call(h))
+			if _, ok := violations[tt.field]; !ok {
+				t.Fatalf("violations %v don't name %s", violations, tt.field)
+			}
+		})
+	}
+}
+
+// retryInfo returns the RetryInfo detail carried by err, if any
+func retryInfo(err error) *errdetails.RetryInfo {
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			return ri
+		}
+	}
+	return nil
+}
+
+// capService creates accounts while their owner holds fewer than limit
+type capService struct {
+	Service
+	limit int
+	held  map[string]int
+}
+
+func (f *capService) CreateAccount(ctx context.Context, id, ownerID string, balanceCents int64, currency, accountType string) (*Account, error) {
+	if f.held[ownerID] >= f.limit {
+		return nil, fmt.Errorf("owner %s: %w", ownerID, ErrAccountLimitReached)
+	}
+	f.held[ownerID]++
+	return &Account{ID: id, OwnerID: ownerID, Currency: currency}, nil
+}
+
+func TestCreateAccountOwner(t *testing.T) {
+	svc := &capService{limit: 1, held: make(map[string]int)}
+	h := NewHandler(svc)
+	user := auth.ContextWithUser(context.Background(), &auth.User{ID: "user-1"})
+
+	if _, err := h.CreateAccount(user, &api.CreateAccountRequest{Currency: "USD"}); err != nil {
+		t.Fatalf("first account: %v", err)
+	}
+	// Naming another owner, real or throwaway, doesn't get past the cap
+	for _, owner := range []string{"user-2", "throwaway-1"} {
+		_, err := h.CreateAccount(user, &api.CreateAccountRequest{Currency: "USD", OwnerId: owner})
+		if code := status.Code(err); code != codes.PermissionDenied {
+			t.Fatalf("creating for %s got %v, want PermissionDenied", owner, err)
+		}
+	}
+	if _, err := h.CreateAccount(user, &api.CreateAccountRequest{Currency: "USD", OwnerId: "user-1"}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("second account of user-1 got %v, want ResourceExhausted", err)
+	}
+	if svc.held["user-2"] != 0 || svc.held["throwaway-1"] != 0 {
+		t.Fatalf("accounts created for other owners: %v", svc.held)
+	}
+
+	admin := auth.ContextWithUser(context.Background(), &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}})
+	resp, err := h.CreateAccount(admin, &api.CreateAccountRequest{Currency: "USD", OwnerId: "user-2"})
+	if err != nil {
+		t.Fatalf("admin creating for user-2: %v", err)
+	}
+	if resp.OwnerId != "user-2" {
+		t.Fatalf("owner = %q, want user-2", resp.OwnerId)
+	}
+}
+
+func TestRetryInfo(t *testing.T) {
+	t.Run("unavailable", func(t *testing.T) {
+		h := NewHandler(&fakeService{err: fmt.Errorf("debit: %w", database.ErrCircuitOpen)})
+		_, err := h.Transfer(context.Background(), &api.TransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: 1, Currency: "USD"})
+		if code := status.Code(err); code != codes.Unavailable {
+			t.Fatalf("got %v, want Unavailable", err)
+		}
+		ri := retryInfo(err)
+		if ri == nil {
+			t.Fatalf("%v carries no RetryInfo", err)
+		}
+		if got := ri.RetryDelay.AsDuration(); got != time.Second {
+			t.Fatalf("retry delay = %v, want %v", got, time.Second)
+		}
+	})
+	t.Run("invalid argument", func(t *testing.T) {
+		h := NewHandler(&fakeService{})
+		_, err := h.Transfer(context.Background(), &api.TransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", Currency: "USD"})
+		if code := status.Code(err); code != codes.InvalidArgument {
+			t.Fatalf("got %v, want InvalidArgument", err)
+		}
+		if ri := retryInfo(err); ri != nil {
+			t.Fatalf("%v carries RetryInfo %v", err, ri)
+		}
+	})
+	t.Run("internal", func(t *testing.T) {
+		err := internalError(fmt.Errorf("boom"), "transfer failed")
+		if code := status.Code(err); code != codes.Internal {
+			t.Fatalf("got %v, want Internal", err)
+		}
+		if ri := retryInfo(err); ri != nil {
+			t.Fatalf("%v carries RetryInfo %v", err, ri)
+		}
+	})
+}
+
+func TestListAccountsTotalPastInt32(t *testing.T) {
+	const total = math.MaxInt32 + 5
+	h := NewHandler(&fakeService{total: total})
+	resp, err := h.ListAccounts(context.Background(), &api.ListAccountsRequest{})
+	if err != nil {
+		t.Fatalf("ListAccounts: %v", err)
+	}
+	if resp.Total != total {
+		t.Fatalf("total = %d, want %d", resp.Total, int64(total))
+	}
+
+	// And it survives the wire
+	raw, err := proto.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded api.ListAccountsResponse
+	if err := proto.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.Total != total {
+		t.Fatalf("decoded total = %d, want %d", decoded.Total, int64(total))
+	}
+}
+
+func TestNonPositiveAmounts(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}})
+	calls := []struct {
+		name  string
+		field string
+		call  func(h *Handler, amount int64) error
+	}{
+		{
+			name:  "transfer",
+			field: "amount_cents",
+			call: func(h *Handler, amount int64) error {
+				_, err := h.Transfer(context.Background(), &api.TransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: amount, Currency: "USD"})
+				return err
+			},
+		},
+		{
+			name:  "batch transfer",
+			field: "transfers[1].amount_cents",
+			call: func(h *Handler, amount int64) error {
+				_, err := h.BatchTransfer(context.Background(), &api.BatchTransferRequest{Transfers: []*api.TransferRequest{
+					{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: 1, Currency: "USD"},
+					{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: amount, Currency: "USD"},
+				}})
+				return err
+			},
+		},
+		{
+			name:  "conversion quote",
+			field: "amount_cents",
+			call: func(h *Handler, amount int64) error {
+				_, err := h.GetConversionQuote(context.Background(), &api.ConversionQuoteRequest{FromCurrency: "USD", ToCurrency: "EUR", AmountCents: amount})
+				return err
+			},
+		},
+		{
+			name:  "cross-currency transfer",
+			field: "amount_cents",
+			call: func(h *Handler, amount int64) error {
+				_, err := h.CrossCurrencyTransfer(context.Background(), &api.CrossCurrencyTransferRequest{FromAccountId: "acc-a", ToAccountId: "acc-b", AmountCents: amount})
+				return err
+			},
+		},
+		{
+			name:  "deposit",
+			field: "amount_cents",
+			call: func(h *Handler, amount int64) error {
+				_, err := h.Deposit(admin, &api.DepositRequest{AccountId: "acc-a", AmountCents: amount})
+				return err
+			},
+		},
+	}
+	for _, c := range calls {
+		for _, amount := range []int64{0, -1, math.MinInt64} {
+			t.Run(fmt.Sprintf("%s %d", c.name, amount), func(t *testing.T) {
+				// The service is never reached
+				violations := fieldViolations(t, c.call(NewHandler(&fakeService{}), amount))
+				if got, want := violations[c.field], c.field+" must be positive"; got != want {
+					t.Fatalf("violation of %s = %q, want %q", c.field, got, want)
+				}
+			})
+		}
+	}
+}
+
+// sentStream collects what a server-streaming handler sends. Like a real
+// stream it copies each message, since handlers may reuse the buffer.
+type sentStream[T any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*T
+}
+
+func (s *sentStream[T]) Send(m *T) error {
+	clone := proto.Clone(any(m).(proto.Message))
+	s.sent = append(s.sent, any(clone).(*T))
+	return nil
+}
+
+func (s *sentStream[T]) Context() context.Context { return s.ctx }
+
+// exportService pages through fixed accounts, ordered by ID, and fixed
+// transactions, ordered by creation time then ID
+type exportService struct {
+	Service
+	accounts []Account
+	txns     []Transaction
+}
+
+func (f *exportService) GetAccount(ctx context.Context, id string) (*Account, error) {
+	for _, acc := range f.accounts {
+		if acc.ID == id {
+			return &acc, nil
+		}
+	}
+	return nil, fmt.Errorf("account %s: %w", id, ErrAccountNotFound)
+}
+
+func (f *exportService) ListTransactionsAfter(ctx context.Context, filter TransactionFilter, after *HistoryCursor, limit int) ([]Transaction, error) {
+	var page []Transaction
+	for _, t := range f.txns {
+		if after != nil && (t.CreatedAt.Before(after.CreatedAt) || t.CreatedAt.Equal(after.CreatedAt) && t.ID <= after.ID) {
+			continue
+		}
+		if filter.AccountID != "" && t.FromAccountID != filter.AccountID && t.ToAccountID != filter.AccountID {
+			continue
+		}
+		if len(page) < limit {
+			page = append(page, t)
+		}
+	}
+	return page, nil
+}
+
+func (f *exportService) ListAccountsAfter(ctx context.Context, afterID, currency string, limit int) ([]Account, error) {
+	var page []Account
+	for _, acc := range f.accounts {
+		if acc.ID > afterID && (currency == "" || acc.Currency == currency) && len(page) < limit {
+			page = append(page, acc)
+		}
+	}
+	return page, nil
+}
+
+func TestExportAccountsRoundTrip(t *testing.T) {
+	admin := auth.ContextWithUser(context.Background(), &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}})
+	created := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	accounts := []Account{
+		{ID: "acc-1", BalanceCents: 1050, Currency: "USD", CreatedAt: created},
+		{ID: "acc-2", BalanceCents: -200, Currency: "EUR", CreatedAt: created.Add(time.Hour)},
+		{ID: "acc-3", BalanceCents: 0, Currency: "USD", CreatedAt: created.Add(2 * time.Hour)},
+		{ID: "acc-4", BalanceCents: math.MaxInt64, Currency: "USD", CreatedAt: created.Add(3 * time.Hour)},
+		{ID: "acc-5,\"quoted\"", BalanceCents: 7, Currency: "GBP", CreatedAt: created.Add(4 * time.Hour)},
+	}
+
+	tests := []struct {
+		currency string
+		want     []Account
+	}{
+		{currency: "", want: accounts},
+		{currency: "USD", want: []Account{accounts[0], accounts[2], accounts[3]}},
+		{currency: "JPY", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run("currency "+tt.currency, func(t *testing.T) {
+			// A page of two makes the export span several chunks
+			h := NewHandler(&exportService{accounts: accounts}, WithMaxPageSize(2, false))
+			stream := &sentStream[api.ExportAccountsChunk]{ctx: admin}
+			if err := h.ExportAccounts(&api.ExportAccountsRequest{Currency: tt.currency}, stream); err != nil {
+				t.Fatalf("ExportAccounts: %v", err)
+			}
+
+			var file bytes.Buffer
+			for _, chunk := range stream.sent {
+				file.Write(chunk.Data)
+			}
+			records, err := csv.NewReader(&file).ReadAll()
+			if err != nil {
+				t.Fatalf("exported CSV doesn't parse: %v", err)
+			}
+			if got, want := records[0], []string{"id", "balance", "currency", "created_at"}; !slices.Equal(got, want) {
+				t.Fatalf("header = %v, want %v", got, want)
+			}
+			rows := records[1:]
+			if len(rows) != len(tt.want) {
+				t.Fatalf("exported %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i, row := range rows {
+				balance, err := strconv.ParseInt(row[1], 10, 64)
+				if err != nil {
+					t.Fatalf("row %d balance %q: %v", i, row[1], err)
+				}
+				createdAt, err := time.Parse(time.RFC3339, row[3])
+				if err != nil {
+					t.Fatalf("row %d created_at %q: %v", i, row[3], err)
+				}
+				got := Account{ID: row[0], BalanceCents: balance, Currency: row[2], CreatedAt: createdAt}
+				if want := tt.want[i]; got.ID != want.ID || got.BalanceCents != want.BalanceCents || got.Currency != want.Currency || !got.CreatedAt.Equal(want.CreatedAt) {
+					t.Fatalf("row %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExportAccountsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(auth.ContextWithUser(context.Background(), &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}}))
+	cancel()
+	h := NewHandler(&exportService{accounts: []Account{{ID: "acc-1", Currency: "USD"}}})
+	err := h.ExportAccounts(&api.ExportAccountsRequest{}, &sentStream[api.ExportAccountsChunk]{ctx: ctx})
+	if code := status.Code(err); code != codes.Canceled {
+		t.Fatalf("got %v, want Canceled", err)
+	}
+}
+
+func TestExportAccountsAdminOnly(t *testing.T) {
+	h := NewHandler(&exportService{accounts: []Account{{ID: "acc-1", OwnerID: "user-2", BalanceCents: 500, Currency: "USD"}}})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{name: "user", ctx: auth.ContextWithUser(context.Background(), &auth.User{ID: "user-1"}), want: codes.PermissionDenied},
+		{name: "no caller", ctx: context.Background(), want: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &sentStream[api.ExportAccountsChunk]{ctx: tt.ctx}
+			if code := status.Code(h.ExportAccounts(&api.ExportAccountsRequest{}, stream)); code != tt.want {
+				t.Fatalf("got %s, want %s", code, tt.want)
+			}
+			if len(stream.sent) != 0 {
+				t.Fatalf("sent %d chunks to an unauthorized caller", len(stream.sent))
+			}
+		})
+	}
+}
+
+func TestExportTransactionsRoundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	balance, converted, rate := int64(900), int64(92), 0.92
+	txns := []Transaction{
+		{ID: "tx-1", Kind: TransactionKindDeposit, ToAccountID: "acc-1", AmountCents: 1000, Currency: "USD", ToBalanceAfter: &balance, ExternalReference: "psp-1", CreatedAt: created},
+		{ID: "tx-2", Kind: TransactionKindTransfer, FromAccountID: "acc-1", ToAccountID: "acc-2", AmountCents: 100, Currency: "USD",
+			ConvertedAmountCents: &converted, ConvertedCurrency: "EUR", ExchangeRate: &rate, FromBalanceAfter: &balance, Category: "rent", ActorID: "user-1", CreatedAt: created},
+		{ID: "tx-3", Kind: TransactionKindReversal, FromAccountID: "acc-2", ToAccountID: "acc-1", AmountCents: 50, Currency: "USD", ReversesTransactionID: "tx-2", Reason: "refund", CreatedAt: created.Add(time.Second)},
+		{ID: "tx-4", Kind: TransactionKindTransfer, FromAccountID: "acc-3", ToAccountID: "acc-2", AmountCents: 7, Currency: "USD", ReversedCents: 7, CreatedAt: created.Add(time.Minute)},
+	}
+	svc := &exportService{accounts: []Account{{ID: "acc-1", OwnerID: "user-1", Currency: "USD"}}, txns: txns}
+	admin := auth.ContextWithUser(context.Background(), &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}})
+	owner := auth.ContextWithUser(context.Background(), &auth.User{ID: "user-1"})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		account string
+		want    []Transaction
+	}{
+		{name: "admin exports everything", ctx: admin, want: txns},
+		{name: "owner exports their account", ctx: owner, account: "acc-1", want: txns[:3]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A page of two makes the export span several queries
+			h := NewHandler(svc, WithMaxPageSize(2, false))
+			stream := &sentStream[api.ExportTransactionsLine]{ctx: tt.ctx}
+			if err := h.ExportTransactions(&api.ExportTransactionsRequest{AccountId: tt.account}, stream); err != nil {
+				t.Fatalf("ExportTransactions: %v", err)
+			}
+			if len(stream.sent) != len(tt.want) {
+				t.Fatalf("sent %d lines, want %d", len(stream.sent), len(tt.want))
+			}
+			for i, line := range stream.sent {
+				if !bytes.HasSuffix(line.Data, []byte("\n")) || bytes.Count(line.Data, []byte("\n")) != 1 {
+					t.Fatalf("line %d = %q, want one newline-terminated JSON object", i, line.Data)
+				}
+				var got exportedTransaction
+				if err := json.Unmarshal(line.Data, &got); err != nil {
+					t.Fatalf("line %d doesn't parse: %v", i, err)
+				}
+				want := tt.want[i]
+				createdAt, err := time.Parse(time.RFC3339Nano, got.CreatedAt)
+				if err != nil || !createdAt.Equal(want.CreatedAt) {
+					t.Fatalf("line %d created_at %q, want %s", i, got.CreatedAt, want.CreatedAt)
+				}
+				if got.ID != want.ID || got.Kind != want.Kind || got.FromAccountID != want.FromAccountID || got.ToAccountID != want.ToAccountID ||
+					got.AmountCents != want.AmountCents || got.Currency != want.Currency || got.ConvertedCurrency != want.ConvertedCurrency ||
+					!equalPtr(got.ConvertedAmountCents, want.ConvertedAmountCents) || !equalPtr(got.ExchangeRate, want.ExchangeRate) ||
+					!equalPtr(got.FromBalanceAfter, want.FromBalanceAfter) || !equalPtr(got.ToBalanceAfter, want.ToBalanceAfter) ||
+					got.ReversedCents != want.ReversedCents || got.ReversesTransactionID != want.ReversesTransactionID ||
+					got.ExternalReference != want.ExternalReference || got.Category != want.Category || got.Reason != want.Reason || got.ActorID != want.ActorID {
+					t.Fatalf("line %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// equalPtr reports whether a and b are both nil or point to equal values
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestExportTransactionsAuthorization(t *testing.T) {
+	svc := &exportService{accounts: []Account{{ID: "acc-1", OwnerID: "user-1", Currency: "USD"}}}
+	other := auth.ContextWithUser(context.Background(), &auth.User{ID: "user-2"})
+	tests := []struct {
+		name    string
+		account string
+		want    codes.Code
+	}{
+		{name: "all accounts", want: codes.PermissionDenied},
+		{name: "someone else's account", account: "acc-1", want: codes.PermissionDenied},
+		{name: "missing account", account: "acc-missing", want: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(svc)
+			stream := &sentStream[api.ExportTransactionsLine]{ctx: other}
+			err := h.ExportTransactions(&api.ExportTransactionsRequest{AccountId: tt.account}, stream)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %v, want %s", err, tt.want)
+			}
+			if len(stream.sent) != 0 {
+				t.Fatalf("sent %d lines to an unauthorized caller", len(stream.sent))
+			}
+		})
+	}
+}
+
+// ownerService reports fixed accounts to authorizeTransaction's lookup
+type ownerService struct {
+	Service
+	accounts []Account
+}
+
+func (f *ownerService) LookupAccounts(ctx context.Context, ids []string) ([]Account, error) {
+	var found []Account
+	for _, acc := range f.accounts {
+		if slices.Contains(ids, acc.ID) {
+			found = append(found, acc)
+		}
+	}
+	return found, nil
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  *auth.User
+		owner string
+		want  codes.Code
+	}{
+		{name: "owner", user: &auth.User{ID: "user-1"}, owner: "user-1", want: codes.OK},
+		{name: "admin", user: &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}}, owner: "user-1", want: codes.OK},
+		{name: "other user", user: &auth.User{ID: "user-2"}, owner: "user-1", want: codes.PermissionDenied},
+		{name: "no subject on an ownerless account", user: &auth.User{}, owner: "", want: codes.PermissionDenied},
+		{name: "no caller", owner: "user-1", want: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = auth.ContextWithUser(ctx, tt.user)
+			}
+			if code := status.Code(authorizeOwner(ctx, tt.owner)); code != tt.want {
+				t.Fatalf("got %s, want %s", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeTransactionNeedsSubject(t *testing.T) {
+	h := NewHandler(&ownerService{accounts: []Account{{ID: "acc-1"}, {ID: "acc-2", OwnerID: "user-1"}}})
+	txn := &Transaction{ID: "tx-1", FromAccountID: "acc-1", ToAccountID: "acc-2"}
+
+	// Sharing the empty owner of acc-1 doesn't make a caller without a subject its owner
+	noSubject := auth.ContextWithUser(context.Background(), &auth.User{})
+	if code := status.Code(h.authorizeTransaction(noSubject, txn)); code != codes.PermissionDenied {
+		t.Fatalf("caller without a subject got %s, want PermissionDenied", code)
+	}
+	owner := auth.ContextWithUser(context.Background(), &auth.User{ID: "user-1"})
+	if err := h.authorizeTransaction(owner, txn); err != nil {
+		t.Fatalf("owner of the receiving account refused: %v", err)
+	}
+}
+
+// transferService serves one transfer and its accounts to GetTransferStatus
+type transferService struct {
+	ownerService
+	transfer *Transaction
+}
+
+func (f *transferService) GetTransfer(ctx context.Context, id string) (*Transaction, error) {
+	if id != f.transfer.ID {
+		return nil, ErrTransactionNotFound
+	}
+	return f.transfer, nil
+}
+
+func TestGetTransferStatusAuthorization(t *testing.T) {
+	svc := &transferService{
+		ownerService: ownerService{accounts: []Account{{ID: "acc-1", OwnerID: "user-1"}, {ID: "acc-2", OwnerID: "user-2"}}},
+		transfer:     &Transaction{ID: "tx-1", FromAccountID: "acc-1", ToAccountID: "acc-2", AmountCents: 100, Currency: "USD"},
+	}
+	h := NewHandler(svc)
+	tests := []struct {
+		name string
+		user *auth.User
+		want codes.Code
+	}{
+		{name: "sender", user: &auth.User{ID: "user-1"}, want: codes.OK},
+		{name: "receiver", user: &auth.User{ID: "user-2"}, want: codes.OK},
+		{name: "admin", user: &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}}, want: codes.OK},
+		{name: "other user", user: &auth.User{ID: "user-3"}, want: codes.PermissionDenied},
+		{name: "no caller", want: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = auth.ContextWithUser(ctx, tt.user)
+			}
+			resp, err := h.GetTransferStatus(ctx, &api.GetTransferStatusRequest{TransactionId: "tx-1"})
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %s, want %s", code, tt.want)
+			}
+			if err == nil && resp.TransactionId != "tx-1" {
+				t.Fatalf("status of %s, want tx-1", resp.TransactionId)
+			}
+			if err != nil && resp != nil {
+				t.Fatal("refused caller was sent the transfer status")
+			}
+		})
+	}
+}
+
+// recvStream feeds fixed records to a client-streaming handler and keeps
+// the response it closes with
+type recvStream[Req, Resp any] struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recs []*Req
+	resp *Resp
+}
+
+func (s *recvStream[Req, Resp]) Recv() (*Req, error) {
+	if len(s.recs) == 0 {
+		return nil, io.EOF
+	}
+	rec := s.recs[0]
+	s.recs = s.recs[1:]
+	return rec, nil
+}
+
+func (s *recvStream[Req, Resp]) SendAndClose(m *Resp) error {
+	s.resp = m
+	return nil
+}
+
+func (s *recvStream[Req, Resp]) Context() context.Context { return s.ctx }
+
+// importService records the accounts it is asked to import
+type importService struct {
+	Service
+	imported []Account
+}
+
+func (f *importService) ImportAccounts(ctx context.Context, accs []Account) ([]error, error) {
+	f.imported = append(f.imported, accs...)
+	return make([]error, len(accs)), nil
+}
+
+func TestImportAccountsAdminOnly(t *testing.T) {
+	recs := func() []*api.ImportAccountRecord {
+		return []*api.ImportAccountRecord{
+			{AccountId: "acc-1", BalanceCents: 1_000_000, Currency: "USD", OwnerId: "user-2"},
+			{AccountId: "acc-2", Currency: "USD", AccountType: AccountTypeAsset},
+		}
+	}
+
+	svc := &importService{}
+	h := NewHandler(svc)
+	for name, user := range map[string]*auth.User{"user": {ID: "user-1"}, "no subject": {}} {
+		stream := &recvStream[api.ImportAccountRecord, api.ImportAccountsResponse]{
+			ctx: auth.ContextWithUser(context.Background(), user), recs: recs(),
+		}
+		if code := status.Code(h.ImportAccounts(stream)); code != codes.PermissionDenied {
+			t.Fatalf("%s got %s, want PermissionDenied", name, code)
+		}
+	}
+	if len(svc.imported) != 0 {
+		t.Fatalf("non-admins imported %d accounts", len(svc.imported))
+	}
+
+	admin := auth.ContextWithUser(context.Background(), &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}})
+	stream := &recvStream[api.ImportAccountRecord, api.ImportAccountsResponse]{ctx: admin, recs: recs()}
+	if err := h.ImportAccounts(stream); err != nil {
+		t.Fatalf("admin import: %v", err)
+	}
+	if stream.resp.Created != 2 {
+		t.Fatalf("created %d accounts, want 2", stream.resp.Created)
+	}
+	// A record without an owner belongs to the importing admin
+	if got := []string{svc.imported[0].OwnerID, svc.imported[1].OwnerID}; !slices.Equal(got, []string{"user-2", "admin-1"}) {
+		t.Fatalf("owners %v, want [user-2 admin-1]", got)
+	}
+	if got := []string{svc.imported[0].Type, svc.imported[1].Type}; !slices.Equal(got, []string{"", AccountTypeAsset}) {
+		t.Fatalf("types %v, want the service default then asset", got)
+	}
+}
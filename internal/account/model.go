@@ -1,19 +1,345 @@
-package account
-
-import "time"
-
-// Account represents the database entity
-type Account struct {
-	ID           string    `db:"id"`
-	BalanceCents int64     `db:"balance_cents"`
-	Currency     string    `db:"currency"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
-}
-
-// TransferEvent is used for the async worker pool
-type TransferEvent struct {
-	FromID string
-	ToID   string
-	Amount int64
-}
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Account represents the database entity
+type Account struct {
+	ID                  string    `db:"id"`
+	OwnerID             string    `db:"owner_id"`
+	BalanceCents        int64     `db:"balance_cents"`
+	Currency            string    `db:"currency"`
+	OverdraftLimitCents int64     `db:"overdraft_limit_cents"`
+	InterestRateBps     int64     `db:"interest_rate_bps"`
+	ParentID            string    `db:"parent_id"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+	// EventSeq is the sequence number of the account's latest ledger event
+	EventSeq int64 `db:"event_seq"`
+	// Status is AccountStatusActive or AccountStatusPending
+	Status string `db:"status"`
+	// Type is AccountTypeStandard, AccountTypeAsset or AccountTypeLiability
+	Type string `db:"account_type"`
+	// NotificationChannel is the preferred delivery channel, or empty for
+	// the default sender
+	NotificationChannel string `db:"notification_channel"`
+	// Sensitive accounts have reads audited as well as writes
+	Sensitive bool `db:"sensitive"`
+}
+
+// Account statuses. A pending account can be read but can't send or receive
+// transfers until it is activated.
+const (
+	AccountStatusActive  = "active"
+	AccountStatusPending = "pending"
+)
+
+// Account types. Asset accounts can never go below zero and liability
+// accounts never above zero, whatever their overdraft. Liability accounts
+// have no floor at all, as debits are how they go negative. Standard
+// accounts are bounded only by their overdraft limit.
+const (
+	AccountTypeStandard  = "standard"
+	AccountTypeAsset     = "asset"
+	AccountTypeLiability = "liability"
+)
+
+// ValidAccountType reports whether t is one of the account types
+func ValidAccountType(t string) bool {
+	return t == AccountTypeStandard || t == AccountTypeAsset || t == AccountTypeLiability
+}
+
+// Notification channels an account can prefer
+const (
+	NotificationChannelEmail   = "email"
+	NotificationChannelSMS     = "sms"
+	NotificationChannelWebhook = "webhook"
+)
+
+// ValidNotificationChannel reports whether c is a notification channel, or
+// empty for no preference
+func ValidNotificationChannel(c string) bool {
+	return c == "" || c == NotificationChannelEmail || c == NotificationChannelSMS || c == NotificationChannelWebhook
+}
+
+// AvailableCents is the most that can be debited: the balance plus any overdraft
+func (a *Account) AvailableCents() int64 {
+	return a.BalanceCents + a.OverdraftLimitCents
+}
+
+// CurrencyBalance is the total held in one currency across a set of accounts
+type CurrencyBalance struct {
+	Currency     string `db:"currency"`
+	BalanceCents int64  `db:"balance_cents"`
+	AccountCount int    `db:"account_count"`
+}
+
+// DeadLetter is a notification that could not be delivered, kept so it can
+// be retried
+type DeadLetter struct {
+	ID             int64     `db:"id"`
+	NotificationID string    `db:"notification_id"`
+	AccountID      string    `db:"account_id"`
+	Channel        string    `db:"channel"`
+	ActorID        string    `db:"actor_id"`
+	Message        string    `db:"message"`
+	Reason         string    `db:"reason"`
+	Attempts       int       `db:"attempts"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// Audit outcomes recorded in the audit_log table
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditEntry records who performed a mutating operation, on which accounts,
+// and whether it succeeded
+type AuditEntry struct {
+	ID            int64         `db:"id"`
+	ActorID       string        `db:"actor_id"`
+	Operation     string        `db:"operation"`
+	AccountIDs    AccountIDList `db:"account_ids"`
+	TransactionID string        `db:"transaction_id"`
+	Outcome       string        `db:"outcome"`
+	Error         string        `db:"error"`
+	CreatedAt     time.Time     `db:"created_at"`
+}
+
+// AuditFilter narrows an audit log query; empty fields and zero times match
+// everything
+type AuditFilter struct {
+	ActorID   string
+	AccountID string
+	Operation string
+	Since     time.Time // Inclusive
+	Until     time.Time // Exclusive
+	// ReadsOnly keeps only read-access entries, whose operations are named
+	// read_*
+	ReadsOnly bool
+}
+
+// TransactionFilter narrows a transaction export; empty fields match all
+type TransactionFilter struct {
+	AccountID string    // Either side
+	Currency  string    // Sent or, for cross-currency transfers, received
+	Since     time.Time // Inclusive
+	Until     time.Time // Exclusive
+}
+
+// AccountIDList scans a text array selected as JSON
+type AccountIDList []string
+
+// Scan implements sql.Scanner
+func (l *AccountIDList) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	case nil:
+		*l = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into AccountIDList", src)
+	}
+	return json.Unmarshal(raw, (*[]string)(l))
+}
+
+// Transaction kinds recorded in the transactions table
+const (
+	TransactionKindTransfer       = "transfer"
+	TransactionKindAdjustment     = "adjustment"
+	TransactionKindOpeningBalance = "opening_balance"
+	TransactionKindReversal       = "reversal"
+	TransactionKindDeposit        = "deposit"
+	TransactionKindInterest       = "interest"
+	TransactionKindOverdraft      = "overdraft_penalty"
+	// TransactionKindSelfTransfer is a transfer from an account to itself,
+	// recorded for tracing only; it moves no money
+	TransactionKindSelfTransfer = "self_transfer"
+)
+
+// Transaction represents a recorded ledger movement.
+// An empty FromAccountID or ToAccountID means money entered or left the
+// ledger (e.g. an adjustment) rather than moving between two accounts.
+type Transaction struct {
+	ID            string `db:"id"`
+	FromAccountID string `db:"from_account_id"`
+	ToAccountID   string `db:"to_account_id"`
+	AmountCents   int64  `db:"amount_cents"`
+	Currency      string `db:"currency"`
+	Kind          string `db:"kind"`
+	Reason        string `db:"reason"`
+	ActorID       string `db:"actor_id"`
+	// Balances of each side immediately after the transaction; nil for rows
+	// recorded before balance snapshots were stored
+	FromBalanceAfter *int64 `db:"from_balance_after"`
+	ToBalanceAfter   *int64 `db:"to_balance_after"`
+	// Set on cross-currency transfers: AmountCents/Currency are what left the
+	// sender, Converted* is what the receiver got at ExchangeRate.
+	// FXRounding names the rounding mode applied, and FXRoundingAdjustment
+	// is what it added, so ConvertedAmountCents = AmountCents × ExchangeRate
+	// + FXRoundingAdjustment; both are empty on rows recorded before
+	// rounding was tracked
+	ConvertedAmountCents *int64   `db:"converted_amount_cents"`
+	ConvertedCurrency    string   `db:"converted_currency"`
+	ExchangeRate         *float64 `db:"exchange_rate"`
+	FXRounding           string   `db:"fx_rounding"`
+	FXRoundingAdjustment *float64 `db:"fx_rounding_adjustment"`
+	// ReversedCents is how much of this transfer has been reversed so far;
+	// ReversesTransactionID links a reversal back to the transfer it undoes
+	ReversedCents         int64  `db:"reversed_cents"`
+	ReversesTransactionID string `db:"reverses_transaction_id"`
+	// ExternalReference is the payment processor's ID for a deposit
+	ExternalReference string `db:"external_reference"`
+	// Category is a client-assigned label of a transfer, such as "fees"
+	Category  string    `db:"category"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ReversibleCents is how much of the transaction can still be reversed
+func (t *Transaction) ReversibleCents() int64 {
+	return t.AmountCents - t.ReversedCents
+}
+
+// BalanceAfter returns accountID's balance immediately after the transaction,
+// if it was recorded
+func (t *Transaction) BalanceAfter(accountID string) (int64, bool) {
+	switch {
+	case accountID == t.FromAccountID && t.FromBalanceAfter != nil:
+		return *t.FromBalanceAfter, true
+	case accountID == t.ToAccountID && t.ToBalanceAfter != nil:
+		return *t.ToBalanceAfter, true
+	}
+	return 0, false
+}
+
+// LedgerEvent is one change to an account's balance, numbered by a
+// per-account sequence with no gaps
+type LedgerEvent struct {
+	AccountID     string `db:"account_id"`
+	Seq           int64  `db:"seq"`
+	TransactionID string `db:"transaction_id"`
+	Kind          string `db:"kind"`
+	DeltaCents    int64  `db:"delta_cents"`
+	// BalanceAfter is nil when the writer didn't capture the balance, as
+	// for batch transfers, which update each account once by its net
+	BalanceAfter *int64    `db:"balance_after"`
+	Currency     string    `db:"currency"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// BalanceUpdate is one message of a balance watch: the account's balance
+// after the ledger event numbered Seq. The first update of a watch is a
+// snapshot of the balance when it started and carries no transaction.
+type BalanceUpdate struct {
+	AccountID     string
+	BalanceCents  int64
+	Currency      string
+	Seq           int64
+	Snapshot      bool
+	DeltaCents    int64
+	TransactionID string
+	Kind          string
+	At            time.Time
+}
+
+// HistoryCursor is the keyset position of the last transaction on a page
+type HistoryCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// BalanceCheck compares an account's stored balance with the net of its transactions
+type BalanceCheck struct {
+	AccountID        string `db:"id"`
+	BalanceCents     int64  `db:"balance_cents"`
+	ComputedCents    int64  `db:"computed_cents"`
+	RecentlyModified bool   `db:"recently_modified"`
+}
+
+// BalanceSnapshot is an account's balance as of a point in time
+type BalanceSnapshot struct {
+	AccountID    string    `db:"account_id"`
+	TakenAt      time.Time `db:"taken_at"`
+	BalanceCents int64     `db:"balance_cents"`
+}
+
+// ConversionQuote is a short-lived offer to convert an amount at a fixed rate
+type ConversionQuote struct {
+	ID             string
+	FromCurrency   string
+	ToCurrency     string
+	AmountCents    int64
+	ConvertedCents int64
+	Rate           float64
+	ExpiresAt      time.Time
+}
+
+// WindowReversal is the outcome of reversing one transfer in a bulk reversal.
+// ReversalID is empty when the transfer was skipped, and Err says why.
+type WindowReversal struct {
+	TransactionID string
+	ReversalID    string
+	AmountCents   int64
+	Err           error
+}
+
+// TransferEntry is one leg of a batch transfer
+type TransferEntry struct {
+	FromID      string
+	ToID        string
+	AmountCents int64
+	Category    string
+	// FromCurrency and ToCurrency, when set, must match the accounts'
+	// currencies at the time of the transfer
+	FromCurrency string
+	ToCurrency   string
+}
+
+// TransferOptions carries the optional parts of a single transfer
+type TransferOptions struct {
+	// ExpectedFromSeq, when set, makes the transfer apply only if the
+	// sending account's sequence still equals it
+	ExpectedFromSeq *int64
+	// Category labels the transfer, e.g. "fees" or "payroll"
+	Category string
+	// FromCurrency and ToCurrency, when set, make the transfer apply only if
+	// the sending and receiving accounts still hold those currencies
+	FromCurrency string
+	ToCurrency   string
+}
+
+// BalanceAdjustment is one record of a bulk balance adjustment
+type BalanceAdjustment struct {
+	AccountID  string
+	DeltaCents int64
+	Reason     string
+}
+
+// AdjustmentResult is the outcome of one BalanceAdjustment: the adjustment
+// transaction and the balance after it, or the error that kept it from
+// being applied
+type AdjustmentResult struct {
+	TransactionID string
+	BalanceCents  int64
+	Err           error
+}
+
+// TransferEvent describes a committed movement of money between two accounts,
+// published to LedgerService subscribers
+type TransferEvent struct {
+	TransactionID string
+	Kind          string
+	FromID        string
+	ToID          string
+	Amount        int64
+	Currency      string
+	CreatedAt     time.Time
+}
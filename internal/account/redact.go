@@ -0,0 +1,85 @@
+package account
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"apex-ledger/internal/auth"
+	"apex-ledger/pkg/api"
+)
+
+// redactors clears each redactable GetAccountResponse field, keyed by its
+// proto name
+var redactors = map[string]func(*api.GetAccountResponse){
+	"owner_id":             func(r *api.GetAccountResponse) { r.OwnerId = "" },
+	"parent_id":            func(r *api.GetAccountResponse) { r.ParentId = "" },
+	"notification_channel": func(r *api.GetAccountResponse) { r.NotificationChannel = "" },
+	"sensitive":            func(r *api.GetAccountResponse) { r.Sensitive = false },
+}
+
+// RedactionPolicy hides account fields from callers who don't own the
+// account. Each listed field is cleared unless the caller holds one of the
+// roles that reveal it, so a support agent can see balances while a finance
+// admin also sees the owner. Owners always see their own accounts in full.
+// The zero value redacts nothing.
+type RedactionPolicy struct {
+	// reveal maps each redacted field to the roles that may still see it
+	reveal map[string][]string
+}
+
+// ParseRedactionPolicy parses "field=role|role,field=..." pairs, e.g.
+// "owner_id=finance,notification_channel=finance|support". A field with no
+// roles ("parent_id=") is hidden from every non-owner. The fields are
+// owner_id, parent_id, notification_channel and sensitive.
+func ParseRedactionPolicy(spec string) (RedactionPolicy, error) {
+	var p RedactionPolicy
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, roles, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		if !ok {
+			return RedactionPolicy{}, fmt.Errorf("redaction entry %q is not field=roles", pair)
+		}
+		if _, known := redactors[field]; !known {
+			return RedactionPolicy{}, fmt.Errorf("field %q cannot be redacted", field)
+		}
+		if p.reveal == nil {
+			p.reveal = make(map[string][]string)
+		}
+		p.reveal[field] = []string{}
+		for _, role := range strings.Split(roles, "|") {
+			if role = strings.TrimSpace(role); role != "" {
+				p.reveal[field] = append(p.reveal[field], role)
+			}
+		}
+	}
+	return p, nil
+}
+
+// Fields lists the redacted fields, sorted
+func (p RedactionPolicy) Fields() []string {
+	fields := make([]string, 0, len(p.reveal))
+	for field := range p.reveal {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// Redact clears from resp, an account owned by ownerID, the fields user may
+// not see. A nil user, or one without an ID, sees nothing the policy hides.
+func (p RedactionPolicy) Redact(user *auth.User, ownerID string, resp *api.GetAccountResponse) {
+	if user != nil && user.ID != "" && user.ID == ownerID {
+		return
+	}
+	for field, roles := range p.reveal {
+		if user != nil && slices.ContainsFunc(roles, user.HasRole) {
+			continue
+		}
+		redactors[field](resp)
+	}
+}
@@ -0,0 +1,115 @@
+package account
+
+import (
+	"slices"
+	"testing"
+
+	"apex-ledger/internal/auth"
+	"apex-ledger/internal/config"
+	"apex-ledger/pkg/api"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// fullAccount returns a response with every redactable field set
+func fullAccount() *api.GetAccountResponse {
+	return &api.GetAccountResponse{
+		AccountId:           "acc-1",
+		OwnerId:             "user-1",
+		ParentId:            "acc-0",
+		NotificationChannel: "email",
+		Sensitive:           true,
+	}
+}
+
+func TestRedact(t *testing.T) {
+	policy, err := ParseRedactionPolicy("owner_id=finance,notification_channel=finance|support,parent_id=")
+	if err != nil {
+		t.Fatalf("ParseRedactionPolicy: %v", err)
+	}
+	tests := []struct {
+		name        string
+		user        *auth.User
+		owner       string
+		showOwner   bool
+		showChannel bool
+		showParent  bool
+	}{
+		{name: "owner", user: &auth.User{ID: "user-1"}, owner: "user-1", showOwner: true, showChannel: true, showParent: true},
+		{name: "admin", user: &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}}, owner: "user-1"},
+		{name: "admin with a revealing role", user: &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin, "finance"}}, owner: "user-1", showOwner: true, showChannel: true},
+		{name: "other user", user: &auth.User{ID: "user-2"}, owner: "user-1"},
+		{name: "other user with a revealing role", user: &auth.User{ID: "user-2", Roles: []string{"support"}}, owner: "user-1", showChannel: true},
+		{name: "empty ID on an ownerless account", user: &auth.User{}, owner: ""},
+		{name: "no caller", owner: "user-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := fullAccount()
+			policy.Redact(tt.user, tt.owner, resp)
+
+			if got := resp.OwnerId != ""; got != tt.showOwner {
+				t.Errorf("owner_id shown = %v, want %v", got, tt.showOwner)
+			}
+			if got := resp.NotificationChannel != ""; got != tt.showChannel {
+				t.Errorf("notification_channel shown = %v, want %v", got, tt.showChannel)
+			}
+			if got := resp.ParentId != ""; got != tt.showParent {
+				t.Errorf("parent_id shown = %v, want %v", got, tt.showParent)
+			}
+			// Fields outside the policy are never touched
+			if resp.AccountId != "acc-1" || !resp.Sensitive {
+				t.Errorf("unlisted fields changed: %+v", resp)
+			}
+		})
+	}
+}
+
+func TestRedactZeroPolicy(t *testing.T) {
+	resp := fullAccount()
+	RedactionPolicy{}.Redact(&auth.User{ID: "user-2"}, "user-1", resp)
+	if resp.OwnerId != "user-1" || resp.ParentId != "acc-0" || resp.NotificationChannel != "email" || !resp.Sensitive {
+		t.Fatalf("zero policy redacted %+v", resp)
+	}
+}
+
+func TestDefaultRedactionPolicy(t *testing.T) {
+	policy, err := ParseRedactionPolicy(config.DefaultRedactedFields)
+	if err != nil {
+		t.Fatalf("ParseRedactionPolicy: %v", err)
+	}
+	if got, want := policy.Fields(), []string{"notification_channel", "owner_id", "parent_id", "sensitive"}; !slices.Equal(got, want) {
+		t.Fatalf("redacted fields %v, want every redactable field %v", got, want)
+	}
+
+	tests := []struct {
+		name         string
+		user         *auth.User
+		showMetadata bool
+	}{
+		{name: "support agent", user: &auth.User{ID: "agent-1", Roles: []string{auth.RoleAdmin, "support"}}},
+		{name: "finance admin", user: &auth.User{ID: "finance-1", Roles: []string{auth.RoleAdmin, "finance"}}, showMetadata: true},
+		{name: "admin", user: &auth.User{ID: "admin-1", Roles: []string{auth.RoleAdmin}}},
+		{name: "owner", user: &auth.User{ID: "user-1"}, showMetadata: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := fullAccount()
+			resp.BalanceCents, resp.Currency = 500, "USD"
+			policy.Redact(tt.user, "user-1", resp)
+
+			// Balances are never redacted
+			if resp.BalanceCents != 500 || resp.Currency != "USD" {
+				t.Fatalf("balance redacted: %+v", resp)
+			}
+			want := fullAccount()
+			want.BalanceCents, want.Currency = 500, "USD"
+			if !tt.showMetadata {
+				want.OwnerId, want.ParentId, want.NotificationChannel, want.Sensitive = "", "", "", false
+			}
+			if !proto.Equal(resp, want) {
+				t.Fatalf("got %+v, want %+v", resp, want)
+			}
+		})
+	}
+}
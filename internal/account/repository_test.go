@@ -0,0 +1,322 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+// newMockRepo returns a repository over a sqlmock connection with a
+// transaction already open on it; the test fails if the mock's expectations
+// aren't all met
+func newMockRepo(t *testing.T, opts ...RepositoryOption) (*Repository, sqlmock.Sqlmock, *sqlx.Tx) {
+	t.Helper()
+	raw, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	db := sqlx.NewDb(raw, "sqlmock")
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet database expectations: %v", err)
+		}
+		db.Close()
+	})
+	mock.ExpectBegin()
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	return NewRepository(db, opts...), mock, tx
+}
+
+func TestBalanceStorageScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		storage  BalanceStorage
+		returned any
+	}{
+		{name: "bigint", storage: BigintBalances, returned: int64(1250)},
+		{name: "numeric", storage: NumericBalances, returned: []byte("1250")},
+		{name: "numeric with zero fraction", storage: NumericBalances, returned: []byte("1250.00")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" debit", func(t *testing.T) {
+			repo, mock, tx := newMockRepo(t, WithBalanceStorage(tt.storage))
+			mock.ExpectQuery(`UPDATE accounts SET balance_cents = balance_cents - \$1`).
+				WithArgs(int64(250), "acc-1").
+				WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}).AddRow(tt.returned))
+			balance, err := repo.Debit(context.Background(), tx, "acc-1", 250)
+			if err != nil {
+				t.Fatalf("Debit: %v", err)
+			}
+			if balance != 1250 {
+				t.Fatalf("balance = %d, want 1250", balance)
+			}
+		})
+		t.Run(tt.name+" credit", func(t *testing.T) {
+			repo, mock, tx := newMockRepo(t, WithBalanceStorage(tt.storage))
+			mock.ExpectQuery(`UPDATE accounts SET balance_cents = balance_cents \+ \$1`).
+				WithArgs(int64(250), "acc-1").
+				WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}).AddRow(tt.returned))
+			balance, err := repo.Credit(context.Background(), tx, "acc-1", 250)
+			if err != nil {
+				t.Fatalf("Credit: %v", err)
+			}
+			if balance != 1250 {
+				t.Fatalf("balance = %d, want 1250", balance)
+			}
+		})
+	}
+}
+
+func TestNumericBalanceOutOfRange(t *testing.T) {
+	repo, mock, tx := newMockRepo(t, WithBalanceStorage(NumericBalances))
+	mock.ExpectQuery(`UPDATE accounts SET balance_cents = balance_cents \+ \$1`).
+		WithArgs(int64(1), "acc-1").
+		WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}).AddRow([]byte("9223372036854775808")))
+	_, err := repo.Credit(context.Background(), tx, "acc-1", 1)
+	if !errors.Is(err, ErrBalanceOutOfRange) {
+		t.Fatalf("got %v, want ErrBalanceOutOfRange", err)
+	}
+}
+
+func TestNumericAccountColumns(t *testing.T) {
+	repo, _, _ := newMockRepo(t, WithBalanceStorage(NumericBalances))
+	if !strings.Contains(repo.accountColumns, "balance_cents::BIGINT") {
+		t.Fatalf("account columns %q don't cast the NUMERIC balance", repo.accountColumns)
+	}
+}
+
+func TestUpdateAccountCurrencyLocked(t *testing.T) {
+	repo, mock, tx := newMockRepo(t)
+	mock.ExpectExec(`UPDATE accounts SET currency = \$1`).
+		WithArgs("EUR", "acc-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT balance_cents FROM accounts WHERE id = \$1`).
+		WithArgs("acc-1").
+		WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}).AddRow(int64(500)))
+
+	err := repo.UpdateAccountTx(context.Background(), tx, "acc-1", "EUR")
+	if !errors.Is(err, ErrCurrencyLocked) {
+		t.Fatalf("got %v, want ErrCurrencyLocked", err)
+	}
+}
+
+func TestUpdateAccountCurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		found   bool
+		wantErr string
+	}{
+		{name: "zero balance", found: true},
+		{name: "missing account", wantErr: "account acc-1 not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, tx := newMockRepo(t)
+			exec := mock.ExpectExec(`UPDATE accounts SET currency = \$1`).WithArgs("EUR", "acc-1")
+			if tt.found {
+				exec.WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT balance_cents FROM accounts WHERE id = \$1`).
+					WithArgs("acc-1").
+					WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}))
+			}
+
+			err := repo.UpdateAccountTx(context.Background(), tx, "acc-1", "EUR")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("UpdateAccountTx: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("got %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAccountWithLockNotFound(t *testing.T) {
+	repo, mock, tx := newMockRepo(t)
+	mock.ExpectQuery(`FROM accounts WHERE id = \$1 FOR UPDATE`).
+		WithArgs("acc-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetAccountWithLock(context.Background(), tx, "acc-1")
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("got %v, want ErrAccountNotFound", err)
+	}
+	// Handlers still match on the message
+	if err.Error() != "account acc-1 not found" {
+		t.Fatalf("message = %q", err.Error())
+	}
+}
+
+func TestDebit(t *testing.T) {
+	const debit = `UPDATE accounts SET balance_cents = balance_cents - \$1`
+	t.Run("applied", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectQuery(debit).
+			WithArgs(int64(300), "acc-1").
+			WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}).AddRow(int64(700)))
+		balance, err := repo.Debit(context.Background(), tx, "acc-1", 300)
+		if err != nil {
+			t.Fatalf("Debit: %v", err)
+		}
+		if balance != 700 {
+			t.Fatalf("balance = %d, want 700", balance)
+		}
+	})
+	t.Run("insufficient funds", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectQuery(debit).
+			WithArgs(int64(300), "acc-1").
+			WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}))
+		mock.ExpectQuery(`FROM accounts WHERE id = \$1`).
+			WithArgs("acc-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance_cents", "overdraft_limit_cents"}).AddRow("acc-1", int64(100), int64(50)))
+		_, err := repo.Debit(context.Background(), tx, "acc-1", 300)
+		var insufficient *InsufficientFundsError
+		if !errors.As(err, &insufficient) {
+			t.Fatalf("got %v, want InsufficientFundsError", err)
+		}
+		want := InsufficientFundsError{AccountID: "acc-1", BalanceCents: 100, OverdraftLimitCents: 50, RequiredCents: 300}
+		if *insufficient != want {
+			t.Fatalf("got %+v, want %+v", *insufficient, want)
+		}
+	})
+	t.Run("missing account", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectQuery(debit).WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}))
+		mock.ExpectQuery(`FROM accounts WHERE id = \$1`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		_, err := repo.Debit(context.Background(), tx, "acc-1", 300)
+		if !errors.Is(err, ErrAccountNotFound) {
+			t.Fatalf("got %v, want ErrAccountNotFound", err)
+		}
+	})
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		dbErr := errors.New("connection reset")
+		mock.ExpectQuery(debit).WillReturnError(dbErr)
+		_, err := repo.Debit(context.Background(), tx, "acc-1", 300)
+		if !errors.Is(err, dbErr) {
+			t.Fatalf("got %v, want %v", err, dbErr)
+		}
+	})
+	t.Run("non-positive amount", func(t *testing.T) {
+		repo, _, tx := newMockRepo(t)
+		for _, amount := range []int64{0, -1} {
+			if _, err := repo.Debit(context.Background(), tx, "acc-1", amount); !errors.Is(err, ErrNonPositiveAmount) {
+				t.Fatalf("debit of %d: got %v, want ErrNonPositiveAmount", amount, err)
+			}
+		}
+	})
+}
+
+func TestCredit(t *testing.T) {
+	const credit = `UPDATE accounts SET balance_cents = balance_cents \+ \$1`
+	t.Run("applied", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectQuery(credit).
+			WithArgs(int64(300), "acc-1").
+			WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}).AddRow(int64(1300)))
+		balance, err := repo.Credit(context.Background(), tx, "acc-1", 300)
+		if err != nil {
+			t.Fatalf("Credit: %v", err)
+		}
+		if balance != 1300 {
+			t.Fatalf("balance = %d, want 1300", balance)
+		}
+	})
+	t.Run("missing account", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectQuery(credit).WillReturnRows(sqlmock.NewRows([]string{"balance_cents"}))
+		_, err := repo.Credit(context.Background(), tx, "acc-1", 300)
+		if !errors.Is(err, ErrAccountNotFound) {
+			t.Fatalf("got %v, want ErrAccountNotFound", err)
+		}
+	})
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		dbErr := errors.New("connection reset")
+		mock.ExpectQuery(credit).WillReturnError(dbErr)
+		_, err := repo.Credit(context.Background(), tx, "acc-1", 300)
+		if !errors.Is(err, dbErr) {
+			t.Fatalf("got %v, want %v", err, dbErr)
+		}
+	})
+	t.Run("non-positive amount", func(t *testing.T) {
+		repo, _, tx := newMockRepo(t)
+		for _, amount := range []int64{0, -1} {
+			if _, err := repo.Credit(context.Background(), tx, "acc-1", amount); !errors.Is(err, ErrNonPositiveAmount) {
+				t.Fatalf("credit of %d: got %v, want ErrNonPositiveAmount", amount, err)
+			}
+		}
+	})
+}
+
+func TestRecordTransaction(t *testing.T) {
+	const (
+		insert  = `INSERT INTO transactions`
+		journal = `INSERT INTO ledger_events`
+	)
+	newTransfer := func() *Transaction {
+		fromAfter, toAfter := int64(700), int64(1300)
+		return &Transaction{
+			ID:               "tx-1",
+			FromAccountID:    "acc-a",
+			ToAccountID:      "acc-b",
+			AmountCents:      300,
+			Currency:         "USD",
+			FromBalanceAfter: &fromAfter,
+			ToBalanceAfter:   &toAfter,
+		}
+	}
+	t.Run("recorded and journaled", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(journal).
+			WithArgs("acc-a", "tx-1", TransactionKindTransfer, int64(-300), sqlmock.AnyArg(), "USD", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(journal).
+			WithArgs("acc-b", "tx-1", TransactionKindTransfer, int64(300), sqlmock.AnyArg(), "USD", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		if err := repo.RecordTransaction(context.Background(), tx, newTransfer()); err != nil {
+			t.Fatalf("RecordTransaction: %v", err)
+		}
+	})
+	t.Run("duplicate reference", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		mock.ExpectExec(insert).WillReturnError(&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: externalReferenceIndex})
+		rec := newTransfer()
+		rec.ExternalReference = "ref-1"
+		if err := repo.RecordTransaction(context.Background(), tx, rec); !errors.Is(err, ErrDuplicateReference) {
+			t.Fatalf("got %v, want ErrDuplicateReference", err)
+		}
+	})
+	t.Run("insert error", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		dbErr := errors.New("connection reset")
+		mock.ExpectExec(insert).WillReturnError(dbErr)
+		if err := repo.RecordTransaction(context.Background(), tx, newTransfer()); !errors.Is(err, dbErr) {
+			t.Fatalf("got %v, want %v", err, dbErr)
+		}
+	})
+	t.Run("journal error", func(t *testing.T) {
+		repo, mock, tx := newMockRepo(t)
+		dbErr := errors.New("connection reset")
+		mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(journal).WillReturnError(dbErr)
+		if err := repo.RecordTransaction(context.Background(), tx, newTransfer()); !errors.Is(err, dbErr) {
+			t.Fatalf("got %v, want %v", err, dbErr)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Store is the persistence the ledger service depends on. Repository is the
+// Postgres implementation; the interface lets the service run against any
+// other, such as an in-memory fake. Methods taking a *sqlx.Tx run inside the
+// caller's transaction; GetAccount, GetAccountsByIDs and GetTransaction join
+// the transaction their context carries (database.ContextWithTx), if any.
+type Store interface {
+	// Accounts
+	GetAccount(ctx context.Context, id string) (*Account, error)
+	GetAccountWithLock(ctx context.Context, tx *sqlx.Tx, id string) (*Account, error)
+	GetAccountTx(ctx context.Context, tx *sqlx.Tx, id string) (*Account, error)
+	LockAccountsAdvisory(ctx context.Context, tx *sqlx.Tx, ids []string) error
+	GetAccountsByIDs(ctx context.Context, ids []string) ([]Account, error)
+	GetAllAccounts(ctx context.Context, limit, offset int) ([]Account, error)
+	GetAccountsAfter(ctx context.Context, afterID, currency string, limit int) ([]Account, error)
+	GetAccountsByCurrency(ctx context.Context, currency string, limit, offset int) ([]Account, error)
+	GetAccountsByOwner(ctx context.Context, ownerID string, limit, offset int) ([]Account, error)
+	GetAccountCount(ctx context.Context) (int64, error)
+	GetAccountCountByOwner(ctx context.Context, ownerID string) (int64, error)
+	CountAccountsByOwnerTx(ctx context.Context, tx *sqlx.Tx, ownerID string) (int64, error)
+	GetCurrencyTotals(ctx context.Context, currency string) (int64, int64, error)
+	CreateAccountTx(ctx context.Context, tx *sqlx.Tx, acc *Account) error
+	UpdateAccountTx(ctx context.Context, tx *sqlx.Tx, id string, currency string) error
+	DeleteAccountTx(ctx context.Context, tx *sqlx.Tx, id string) error
+	ActivateAccountTx(ctx context.Context, tx *sqlx.Tx, id string) (bool, error)
+	SetNotificationChannelTx(ctx context.Context, tx *sqlx.Tx, id, channel string) (bool, error)
+	SetSensitiveTx(ctx context.Context, tx *sqlx.Tx, id string, sensitive bool) (bool, error)
+
+	// Balances
+	Debit(ctx context.Context, tx *sqlx.Tx, id string, amount int64) (int64, error)
+	Credit(ctx context.Context, tx *sqlx.Tx, id string, amount int64) (int64, error)
+	GetBalanceChecks(ctx context.Context, afterID string, limit int, quietPeriod time.Duration) ([]BalanceCheck, error)
+	CreateBalanceSnapshots(ctx context.Context, takenAt time.Time) (int64, error)
+	GetNearestSnapshot(ctx context.Context, accountID string, at time.Time) (*BalanceSnapshot, error)
+	GetNetChange(ctx context.Context, accountID string, after, upTo time.Time) (int64, error)
+	GetInterestBearingAccountsAfter(ctx context.Context, afterID, prefix string, limit int) ([]Account, error)
+	GetOverdrawnAccountsAfter(ctx context.Context, afterID, prefix string, overdrawnBy time.Time, limit int) ([]Account, error)
+	ChargeOverdraftPenalty(ctx context.Context, tx *sqlx.Tx, id string, amount int64, overdrawnBy time.Time) (int64, bool, error)
+
+	// Transactions
+	RecordTransaction(ctx context.Context, tx *sqlx.Tx, t *Transaction) error
+	GetTransaction(ctx context.Context, id string) (*Transaction, error)
+	GetTransactionForUpdate(ctx context.Context, tx *sqlx.Tx, id string) (*Transaction, error)
+	ReadLedgerEvents(ctx context.Context, accountID string, fromSeq int64, limit int) ([]LedgerEvent, error)
+	GetReversibleTransfersInWindow(ctx context.Context, from, to time.Time, accountID, afterID string, limit int) ([]Transaction, error)
+	GetTransactionByExternalReference(ctx context.Context, ref string) (*Transaction, error)
+	GetTransactionHistory(ctx context.Context, accountID, category string, cursor *HistoryCursor, limit int) ([]Transaction, error)
+	GetTransactionsAfter(ctx context.Context, filter TransactionFilter, after *HistoryCursor, limit int) ([]Transaction, error)
+	AddReversedAmount(ctx context.Context, tx *sqlx.Tx, id string, amount int64) error
+
+	// Hierarchy
+	LockHierarchy(ctx context.Context, tx *sqlx.Tx) error
+	IsAncestorOrSelf(ctx context.Context, tx *sqlx.Tx, ancestorID, id string) (bool, error)
+	SetParent(ctx context.Context, tx *sqlx.Tx, id, parentID string) error
+	GetAggregateBalance(ctx context.Context, id string) ([]CurrencyBalance, error)
+
+	// Audit log
+	RecordAudit(ctx context.Context, e *AuditEntry) error
+	RecordAuditTx(ctx context.Context, tx *sqlx.Tx, e *AuditEntry) error
+	QueryAuditLog(ctx context.Context, filter AuditFilter, afterID int64, limit int) ([]AuditEntry, error)
+
+	// Dead letters
+	ListDeadLetters(ctx context.Context, afterID int64, limit int) ([]DeadLetter, error)
+	TakeDeadLetters(ctx context.Context, ids []int64, limit int) ([]DeadLetter, error)
+}
+
+var _ Store = (*Repository)(nil)
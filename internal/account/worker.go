@@ -1,44 +1,376 @@
-package account
-
-import (
-	"log"
-)
-
-// Notification represents a notification job
-type Notification struct {
-	AccountID string
-	Message   string
-}
-
-// NotificationWorkerPool manages async notification tasks
-type NotificationWorkerPool struct {
-	JobQueue chan Notification
-}
-
-// NewNotificationWorkerPool creates a new worker pool
-func NewNotificationWorkerPool(bufferSize int) *NotificationWorkerPool {
-	return &NotificationWorkerPool{
-		JobQueue: make(chan Notification, bufferSize),
-	}
-}
-
-// Start spawns N worker goroutines
-func (p *NotificationWorkerPool) Start(workerCount int) {
-	for i := 0; i < workerCount; i++ {
-		go func(id int) {
-			for job := range p.JobQueue {
-				// Simulating external API call (Email/SMS)
-				log.Printf("Worker %d: Sending notification to %s: %s", id, job.AccountID, job.Message)
-			}
-		}(i)
-	}
-}
-
-// Enqueue adds a notification job to the queue
-func (p *NotificationWorkerPool) Enqueue(notification Notification) {
-	select {
-	case p.JobQueue <- notification:
-	default:
-		log.Printf("Warning: notification queue full, dropping notification for %s", notification.AccountID)
-	}
-}
+package account
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"apex-ledger/internal/platform/metrics"
+)
+
+var deadLettered = metrics.NewCounter("notifications_dead_lettered")
+
+// errDrainDeadline is the dead-letter reason for notifications Stop could not
+// deliver before its deadline
+var errDrainDeadline = errors.New("shutdown drain deadline passed")
+
+// retryBackoff is the pause before a failed notification's next attempt,
+// multiplied by the number of attempts made so far
+const retryBackoff = 200 * time.Millisecond
+
+// deadLetterTimeout bounds persisting one dead letter
+const deadLetterTimeout = 5 * time.Second
+
+// enqueueWaitTimeout bounds how long Enqueue on an unbuffered pool waits for
+// a worker. Enqueue runs after money has moved, so it must not hold the
+// caller's response up indefinitely.
+const enqueueWaitTimeout = 5 * time.Second
+
+// Notification represents a notification job
+type Notification struct {
+	// ID optionally identifies the job; replays of a recently processed ID are skipped
+	ID        string
+	AccountID string
+	// Channel is the account's preferred delivery channel; the pool routes
+	// the job to the sender registered for it, or the default sender
+	Channel string
+	// ActorID is the authenticated user whose operation sent the
+	// notification, or "system" for background jobs
+	ActorID string
+	Message string
+}
+
+// NotificationSender delivers one notification to the outside world
+type NotificationSender func(ctx context.Context, n Notification) error
+
+// DeadLetterStore persists notifications that could not be delivered
+type DeadLetterStore interface {
+	SaveDeadLetter(ctx context.Context, d *DeadLetter) error
+}
+
+// NotificationWorkerPool manages async notification tasks
+type NotificationWorkerPool struct {
+	JobQueue chan Notification
+	wg       sync.WaitGroup
+	dedup    *recentIDs
+	blocking bool
+
+	send        NotificationSender
+	senders     map[string]NotificationSender
+	maxAttempts int
+	deadLetters DeadLetterStore
+
+	// mu guards the worker set. Each running worker owns a quit channel;
+	// closing it retires that worker once it finishes its current job.
+	mu      sync.Mutex
+	quits   []chan struct{}
+	nextID  int
+	stopped bool
+	active  atomic.Int64
+
+	// queueMu orders sends against Stop closing JobQueue: Enqueue holds it
+	// shared while sending, Stop exclusively while closing, so a late
+	// Enqueue sees closed instead of sending on a closed channel
+	queueMu sync.RWMutex
+	closed  bool
+
+	// sendCtx is passed to senders and cancelled by abort when Stop's drain
+	// deadline passes; workers then dead-letter instead of delivering
+	sendCtx context.Context
+	abort   context.CancelFunc
+}
+
+// PoolOption configures optional NotificationWorkerPool behaviour
+type PoolOption func(*NotificationWorkerPool)
+
+// WithSender replaces the default sender, which only logs the notification
+func WithSender(send NotificationSender) PoolOption {
+	return func(p *NotificationWorkerPool) {
+		p.send = send
+	}
+}
+
+// WithChannelSender registers the sender for notifications whose Channel is
+// channel. Notifications for a channel without a sender go to the default
+// sender.
+func WithChannelSender(channel string, send NotificationSender) PoolOption {
+	return func(p *NotificationWorkerPool) {
+		if p.senders == nil {
+			p.senders = make(map[string]NotificationSender)
+		}
+		p.senders[channel] = send
+	}
+}
+
+// WithMaxAttempts sets how many times a notification is tried before it is
+// dead-lettered (default 3)
+func WithMaxAttempts(n int) PoolOption {
+	return func(p *NotificationWorkerPool) {
+		p.maxAttempts = max(n, 1)
+	}
+}
+
+// WithDeadLetterStore persists notifications that exhaust their attempts or
+// are dropped because the queue is full; without it they are only logged
+func WithDeadLetterStore(store DeadLetterStore) PoolOption {
+	return func(p *NotificationWorkerPool) {
+		p.deadLetters = store
+	}
+}
+
+// NewNotificationWorkerPool creates a new worker pool.
+// A bufferSize of 0 gives an unbuffered queue where Enqueue waits (up to
+// enqueueWaitTimeout) for a worker to take the job, instead of dropping it
+// as soon as the queue is full.
+// dedupWindow is how many recent job IDs are remembered for duplicate
+// suppression; 0 disables it. Dedup is in-memory and best-effort only.
+func NewNotificationWorkerPool(bufferSize, dedupWindow int, opts ...PoolOption) *NotificationWorkerPool {
+	p := &NotificationWorkerPool{
+		JobQueue:    make(chan Notification, bufferSize),
+		blocking:    bufferSize == 0,
+		send:        logNotification,
+		maxAttempts: 3,
+	}
+	p.sendCtx, p.abort = context.WithCancel(context.Background())
+	if dedupWindow > 0 {
+		p.dedup = newRecentIDs(dedupWindow)
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// logNotification is the default sender
+func logNotification(ctx context.Context, n Notification) error {
+	// Simulating external API call (Email/SMS)
+	if n.Channel != "" {
+		log.Printf("Sending notification to %s via %s: %s", n.AccountID, n.Channel, n.Message)
+		return nil
+	}
+	log.Printf("Sending notification to %s: %s", n.AccountID, n.Message)
+	return nil
+}
+
+// Start spawns N worker goroutines
+func (p *NotificationWorkerPool) Start(workerCount int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := 0; i < workerCount; i++ {
+		p.spawn()
+	}
+}
+
+// Resize grows or shrinks the pool to workerCount workers. New workers start
+// immediately; retired workers finish the job they are processing, if any,
+// and exit without taking another, so no queued job is lost.
+func (p *NotificationWorkerPool) Resize(workerCount int) error {
+	if workerCount < 1 {
+		return errors.New("worker count must be at least 1")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return errors.New("worker pool is stopped")
+	}
+	for len(p.quits) < workerCount {
+		p.spawn()
+	}
+	for len(p.quits) > workerCount {
+		last := len(p.quits) - 1
+		close(p.quits[last])
+		p.quits = p.quits[:last]
+	}
+	return nil
+}
+
+// Size is the number of workers the pool is scaled to
+func (p *NotificationWorkerPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.quits)
+}
+
+// ActiveWorkers is the number of worker goroutines still running, including
+// retired workers finishing their last job
+func (p *NotificationWorkerPool) ActiveWorkers() int {
+	return int(p.active.Load())
+}
+
+// spawn starts one worker; p.mu must be held
+func (p *NotificationWorkerPool) spawn() {
+	quit := make(chan struct{})
+	p.quits = append(p.quits, quit)
+	id := p.nextID
+	p.nextID++
+
+	p.wg.Add(1)
+	p.active.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer p.active.Add(-1)
+		for {
+			select {
+			case <-quit:
+				return
+			case <-p.sendCtx.Done():
+				return
+			case job, ok := <-p.JobQueue:
+				if !ok {
+					return
+				}
+				p.process(id, job)
+			}
+		}
+	}()
+}
+
+// process delivers job, retrying with backoff, and dead-letters it once
+// every attempt has failed
+func (p *NotificationWorkerPool) process(id int, job Notification) {
+	if job.ID != "" && p.dedup != nil && p.dedup.seen(job.ID) {
+		log.Printf("Worker %d: Skipping duplicate notification %s", id, job.ID)
+		return
+	}
+
+	send := p.senderFor(job.Channel)
+	var err error
+	attempts := 0
+	for attempts < p.maxAttempts {
+		// A job picked up after the drain deadline, or still retrying at
+		// it, is dead-lettered rather than lost at exit
+		if p.sendCtx.Err() != nil {
+			err = errDrainDeadline
+			break
+		}
+		attempts++
+		if err = send(p.sendCtx, job); err == nil {
+			return
+		}
+		log.Printf("Worker %d: Notification to %s failed (attempt %d/%d): %v", id, job.AccountID, attempts, p.maxAttempts, err)
+		if attempts < p.maxAttempts {
+			select {
+			case <-time.After(retryBackoff * time.Duration(attempts)):
+			case <-p.sendCtx.Done():
+			}
+		}
+	}
+
+	// Let a retry from the dead-letter store through the dedup check
+	if job.ID != "" && p.dedup != nil {
+		p.dedup.forget(job.ID)
+	}
+	p.deadLetter(job, err.Error(), attempts)
+}
+
+// senderFor returns the sender registered for channel, or the default sender
+func (p *NotificationWorkerPool) senderFor(channel string) NotificationSender {
+	if send, ok := p.senders[channel]; ok {
+		return send
+	}
+	return p.send
+}
+
+// deadLetter records a notification that will not be delivered
+func (p *NotificationWorkerPool) deadLetter(job Notification, reason string, attempts int) {
+	deadLettered.Add(1)
+	if p.deadLetters == nil {
+		log.Printf("Warning: dropping notification for %s: %s", job.AccountID, reason)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadLetterTimeout)
+	defer cancel()
+	d := &DeadLetter{
+		NotificationID: job.ID,
+		AccountID:      job.AccountID,
+		Channel:        job.Channel,
+		ActorID:        job.ActorID,
+		Message:        job.Message,
+		Reason:         reason,
+		Attempts:       attempts,
+	}
+	if err := p.deadLetters.SaveDeadLetter(ctx, d); err != nil {
+		log.Printf("Warning: failed to dead-letter notification for %s (%s): %v", job.AccountID, reason, err)
+	}
+}
+
+// Enqueue adds a notification job to the queue. On a buffered pool it
+// dead-letters the job if the queue is full; on an unbuffered pool it waits
+// up to enqueueWaitTimeout for a worker, then dead-letters it. Once the pool
+// is stopped, jobs are dead-lettered. It never fails, so callers can use it
+// after committing without putting the commit's result at risk.
+func (p *NotificationWorkerPool) Enqueue(notification Notification) {
+	p.queueMu.RLock()
+	defer p.queueMu.RUnlock()
+	if p.closed {
+		p.deadLetter(notification, "worker pool stopped", 0)
+		return
+	}
+	if p.blocking {
+		timer := time.NewTimer(enqueueWaitTimeout)
+		defer timer.Stop()
+		select {
+		case p.JobQueue <- notification:
+		case <-timer.C:
+			p.deadLetter(notification, "no worker available", 0)
+		}
+		return
+	}
+	select {
+	case p.JobQueue <- notification:
+	default:
+		p.deadLetter(notification, "queue full", 0)
+	}
+}
+
+// Stop closes the queue and waits for the workers to drain it. It is safe to
+// call more than once, and concurrently with Enqueue.
+// If the workers don't finish before ctx is done, Stop cancels the context
+// given to senders, dead-letters every job still queued or retrying, and
+// returns ctx.Err().
+func (p *NotificationWorkerPool) Stop(ctx context.Context) error {
+	p.mu.Lock()
+	p.stopped = true
+	p.mu.Unlock()
+
+	p.queueMu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.JobQueue)
+	}
+	p.queueMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Out of time: stop the workers taking jobs and dead-letter what is left
+	p.abort()
+	dropped := 0
+	for job := range p.JobQueue {
+		p.deadLetter(job, errDrainDeadline.Error(), 0)
+		dropped++
+	}
+	if dropped > 0 {
+		log.Printf("Warning: notification drain deadline passed, dead-lettered %d queued notifications", dropped)
+	}
+	// Give in-flight sends a moment to observe the cancellation and
+	// dead-letter their jobs
+	select {
+	case <-done:
+	case <-time.After(deadLetterTimeout):
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,231 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// deadLetterRecorder is a DeadLetterStore keeping dead letters in memory
+type deadLetterRecorder struct {
+	mu      sync.Mutex
+	letters []*DeadLetter
+}
+
+func (r *deadLetterRecorder) SaveDeadLetter(ctx context.Context, d *DeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.letters = append(r.letters, d)
+	return nil
+}
+
+func (r *deadLetterRecorder) reasons() map[string]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reasons := make(map[string]int)
+	for _, d := range r.letters {
+		reasons[d.Reason]++
+	}
+	return reasons
+}
+
+func (r *deadLetterRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.letters)
+}
+
+func TestStopDuringEnqueue(t *testing.T) {
+	var delivered atomic.Int64
+	dead := &deadLetterRecorder{}
+	pool := NewNotificationWorkerPool(8, 0,
+		WithSender(func(ctx context.Context, n Notification) error {
+			delivered.Add(1)
+			return nil
+		}),
+		WithDeadLetterStore(dead),
+	)
+	pool.Start(2)
+
+	const senders, perSender = 8, 50
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for j := 0; j < perSender; j++ {
+				pool.Enqueue(Notification{ID: fmt.Sprintf("n-%d-%d", i, j), AccountID: "acc-1"})
+			}
+		}()
+	}
+	close(start)
+
+	// Stop, and stop again, while the senders are still enqueueing; a send
+	// on the closed queue would panic
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stopped := make(chan error, 2)
+	go func() { stopped <- pool.Stop(ctx) }()
+	go func() { stopped <- pool.Stop(ctx) }()
+	wg.Wait()
+	for i := 0; i < 2; i++ {
+		if err := <-stopped; err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+	}
+
+	// Every notification was either delivered or dead-lettered
+	if got := int(delivered.Load()) + dead.count(); got != senders*perSender {
+		t.Fatalf("delivered %d and dead-lettered %d of %d notifications", delivered.Load(), dead.count(), senders*perSender)
+	}
+}
+
+func TestEnqueueAfterStop(t *testing.T) {
+	dead := &deadLetterRecorder{}
+	pool := NewNotificationWorkerPool(8, 0, WithDeadLetterStore(dead))
+	pool.Start(1)
+	if err := pool.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	pool.Enqueue(Notification{ID: "late", AccountID: "acc-1"})
+	if reasons := dead.reasons(); reasons["worker pool stopped"] != 1 {
+		t.Fatalf("dead letters %v, want the late notification", reasons)
+	}
+}
+
+func TestStopDrainsWithinDeadline(t *testing.T) {
+	var delivered atomic.Int64
+	dead := &deadLetterRecorder{}
+	pool := NewNotificationWorkerPool(16, 0,
+		WithSender(func(ctx context.Context, n Notification) error {
+			time.Sleep(time.Millisecond)
+			delivered.Add(1)
+			return nil
+		}),
+		WithDeadLetterStore(dead),
+	)
+	pool.Start(2)
+	for i := 0; i < 10; i++ {
+		pool.Enqueue(Notification{AccountID: "acc-1", Message: fmt.Sprint(i)})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := pool.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if delivered.Load() != 10 || dead.count() != 0 {
+		t.Fatalf("delivered %d and dead-lettered %d, want all 10 delivered", delivered.Load(), dead.count())
+	}
+}
+
+func TestStopDeadLettersAfterDeadline(t *testing.T) {
+	dead := &deadLetterRecorder{}
+	started := make(chan struct{}, 1)
+	pool := NewNotificationWorkerPool(16, 0,
+		// Deliveries hang until Stop gives up on them
+		WithSender(func(ctx context.Context, n Notification) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		WithDeadLetterStore(dead),
+	)
+	pool.Start(1)
+	for i := 0; i < 5; i++ {
+		pool.Enqueue(Notification{AccountID: "acc-1", Message: fmt.Sprint(i)})
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := pool.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stop returned %v, want context.DeadlineExceeded", err)
+	}
+
+	// The in-flight job and the four still queued are all dead-lettered
+	if reasons := dead.reasons(); reasons[errDrainDeadline.Error()] != 5 || len(reasons) != 1 {
+		t.Fatalf("dead letters by reason %v, want 5 for the drain deadline", reasons)
+	}
+}
+
+func TestResizeShrinkUnderLoad(t *testing.T) {
+	const total = 400
+	var delivered atomic.Int64
+	dead := &deadLetterRecorder{}
+	pool := NewNotificationWorkerPool(total, 0,
+		WithSender(func(ctx context.Context, n Notification) error {
+			time.Sleep(100 * time.Microsecond)
+			delivered.Add(1)
+			return nil
+		}),
+		WithDeadLetterStore(dead),
+	)
+	pool.Start(8)
+
+	// Resize up and down while the queue is being filled and drained
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, n := range []int{1, 6, 2, 8, 1} {
+			if err := pool.Resize(n); err != nil {
+				t.Errorf("Resize(%d): %v", n, err)
+			}
+			time.Sleep(2 * time.Millisecond)
+		}
+	}()
+	for i := 0; i < total; i++ {
+		pool.Enqueue(Notification{ID: fmt.Sprintf("n-%d", i), AccountID: "acc-1"})
+	}
+	<-done
+
+	if got := pool.Size(); got != 1 {
+		t.Fatalf("Size = %d, want 1", got)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := pool.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if delivered.Load() != total || dead.count() != 0 {
+		t.Fatalf("delivered %d and dead-lettered %d of %d, want every job delivered", delivered.Load(), dead.count(), total)
+	}
+	if got := pool.ActiveWorkers(); got != 0 {
+		t.Fatalf("%d workers still running after Stop", got)
+	}
+}
+
+func TestResizeRetiresIdleWorkers(t *testing.T) {
+	pool := NewNotificationWorkerPool(8, 0)
+	pool.Start(4)
+	if err := pool.Resize(1); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for pool.ActiveWorkers() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d workers running after shrinking to 1", pool.ActiveWorkers())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := pool.Resize(0); err == nil {
+		t.Fatal("Resize(0) accepted")
+	}
+	if err := pool.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if err := pool.Resize(2); err == nil {
+		t.Fatal("Resize accepted after Stop")
+	}
+}
@@ -1,47 +1,155 @@
-package auth
-
-import (
-	"context"
-	"fmt"
-	"strings"
-
-	"github.com/golang-jwt/jwt/v5"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
-)
-
-// AuthInterceptor handles JWT validation
-func AuthInterceptor(secretKey string) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		// 1. Extract metadata from context
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "metadata missing")
-		}
-
-		// 2. Get the Authorization header
-		authHeader := md.Get("authorization")
-		if len(authHeader) == 0 {
-			return nil, status.Error(codes.Unauthenticated, "authorization token missing")
-		}
-
-		// 3. Parse and Validate JWT
-		tokenStr := strings.TrimPrefix(authHeader[0], "Bearer ")
-		token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-			// Validate signing method to prevent algorithm confusion attacks
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-			}
-			return []byte(secretKey), nil
-		})
-
-		if err != nil || !token.Valid {
-			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
-		}
-
-		// 4. Proceed to the actual handler
-		return handler(ctx, req)
-	}
-}
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Option configures the AuthInterceptor
+type Option func(*options)
+
+type options struct {
+	leeway    time.Duration
+	audiences []string
+	public    map[string]bool
+}
+
+// WithLeeway tolerates clock skew of up to d when validating the exp and nbf
+// claims, so clients with slightly skewed clocks aren't rejected near token boundaries
+func WithLeeway(d time.Duration) Option {
+	return func(o *options) {
+		o.leeway = d
+	}
+}
+
+// WithAudience requires tokens to carry an aud claim naming at least one of
+// audiences; tokens without aud are rejected. No audiences disables the check.
+func WithAudience(audiences ...string) Option {
+	return func(o *options) {
+		o.audiences = audiences
+	}
+}
+
+// WithPublicMethods exempts the given full method names (e.g.
+// "/ledger.LedgerService/GetServerInfo") from authentication
+func WithPublicMethods(methods ...string) Option {
+	return func(o *options) {
+		if o.public == nil {
+			o.public = make(map[string]bool)
+		}
+		for _, m := range methods {
+			o.public[m] = true
+		}
+	}
+}
+
+// AuthInterceptor handles JWT validation, accepting tokens signed with any
+// secret currently valid in keys
+func AuthInterceptor(keys *Keyring, opts ...Option) grpc.UnaryServerInterceptor {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if o.public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		user, err := authenticate(ctx, keys, o)
+		if err != nil {
+			return nil, err
+		}
+
+		// Proceed to the actual handler
+		return handler(ContextWithUser(ctx, user), req)
+	}
+}
+
+// AuthStreamInterceptor applies the same JWT validation to streaming RPCs
+func AuthStreamInterceptor(keys *Keyring, opts ...Option) grpc.StreamServerInterceptor {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if o.public[info.FullMethod] {
+			return handler(srv, ss)
+		}
+		user, err := authenticate(ss.Context(), keys, o)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ContextWithUser(ss.Context(), user)})
+	}
+}
+
+// authenticatedStream overrides the stream context to carry the caller
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate validates the bearer token carried in the incoming metadata
+// and returns the caller it identifies. Each valid secret in keys is tried in
+// turn, so tokens signed before a rotation keep working.
+func authenticate(ctx context.Context, keys *Keyring, o options) (*User, error) {
+	// 1. Extract metadata from context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata missing")
+	}
+
+	// 2. Get the Authorization header
+	authHeader := md.Get("authorization")
+	if len(authHeader) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization token missing")
+	}
+
+	// 3. Parse and Validate JWT
+	tokenStr := strings.TrimPrefix(authHeader[0], "Bearer ")
+	parserOpts := []jwt.ParserOption{jwt.WithLeeway(o.leeway)}
+	if len(o.audiences) > 0 {
+		parserOpts = append(parserOpts, jwt.WithAudience(o.audiences...))
+	}
+	var (
+		claims jwt.MapClaims
+		token  *jwt.Token
+		err    error
+	)
+	for _, secret := range keys.validSecrets() {
+		claims = jwt.MapClaims{}
+		token, err = jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
+			// Validate signing method to prevent algorithm confusion attacks
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+			}
+			return []byte(secret), nil
+		}, parserOpts...)
+		// Only a signature mismatch means another secret may match
+		if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
+		}
+	}
+
+	if errors.Is(err, jwt.ErrTokenInvalidAudience) || errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		return nil, status.Error(codes.Unauthenticated, "token audience not accepted by this service")
+	}
+	if err != nil || !token.Valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	return userFromClaims(claims), nil
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "test-secret"
+
+// signToken returns an HS256 token over claims signed with secret
+func signToken(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+// callWithToken runs the unary interceptor over a handler that returns the
+// caller it was given, as if a request arrived bearing token
+func callWithToken(t *testing.T, keys *Keyring, token string, opts ...Option) (*User, error) {
+	t.Helper()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	info := &grpc.UnaryServerInfo{FullMethod: "/ledger.LedgerService/GetAccount"}
+	resp, err := AuthInterceptor(keys, opts...)(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		user, _ := UserFromContext(ctx)
+		return user, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*User), nil
+}
+
+func assertUnauthenticated(t *testing.T, err error) {
+	t.Helper()
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("got %v, want Unauthenticated", err)
+	}
+}
+
+func TestAuthInterceptorLeeway(t *testing.T) {
+	keys := NewKeyring(testSecret)
+	tests := []struct {
+		name      string
+		expiredBy time.Duration
+		wantErr   bool
+	}{
+		{name: "within leeway", expiredBy: 10 * time.Second},
+		{name: "past leeway", expiredBy: 60 * time.Second, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := signToken(t, testSecret, jwt.MapClaims{
+				"sub": "user-1",
+				"exp": time.Now().Add(-tt.expiredBy).Unix(),
+			})
+			user, err := callWithToken(t, keys, token, WithLeeway(30*time.Second))
+			if tt.wantErr {
+				assertUnauthenticated(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if user.ID != "user-1" {
+				t.Fatalf("user ID = %q, want user-1", user.ID)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorNoLeeway(t *testing.T) {
+	token := signToken(t, testSecret, jwt.MapClaims{
+		"sub": "user-1",
+		"exp": time.Now().Add(-10 * time.Second).Unix(),
+	})
+	_, err := callWithToken(t, NewKeyring(testSecret), token)
+	assertUnauthenticated(t, err)
+}
+
+func TestAuthInterceptorAudience(t *testing.T) {
+	keys := NewKeyring(testSecret)
+	tests := []struct {
+		name    string
+		aud     any
+		wantErr bool
+	}{
+		{name: "matching", aud: "ledger"},
+		{name: "one of several", aud: []string{"billing", "ledger"}},
+		{name: "not matching", aud: "billing", wantErr: true},
+		{name: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()}
+			if tt.aud != nil {
+				claims["aud"] = tt.aud
+			}
+			_, err := callWithToken(t, keys, signToken(t, testSecret, claims), WithAudience("ledger", "ledger-admin"))
+			if tt.wantErr {
+				assertUnauthenticated(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorAudienceUnchecked(t *testing.T) {
+	token := signToken(t, testSecret, jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()})
+	if _, err := callWithToken(t, NewKeyring(testSecret), token); err != nil {
+		t.Fatalf("token without aud rejected with no audience configured: %v", err)
+	}
+}
+
+func TestAuthInterceptorSubject(t *testing.T) {
+	keys := NewKeyring(testSecret)
+	user, err := callWithToken(t, keys, signToken(t, testSecret, jwt.MapClaims{
+		"sub":   "user-1",
+		"roles": []any{"admin", "support"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != "user-1" || !user.IsAdmin() || !user.HasRole("support") {
+		t.Fatalf("user = %+v", user)
+	}
+
+	// A token without sub is still accepted; the service attributes it to system
+	user, err = callWithToken(t, keys, signToken(t, testSecret, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}))
+	if err != nil {
+		t.Fatalf("token without sub rejected: %v", err)
+	}
+	if user.ID != "" {
+		t.Fatalf("user ID = %q, want empty", user.ID)
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Keyring holds the HMAC secrets tokens may be signed with: the primary,
+// which issuers should sign new tokens with, and previously used secrets
+// that still validate so live tokens survive a rotation. A retired secret
+// either stays valid until removed from the ring or, when rotated out with
+// a grace period, until that period ends. It is safe for concurrent use.
+type Keyring struct {
+	mu       sync.RWMutex
+	primary  string
+	previous []retiredSecret
+}
+
+type retiredSecret struct {
+	secret string
+	// until is when the secret stops validating; zero means never
+	until time.Time
+}
+
+// NewKeyring creates a keyring with primary and any previous secrets that
+// remain valid until removed
+func NewKeyring(primary string, previous ...string) *Keyring {
+	k := &Keyring{primary: primary}
+	k.SetPrevious(previous...)
+	return k
+}
+
+// Primary returns the secret new tokens should be signed with
+func (k *Keyring) Primary() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.primary
+}
+
+// Rotate makes secret the primary. The outgoing primary keeps validating
+// for grace, or until removed with SetPrevious when grace is zero, and is
+// returned together with the time it stops (zero for no expiry). Rotating
+// to the current primary changes nothing.
+func (k *Keyring) Rotate(secret string, grace time.Duration) time.Time {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if secret == k.primary {
+		return time.Time{}
+	}
+	var until time.Time
+	if grace > 0 {
+		until = time.Now().Add(grace)
+	}
+	k.previous = slices.DeleteFunc(k.previous, func(r retiredSecret) bool {
+		return r.secret == secret || r.secret == k.primary
+	})
+	k.previous = append(k.previous, retiredSecret{secret: k.primary, until: until})
+	k.primary = secret
+	return until
+}
+
+// SetPrevious replaces the secrets kept valid without expiry. Secrets still
+// in a rotation grace period are unaffected.
+func (k *Keyring) SetPrevious(secrets ...string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.previous = slices.DeleteFunc(k.previous, func(r retiredSecret) bool {
+		return r.until.IsZero()
+	})
+	for _, s := range secrets {
+		if s != "" && s != k.primary {
+			k.previous = append(k.previous, retiredSecret{secret: s})
+		}
+	}
+}
+
+// validSecrets returns the secrets a token may currently be verified with,
+// primary first
+func (k *Keyring) validSecrets() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	now := time.Now()
+	secrets := make([]string, 0, 1+len(k.previous))
+	secrets = append(secrets, k.primary)
+	for _, r := range k.previous {
+		if r.until.IsZero() || now.Before(r.until) {
+			secrets = append(secrets, r.secret)
+		}
+	}
+	return secrets
+}
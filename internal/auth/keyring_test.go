@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func validToken(t *testing.T, secret string) string {
+	t.Helper()
+	return signToken(t, secret, jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()})
+}
+
+func TestKeyringPreviousSecrets(t *testing.T) {
+	keys := NewKeyring("new-secret", "old-secret")
+
+	for _, secret := range []string{"new-secret", "old-secret"} {
+		if _, err := callWithToken(t, keys, validToken(t, secret)); err != nil {
+			t.Fatalf("token signed with %s rejected: %v", secret, err)
+		}
+	}
+	_, err := callWithToken(t, keys, validToken(t, "unknown-secret"))
+	assertUnauthenticated(t, err)
+
+	// Removing the old secret cuts off its tokens
+	keys.SetPrevious()
+	_, err = callWithToken(t, keys, validToken(t, "old-secret"))
+	assertUnauthenticated(t, err)
+	if _, err := callWithToken(t, keys, validToken(t, "new-secret")); err != nil {
+		t.Fatalf("primary token rejected after removal: %v", err)
+	}
+}
+
+func TestKeyringRotateGrace(t *testing.T) {
+	keys := NewKeyring("old-secret")
+	oldToken := validToken(t, "old-secret")
+
+	until := keys.Rotate("new-secret", time.Hour)
+	if until.IsZero() {
+		t.Fatal("rotation with a grace period returned no expiry")
+	}
+	if keys.Primary() != "new-secret" {
+		t.Fatalf("primary = %q, want new-secret", keys.Primary())
+	}
+	if _, err := callWithToken(t, keys, oldToken); err != nil {
+		t.Fatalf("old token rejected during the grace period: %v", err)
+	}
+
+	// A grace period that has run out no longer validates
+	keys.Rotate("newer-secret", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, err := callWithToken(t, keys, validToken(t, "new-secret"))
+	assertUnauthenticated(t, err)
+	if _, err := callWithToken(t, keys, oldToken); err != nil {
+		t.Fatalf("secret still in its grace period rejected: %v", err)
+	}
+}
+
+func TestKeyringSetPreviousKeepsGrace(t *testing.T) {
+	keys := NewKeyring("old-secret")
+	keys.Rotate("new-secret", time.Hour)
+
+	// SetPrevious only replaces secrets kept without expiry
+	keys.SetPrevious()
+	if _, err := callWithToken(t, keys, validToken(t, "old-secret")); err != nil {
+		t.Fatalf("secret in its grace period dropped by SetPrevious: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RoleAdmin grants access to other owners' accounts and admin-only RPCs
+const RoleAdmin = "admin"
+
+// User is the authenticated caller, extracted from the JWT claims
+type User struct {
+	ID    string
+	Roles []string
+}
+
+// HasRole reports whether the user was granted role
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the user holds the admin role
+func (u *User) IsAdmin() bool {
+	return u.HasRole(RoleAdmin)
+}
+
+type userKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user
+func ContextWithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// UserFromContext returns the authenticated user stored by the auth interceptor
+func UserFromContext(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(userKey{}).(*User)
+	return u, ok
+}
+
+// userFromClaims maps the standard "sub" claim to the user ID and accepts
+// roles either as a "roles" array or a single "role" string
+func userFromClaims(claims jwt.MapClaims) *User {
+	u := &User{}
+	if sub, err := claims.GetSubject(); err == nil {
+		u.ID = sub
+	}
+	switch roles := claims["roles"].(type) {
+	case []any:
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				u.Roles = append(u.Roles, s)
+			}
+		}
+	case string:
+		u.Roles = append(u.Roles, roles)
+	}
+	if role, ok := claims["role"].(string); ok {
+		u.Roles = append(u.Roles, role)
+	}
+	return u
+}
@@ -0,0 +1,293 @@
+// Package gateway exposes the ledger's unary RPCs as HTTP+JSON endpoints for
+// clients that don't speak gRPC. Each request is decoded into the RPC's proto
+// message, forwarded over a gRPC client connection to the ledger server (so
+// auth and every other interceptor apply), and the reply is encoded with the
+// proto JSON mapping.
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"apex-ledger/pkg/api"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxBodyBytes caps the size of a JSON request body
+const maxBodyBytes = 4 << 20
+
+// route maps one HTTP endpoint to a unary RPC. Path wildcards and query
+// parameters are copied into the request field of the same name; routes with
+// body set also decode the JSON body into the request first.
+type route struct {
+	pattern  string
+	method   string
+	body     bool
+	request  func() proto.Message
+	response func() proto.Message
+}
+
+// routes lists every endpoint the gateway serves
+var routes = []route{
+	{"POST /v1/transfers", api.LedgerService_Transfer_FullMethodName, true,
+		func() proto.Message { return &api.TransferRequest{} }, func() proto.Message { return &api.TransferResponse{} }},
+	{"POST /v1/transfers/batch", api.LedgerService_BatchTransfer_FullMethodName, true,
+		func() proto.Message { return &api.BatchTransferRequest{} }, func() proto.Message { return &api.BatchTransferResponse{} }},
+	{"POST /v1/transfers/cross-currency", api.LedgerService_CrossCurrencyTransfer_FullMethodName, true,
+		func() proto.Message { return &api.CrossCurrencyTransferRequest{} }, func() proto.Message { return &api.CrossCurrencyTransferResponse{} }},
+	{"POST /v1/transactions/{transaction_id}/reverse", api.LedgerService_ReverseTransfer_FullMethodName, true,
+		func() proto.Message { return &api.ReverseTransferRequest{} }, func() proto.Message { return &api.ReverseTransferResponse{} }},
+	{"POST /v1/transactions/reverse-window", api.LedgerService_ReverseTransfersInWindow_FullMethodName, true,
+		func() proto.Message { return &api.ReverseTransfersInWindowRequest{} }, func() proto.Message { return &api.ReverseTransfersInWindowResponse{} }},
+	{"POST /v1/accounts/{account_id}/deposits", api.LedgerService_Deposit_FullMethodName, true,
+		func() proto.Message { return &api.DepositRequest{} }, func() proto.Message { return &api.DepositResponse{} }},
+	{"GET /v1/transfers/{transaction_id}/status", api.LedgerService_GetTransferStatus_FullMethodName, false,
+		func() proto.Message { return &api.GetTransferStatusRequest{} }, func() proto.Message { return &api.GetTransferStatusResponse{} }},
+	{"GET /v1/transactions/{transaction_id}", api.LedgerService_GetTransaction_FullMethodName, false,
+		func() proto.Message { return &api.GetTransactionRequest{} }, func() proto.Message { return &api.GetTransactionResponse{} }},
+	{"GET /v1/quotes", api.LedgerService_GetConversionQuote_FullMethodName, false,
+		func() proto.Message { return &api.ConversionQuoteRequest{} }, func() proto.Message { return &api.ConversionQuoteResponse{} }},
+
+	{"GET /v1/accounts/{account_id}/balance", api.LedgerService_GetBalance_FullMethodName, false,
+		func() proto.Message { return &api.BalanceRequest{} }, func() proto.Message { return &api.BalanceResponse{} }},
+	{"GET /v1/accounts/{account_id}/balance/as-of", api.LedgerService_GetBalanceAsOf_FullMethodName, false,
+		func() proto.Message { return &api.BalanceAsOfRequest{} }, func() proto.Message { return &api.BalanceAsOfResponse{} }},
+	{"POST /v1/balances/batch-get", api.LedgerService_BatchGetBalance_FullMethodName, true,
+		func() proto.Message { return &api.BatchGetBalanceRequest{} }, func() proto.Message { return &api.BatchGetBalanceResponse{} }},
+
+	{"POST /v1/accounts", api.LedgerService_CreateAccount_FullMethodName, true,
+		func() proto.Message { return &api.CreateAccountRequest{} }, func() proto.Message { return &api.CreateAccountResponse{} }},
+	{"GET /v1/accounts", api.LedgerService_ListAccounts_FullMethodName, false,
+		func() proto.Message { return &api.ListAccountsRequest{} }, func() proto.Message { return &api.ListAccountsResponse{} }},
+	{"GET /v1/accounts/{account_id}", api.LedgerService_GetAccount_FullMethodName, false,
+		func() proto.Message { return &api.GetAccountRequest{} }, func() proto.Message { return &api.GetAccountResponse{} }},
+	{"PATCH /v1/accounts/{account_id}", api.LedgerService_UpdateAccount_FullMethodName, true,
+		func() proto.Message { return &api.UpdateAccountRequest{} }, func() proto.Message { return &api.UpdateAccountResponse{} }},
+	{"DELETE /v1/accounts/{account_id}", api.LedgerService_DeleteAccount_FullMethodName, false,
+		func() proto.Message { return &api.DeleteAccountRequest{} }, func() proto.Message { return &api.DeleteAccountResponse{} }},
+	{"POST /v1/accounts/{account_id}/activate", api.LedgerService_ActivateAccount_FullMethodName, true,
+		func() proto.Message { return &api.ActivateAccountRequest{} }, func() proto.Message { return &api.ActivateAccountResponse{} }},
+	{"PUT /v1/accounts/{account_id}/notification-preference", api.LedgerService_SetNotificationPreference_FullMethodName, true,
+		func() proto.Message { return &api.SetNotificationPreferenceRequest{} }, func() proto.Message { return &api.SetNotificationPreferenceResponse{} }},
+	{"PUT /v1/accounts/{account_id}/sensitive", api.LedgerService_SetAccountSensitive_FullMethodName, true,
+		func() proto.Message { return &api.SetAccountSensitiveRequest{} }, func() proto.Message { return &api.SetAccountSensitiveResponse{} }},
+	{"PUT /v1/accounts/{account_id}/parent", api.LedgerService_SetParentAccount_FullMethodName, true,
+		func() proto.Message { return &api.SetParentAccountRequest{} }, func() proto.Message { return &api.SetParentAccountResponse{} }},
+	{"GET /v1/accounts/{account_id}/aggregate-balance", api.LedgerService_GetAggregateBalance_FullMethodName, false,
+		func() proto.Message { return &api.AggregateBalanceRequest{} }, func() proto.Message { return &api.AggregateBalanceResponse{} }},
+	{"POST /v1/accounts/{account_id}/adjust", api.LedgerService_AdjustBalance_FullMethodName, true,
+		func() proto.Message { return &api.AdjustBalanceRequest{} }, func() proto.Message { return &api.AdjustBalanceResponse{} }},
+	{"GET /v1/accounts/{account_id}/transactions", api.LedgerService_GetTransactionHistory_FullMethodName, false,
+		func() proto.Message { return &api.TransactionHistoryRequest{} }, func() proto.Message { return &api.TransactionHistoryResponse{} }},
+	{"GET /v1/accounts/{account_id}/events", api.LedgerService_ReadEvents_FullMethodName, false,
+		func() proto.Message { return &api.ReadEventsRequest{} }, func() proto.Message { return &api.ReadEventsResponse{} }},
+	{"GET /v1/accounts/{account_id}/statement", api.LedgerService_GetAccountStatement_FullMethodName, false,
+		func() proto.Message { return &api.TransactionHistoryRequest{} }, func() proto.Message { return &api.AccountStatementResponse{} }},
+	{"GET /v1/owners/{owner_id}/accounts", api.LedgerService_GetAccountsByOwner_FullMethodName, false,
+		func() proto.Message { return &api.GetAccountsByOwnerRequest{} }, func() proto.Message { return &api.ListAccountsResponse{} }},
+	{"GET /v1/currencies/{currency}/accounts", api.LedgerService_ListAccountsByCurrency_FullMethodName, false,
+		func() proto.Message { return &api.ListAccountsByCurrencyRequest{} }, func() proto.Message { return &api.ListAccountsByCurrencyResponse{} }},
+
+	{"GET /v1/dead-letters", api.LedgerService_ListDeadLetters_FullMethodName, false,
+		func() proto.Message { return &api.ListDeadLettersRequest{} }, func() proto.Message { return &api.ListDeadLettersResponse{} }},
+	{"POST /v1/dead-letters/retry", api.LedgerService_RetryDeadLetters_FullMethodName, true,
+		func() proto.Message { return &api.RetryDeadLettersRequest{} }, func() proto.Message { return &api.RetryDeadLettersResponse{} }},
+	{"POST /v1/jwt-secret/rotate", api.LedgerService_RotateJWTSecret_FullMethodName, true,
+		func() proto.Message { return &api.RotateJWTSecretRequest{} }, func() proto.Message { return &api.RotateJWTSecretResponse{} }},
+
+	{"GET /v1/audit-log", api.LedgerService_QueryAuditLog_FullMethodName, false,
+		func() proto.Message { return &api.QueryAuditLogRequest{} }, func() proto.Message { return &api.QueryAuditLogResponse{} }},
+
+	{"GET /v1/currencies", api.LedgerService_ListCurrencies_FullMethodName, false,
+		func() proto.Message { return &api.ListCurrenciesRequest{} }, func() proto.Message { return &api.ListCurrenciesResponse{} }},
+	{"GET /v1/server-info", api.LedgerService_GetServerInfo_FullMethodName, false,
+		func() proto.Message { return &api.GetServerInfoRequest{} }, func() proto.Message { return &api.GetServerInfoResponse{} }},
+}
+
+var (
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+	marshaler   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// NewHandler returns an http.Handler serving every gateway route by calling
+// the ledger service over conn
+func NewHandler(conn grpc.ClientConnInterface) http.Handler {
+	mux := http.NewServeMux()
+	for _, rt := range routes {
+		mux.Handle(rt.pattern, &endpoint{conn: conn, route: rt})
+	}
+	return mux
+}
+
+type endpoint struct {
+	conn  grpc.ClientConnInterface
+	route route
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := e.route.request()
+	if err := e.decode(r, req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// The auth interceptor reads the bearer token from gRPC metadata
+	ctx := r.Context()
+	if authz := r.Header.Get("Authorization"); authz != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authz)
+	}
+
+	resp := e.route.response()
+	if err := e.conn.Invoke(ctx, e.route.method, req, resp); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	data, err := marshaler.Marshal(resp)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "failed to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// decode fills req from the JSON body (if the route takes one), then the
+// query string, then the path wildcards, so path values always win
+func (e *endpoint) decode(r *http.Request, req proto.Message) error {
+	if e.route.body {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+		}
+		if len(body) > maxBodyBytes {
+			return status.Errorf(codes.InvalidArgument, "request body exceeds %d bytes", maxBodyBytes)
+		}
+		if len(body) > 0 {
+			if err := unmarshaler.Unmarshal(body, req); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid JSON body: %v", err)
+			}
+		}
+	}
+
+	for name, values := range r.URL.Query() {
+		for _, v := range values {
+			if err := setField(req, name, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	fields := req.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		name := string(fields.Get(i).Name())
+		if v := r.PathValue(name); v != "" {
+			if err := setField(req, name, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setField parses value into the scalar field called name. Repeated fields
+// are appended to, so a query parameter may be given several times.
+func setField(msg proto.Message, name, value string) error {
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil {
+		return status.Errorf(codes.InvalidArgument, "unknown parameter %q", name)
+	}
+
+	var v protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(value)
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalidParam(name, value)
+		}
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return invalidParam(name, value)
+		}
+		v = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return invalidParam(name, value)
+		}
+		v = protoreflect.ValueOfInt64(n)
+	default:
+		return status.Errorf(codes.InvalidArgument, "parameter %q must be sent in the request body", name)
+	}
+
+	if fd.IsList() {
+		m.Mutable(fd).List().Append(v)
+	} else {
+		m.Set(fd, v)
+	}
+	return nil
+}
+
+func invalidParam(name, value string) error {
+	return status.Errorf(codes.InvalidArgument, "invalid value %q for parameter %q", value, name)
+}
+
+// writeError encodes a gRPC error as a google.rpc.Status JSON body with the
+// matching HTTP status code
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	data, mErr := marshaler.Marshal(st.Proto())
+	if mErr != nil {
+		data = []byte(fmt.Sprintf(`{"code":%d,"message":%q}`, st.Code(), st.Message()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatusFromCode(st.Code()))
+	w.Write(data)
+}
+
+// HTTPStatusFromCode maps a gRPC status code to the HTTP status the gateway
+// responds with, following the google.rpc.Code mapping
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499 // Client Closed Request
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
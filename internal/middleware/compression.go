@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// gzipName is the grpc-encoding name of the gzip compressor
+const gzipName = "gzip"
+
+// EnableGzip registers a gzip compressor with gRPC, so the server accepts
+// gzip-compressed requests and advertises gzip in grpc-accept-encoding.
+// Clients that don't ask for compression are unaffected. It must be called
+// before the server starts.
+func EnableGzip() {
+	encoding.RegisterCompressor(&gzipCompressor{})
+}
+
+// CompressionInterceptor compresses responses with gzip for clients that
+// advertise support for it, even when their request was sent uncompressed.
+// It requires EnableGzip.
+func CompressionInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		preferGzip(ctx)
+		return handler(ctx, req)
+	}
+}
+
+// CompressionStreamInterceptor is CompressionInterceptor for streams, where
+// large exports benefit most
+func CompressionStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		preferGzip(ss.Context())
+		return handler(srv, ss)
+	}
+}
+
+func preferGzip(ctx context.Context) {
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err == nil && slices.Contains(supported, gzipName) {
+		// Only fails if headers were already sent, in which case the call
+		// simply stays uncompressed
+		_ = grpc.SetSendCompressor(ctx, gzipName)
+	}
+}
+
+// gzipCompressor implements encoding.Compressor, pooling writers and readers
+// since each message gets its own
+type gzipCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+func (c *gzipCompressor) Name() string {
+	return gzipName
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if z, ok := c.writers.Get().(*gzip.Writer); ok {
+		z.Reset(w)
+		return &pooledWriter{Writer: z, pool: &c.writers}, nil
+	}
+	return &pooledWriter{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	z, ok := c.readers.Get().(*gzip.Reader)
+	if !ok {
+		nz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return &pooledReader{Reader: nz, pool: &c.readers}, nil
+	}
+	if err := z.Reset(r); err != nil {
+		c.readers.Put(z)
+		return nil, err
+	}
+	return &pooledReader{Reader: z, pool: &c.readers}, nil
+}
+
+// pooledWriter returns its gzip.Writer to the pool once closed
+type pooledWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *pooledWriter) Close() error {
+	defer w.pool.Put(w.Writer)
+	return w.Writer.Close()
+}
+
+// pooledReader returns its gzip.Reader to the pool at end of stream
+type pooledReader struct {
+	*gzip.Reader
+	pool *sync.Pool
+}
+
+func (r *pooledReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Reader)
+	}
+	return n, err
+}
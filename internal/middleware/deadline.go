@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Deadlines bounds how long the server works on a request.
+//
+// MethodTimeouts, keyed by the short method name (e.g. "ListAccounts"), cap
+// every call to that method: a request arriving without a deadline gets the
+// method's timeout, and one whose client deadline is further away has it
+// shortened. A client deadline that is already sooner always wins.
+//
+// Methods without an entry fall back to Default, which only applies to
+// requests that arrive without a deadline; a client deadline is left alone.
+// A zero timeout disables the corresponding bound.
+type Deadlines struct {
+	Default        time.Duration
+	MethodTimeouts map[string]time.Duration
+}
+
+// forMethod resolves the timeout for a full gRPC method name and whether it
+// caps deadlines the client set
+func (d Deadlines) forMethod(fullMethod string) (timeout time.Duration, capsClient bool) {
+	if t, ok := d.MethodTimeouts[path.Base(fullMethod)]; ok && t > 0 {
+		return t, true
+	}
+	return d.Default, false
+}
+
+// apply derives the context a call to fullMethod runs under. The returned
+// cancel func must be called once the call is done.
+func (d Deadlines) apply(ctx context.Context, fullMethod string) (context.Context, context.CancelFunc) {
+	timeout, capsClient := d.forMethod(fullMethod)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok && !capsClient {
+		return ctx, func() {}
+	}
+	// WithTimeout keeps the parent's deadline when it is sooner
+	return context.WithTimeout(ctx, timeout)
+}
+
+// DeadlineInterceptor applies deadlines to unary requests, so a client that
+// never gives up can't keep server work (and any row locks it holds) alive
+// indefinitely
+func DeadlineInterceptor(deadlines Deadlines) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			if timeout, _ := deadlines.forMethod(info.FullMethod); timeout > 0 {
+				log.Printf("No client deadline on %s, applying default of %s", info.FullMethod, timeout)
+			}
+		}
+		ctx, cancel := deadlines.apply(ctx, info.FullMethod)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// DeadlineStreamInterceptor applies per-method timeouts to streaming calls.
+// Default is not applied to streams, which may legitimately run long.
+func DeadlineStreamInterceptor(deadlines Deadlines) grpc.StreamServerInterceptor {
+	deadlines.Default = 0
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := deadlines.apply(ss.Context(), info.FullMethod)
+		defer cancel()
+		return handler(srv, &deadlineStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type deadlineStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *deadlineStream) Context() context.Context {
+	return s.ctx
+}
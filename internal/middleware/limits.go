@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"path"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RequestLimits bounds the size of incoming request messages.
+// Per-method maps are keyed by the short method name (e.g. "ListAccounts")
+// and override the defaults; a zero limit means unlimited.
+type RequestLimits struct {
+	MaxBytes          int
+	MaxElements       int
+	MethodMaxBytes    map[string]int
+	MethodMaxElements map[string]int
+}
+
+// forMethod resolves the effective limits for a full gRPC method name
+func (l RequestLimits) forMethod(fullMethod string) (maxBytes, maxElements int) {
+	name := path.Base(fullMethod)
+	maxBytes, maxElements = l.MaxBytes, l.MaxElements
+	if v, ok := l.MethodMaxBytes[name]; ok {
+		maxBytes = v
+	}
+	if v, ok := l.MethodMaxElements[name]; ok {
+		maxElements = v
+	}
+	return maxBytes, maxElements
+}
+
+// RequestLimiter enforces RequestLimits that can be replaced at runtime
+type RequestLimiter struct {
+	limits atomic.Pointer[RequestLimits]
+}
+
+// NewRequestLimiter creates a limiter enforcing limits
+func NewRequestLimiter(limits RequestLimits) *RequestLimiter {
+	l := &RequestLimiter{}
+	l.Set(limits)
+	return l
+}
+
+// Set swaps in new limits; requests already past the check are unaffected
+func (l *RequestLimiter) Set(limits RequestLimits) {
+	l.limits.Store(&limits)
+}
+
+// UnaryInterceptor rejects oversized requests with InvalidArgument
+// before they reach the handler. This complements the transport-level
+// MaxRecvMsgSize with per-method budgets and caps on repeated fields.
+func (l *RequestLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkLimits(req, *l.limits.Load(), info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor applies the same limits to every message a client sends
+// on a stream
+func (l *RequestLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedStream{ServerStream: ss, limiter: l, method: info.FullMethod})
+	}
+}
+
+// RequestLimitInterceptor is a unary interceptor enforcing fixed limits
+func RequestLimitInterceptor(limits RequestLimits) grpc.UnaryServerInterceptor {
+	return NewRequestLimiter(limits).UnaryInterceptor()
+}
+
+// RequestLimitStreamInterceptor is a stream interceptor enforcing fixed limits
+func RequestLimitStreamInterceptor(limits RequestLimits) grpc.StreamServerInterceptor {
+	return NewRequestLimiter(limits).StreamInterceptor()
+}
+
+type limitedStream struct {
+	grpc.ServerStream
+	limiter *RequestLimiter
+	method  string
+}
+
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkLimits(m, *s.limiter.limits.Load(), s.method)
+}
+
+func checkLimits(req any, limits RequestLimits, fullMethod string) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	maxBytes, maxElements := limits.forMethod(fullMethod)
+
+	if maxBytes > 0 {
+		if size := proto.Size(msg); size > maxBytes {
+			return status.Errorf(codes.InvalidArgument, "request is %d bytes, %s allows at most %d", size, path.Base(fullMethod), maxBytes)
+		}
+	}
+
+	if maxElements > 0 {
+		var err error
+		msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if fd.IsList() && v.List().Len() > maxElements {
+				err = status.Errorf(codes.InvalidArgument, "%s has %d elements, %s allows at most %d", fd.Name(), v.List().Len(), path.Base(fullMethod), maxElements)
+				return false
+			}
+			return true
+		})
+		return err
+	}
+	return nil
+}
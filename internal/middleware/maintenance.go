@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"apex-ledger/internal/platform/grpcerr"
+
+	"google.golang.org/grpc"
+)
+
+// maintenanceRetryDelay is the retry hint sent with writes refused during maintenance
+const maintenanceRetryDelay = 30 * time.Second
+
+// writeMethods are the RPCs that change ledger state and are refused while
+// maintenance mode is on. Everything else is treated as a read.
+var writeMethods = map[string]bool{
+	"Transfer":                  true,
+	"BatchTransfer":             true,
+	"CrossCurrencyTransfer":     true,
+	"CreateAccount":             true,
+	"UpdateAccount":             true,
+	"DeleteAccount":             true,
+	"ActivateAccount":           true,
+	"SetNotificationPreference": true,
+	"SetAccountSensitive":       true,
+	"AdjustBalance":             true,
+	"BulkAdjustBalance":         true,
+	"ReverseTransfer":           true,
+	"ReverseTransfersInWindow":  true,
+	"Deposit":                   true,
+	"SetParentAccount":          true,
+	"RetryDeadLetters":          true,
+	"ImportAccounts":            true,
+}
+
+// IsWriteMethod reports whether a full gRPC method name is a write
+func IsWriteMethod(fullMethod string) bool {
+	return writeMethods[path.Base(fullMethod)]
+}
+
+// Maintenance is a switch that, when on, makes write RPCs fail with
+// Unavailable while reads keep working. It is safe to flip at runtime.
+type Maintenance struct {
+	enabled atomic.Bool
+}
+
+// NewMaintenance creates a maintenance switch in the given state
+func NewMaintenance(enabled bool) *Maintenance {
+	m := &Maintenance{}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// SetEnabled turns maintenance mode on or off
+func (m *Maintenance) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Enabled reports whether maintenance mode is on
+func (m *Maintenance) Enabled() bool {
+	return m.enabled.Load()
+}
+
+func (m *Maintenance) check(fullMethod string) error {
+	if m.Enabled() && IsWriteMethod(fullMethod) {
+		return grpcerr.Unavailable(path.Base(fullMethod)+" is unavailable during maintenance", maintenanceRetryDelay)
+	}
+	return nil
+}
+
+// UnaryInterceptor rejects write RPCs while maintenance mode is on
+func (m *Maintenance) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := m.check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects write streams while maintenance mode is on
+func (m *Maintenance) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := m.check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
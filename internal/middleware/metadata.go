@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataSize is the total length of every key and value in md
+func metadataSize(md metadata.MD) int {
+	size := 0
+	for key, values := range md {
+		for _, v := range values {
+			size += len(key) + len(v)
+		}
+	}
+	return size
+}
+
+func checkMetadata(ctx context.Context, maxBytes int) error {
+	if maxBytes <= 0 {
+		return nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	if size := metadataSize(md); size > maxBytes {
+		return status.Errorf(codes.InvalidArgument, "request metadata is %d bytes, at most %d allowed", size, maxBytes)
+	}
+	return nil
+}
+
+// MetadataLimitInterceptor rejects requests whose incoming metadata (keys
+// plus values) exceeds maxBytes with InvalidArgument. Chain it ahead of
+// authentication so an oversized header is refused before the token is
+// parsed. A zero limit disables the check.
+func MetadataLimitInterceptor(maxBytes int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkMetadata(ctx, maxBytes); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// MetadataLimitStreamInterceptor applies the same limit to streams
+func MetadataLimitStreamInterceptor(maxBytes int) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkMetadata(ss.Context(), maxBytes); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"apex-ledger/internal/platform/metrics"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrCircuitOpen is returned without touching the database while the
+// circuit breaker is open
+var ErrCircuitOpen = errors.New("database circuit breaker is open")
+
+var (
+	breakerOpened   = metrics.NewCounter("db_breaker_opened")
+	breakerRejected = metrics.NewCounter("db_breaker_rejected")
+)
+
+// Breaker states, as published in the db_breaker_state metric
+const (
+	stateClosed   = "closed"
+	stateOpen     = "open"
+	stateHalfOpen = "half_open"
+)
+
+// Breaker is a circuit breaker for database calls. After threshold
+// consecutive connection failures or timeouts it opens, and every call fails
+// fast with ErrCircuitOpen for cooldown instead of queueing for a connection.
+// It then half-opens and lets a single trial call through: success closes
+// it, failure opens it for another cooldown.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+}
+
+// NewBreaker creates a closed breaker. Only one breaker may be created per
+// process, since it publishes the db_breaker_state metric.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	b := &Breaker{threshold: threshold, cooldown: cooldown, state: stateClosed}
+	metrics.NewGauge("db_breaker_state", func() any { return b.State() })
+	return b
+}
+
+// State reports "closed", "open" or "half_open"
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == stateOpen && time.Since(b.openedAt) >= b.cooldown {
+		return stateHalfOpen
+	}
+	return b.state
+}
+
+// allow reports whether a call may proceed, claiming the trial slot when
+// half-open
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == stateOpen && time.Since(b.openedAt) >= b.cooldown {
+		b.state = stateHalfOpen
+	}
+	switch b.state {
+	case stateOpen:
+		breakerRejected.Add(1)
+		return ErrCircuitOpen
+	case stateHalfOpen:
+		if b.trial {
+			breakerRejected.Add(1)
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of an allowed call
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	halfOpen := b.state == stateHalfOpen
+	b.trial = false
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		// The caller gave up; says nothing about the database
+	case isFailure(err):
+		b.failures++
+		if halfOpen || b.failures >= b.threshold {
+			b.state = stateOpen
+			b.openedAt = time.Now()
+			b.failures = 0
+			breakerOpened.Add(1)
+		}
+	default:
+		// Any answer from the server, including a constraint violation,
+		// shows the database is reachable
+		b.state = stateClosed
+		b.failures = 0
+	}
+}
+
+// isFailure reports whether err counts towards opening the breaker
+func isFailure(err error) bool {
+	return err != nil && (IsConnectionFailure(err) || errors.Is(err, context.DeadlineExceeded))
+}
+
+// IsConnectionFailure reports whether err is a database failure that is
+// likely to succeed if retried later: lost or refused connections, a server
+// that is starting up or shutting down, or connection-slot exhaustion
+func IsConnectionFailure(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || pgconn.SafeToRetry(err) {
+		return true
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08: connection exception; 57P0x: admin/crash shutdown, cannot
+		// connect now; 53300: too many connections
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "57P0") || pgErr.Code == "53300"
+	}
+	return false
+}
+
+// breakerConnector opens connections guarded by a breaker
+type breakerConnector struct {
+	driver.Connector
+	breaker *Breaker
+}
+
+func (c *breakerConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	conn, err := c.Connector.Connect(ctx)
+	c.breaker.record(err)
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := conn.(pgxConn)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("database driver connection does not support context methods")
+	}
+	return &breakerConn{pgxConn: inner, breaker: c.breaker}, nil
+}
+
+// pgxConn is the set of driver interfaces the pgx stdlib connection
+// implements and database/sql looks for
+type pgxConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.NamedValueChecker
+	driver.SessionResetter
+}
+
+// breakerConn passes every statement through the breaker. Commit and
+// rollback are not guarded, so a transaction that is already open can
+// always finish.
+type breakerConn struct {
+	pgxConn
+	breaker *Breaker
+}
+
+func (c *breakerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	tx, err := c.pgxConn.BeginTx(ctx, opts)
+	c.breaker.record(err)
+	return tx, err
+}
+
+func (c *breakerConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	stmt, err := c.pgxConn.PrepareContext(ctx, query)
+	c.breaker.record(err)
+	return stmt, err
+}
+
+func (c *breakerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	result, err := c.pgxConn.ExecContext(ctx, query, args)
+	c.breaker.record(err)
+	return result, err
+}
+
+func (c *breakerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+	rows, err := c.pgxConn.QueryContext(ctx, query, args)
+	c.breaker.record(err)
+	return rows, err
+}
+
+func (c *breakerConn) Ping(ctx context.Context) error {
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+	err := c.pgxConn.Ping(ctx)
+	c.breaker.record(err)
+	return err
+}
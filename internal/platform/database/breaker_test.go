@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestBreaker builds a closed breaker without publishing its state
+// metric, which may only be registered once per process
+func newTestBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown, state: stateClosed}
+}
+
+// call runs one guarded call that fails with err
+func call(b *Breaker, err error) error {
+	if allowErr := b.allow(); allowErr != nil {
+		return allowErr
+	}
+	b.record(err)
+	return err
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b := newTestBreaker(3, time.Hour)
+	connErr := fmt.Errorf("dial: %w", driver.ErrBadConn)
+
+	call(b, connErr)
+	call(b, connErr)
+	// Any answer from the server resets the count
+	call(b, errors.New("duplicate key"))
+	call(b, connErr)
+	call(b, connErr)
+	// A caller giving up says nothing about the database
+	call(b, context.Canceled)
+	if got := b.State(); got != stateClosed {
+		t.Fatalf("state = %s after two consecutive failures, want closed", got)
+	}
+
+	call(b, context.DeadlineExceeded)
+	if got := b.State(); got != stateOpen {
+		t.Fatalf("state = %s after three consecutive failures, want open", got)
+	}
+	if err := call(b, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("call while open returned %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestBreakerHalfOpenTrial(t *testing.T) {
+	tests := []struct {
+		name  string
+		trial error
+		want  string
+	}{
+		{name: "success closes", trial: nil, want: stateClosed},
+		{name: "server error closes", trial: errors.New("syntax error"), want: stateClosed},
+		{name: "failure reopens", trial: driver.ErrBadConn, want: stateOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTestBreaker(1, 20*time.Millisecond)
+			call(b, driver.ErrBadConn)
+			if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("allow while open returned %v, want ErrCircuitOpen", err)
+			}
+
+			time.Sleep(40 * time.Millisecond)
+			if got := b.State(); got != stateHalfOpen {
+				t.Fatalf("state = %s after the cooldown, want half_open", got)
+			}
+			if err := b.allow(); err != nil {
+				t.Fatalf("trial call refused: %v", err)
+			}
+			// Only one trial at a time
+			if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("second call during the trial returned %v, want ErrCircuitOpen", err)
+			}
+
+			b.record(tt.trial)
+			if got := b.State(); got != tt.want {
+				t.Fatalf("state = %s after the trial, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerSingleTrialUnderContention(t *testing.T) {
+	b := newTestBreaker(1, time.Millisecond)
+	call(b, driver.ErrBadConn)
+	time.Sleep(5 * time.Millisecond)
+
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if b.allow() == nil {
+				allowed.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if allowed.Load() != 1 {
+		t.Fatalf("%d calls let through while half-open, want 1", allowed.Load())
+	}
+}
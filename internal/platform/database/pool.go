@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"apex-ledger/internal/platform/metrics"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrPoolExhausted is returned when no pooled connection became free within
+// the acquire timeout
+var ErrPoolExhausted = errors.New("database connection pool exhausted")
+
+var poolExhausted = metrics.NewCounter("db_pool_exhausted")
+
+// PublishPoolStats publishes db's connection pool statistics as metrics:
+// connections open, in use and idle, and how many acquisitions had to wait
+// and for how long in total. It may be called once per process.
+func PublishPoolStats(db *sqlx.DB) {
+	metrics.NewGauge("db_pool_open", func() any { return db.Stats().OpenConnections })
+	metrics.NewGauge("db_pool_in_use", func() any { return db.Stats().InUse })
+	metrics.NewGauge("db_pool_idle", func() any { return db.Stats().Idle })
+	metrics.NewGauge("db_pool_max_open", func() any { return db.Stats().MaxOpenConnections })
+	metrics.NewGauge("db_pool_wait_count", func() any { return db.Stats().WaitCount })
+	metrics.NewGauge("db_pool_wait_ms", func() any { return db.Stats().WaitDuration.Milliseconds() })
+}
+
+// BeginTx starts a transaction like db.BeginTxx, but fails with
+// ErrPoolExhausted if no connection can be acquired within acquireTimeout
+// instead of waiting until ctx is done. The transaction itself still runs
+// under ctx. A zero acquireTimeout waits as long as ctx allows.
+func BeginTx(ctx context.Context, db *sqlx.DB, opts *sql.TxOptions, acquireTimeout time.Duration) (*sqlx.Tx, error) {
+	if acquireTimeout <= 0 {
+		return db.BeginTxx(ctx, opts)
+	}
+
+	acquireCtx, cancel := context.WithTimeout(ctx, acquireTimeout)
+	conn, err := db.Connx(acquireCtx)
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			poolExhausted.Add(1)
+			return nil, ErrPoolExhausted
+		}
+		return nil, err
+	}
+
+	tx, err := conn.BeginTxx(ctx, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// Conn.Close blocks until the transaction ends, then returns the
+	// connection to the pool
+	go conn.Close()
+	return tx, nil
+}
@@ -1,52 +1,121 @@
-package database
-
-import (
-	"context"
-	"time"
-
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
-)
-
-// NewPostgres creates a connection pool with production settings using pgx/v5
-func NewPostgres(uri string) (*sqlx.DB, error) {
-	// Parse the connection string
-	config, err := pgx.ParseConfig(uri)
-	if err != nil {
-		return nil, err
-	}
-
-	// Use pgx/v5 stdlib driver with sqlx
-	db := stdlib.OpenDB(*config)
-
-	// Wrap with sqlx
-	sqlxDB := sqlx.NewDb(db, "pgx")
-
-	// Production settings: prevent connection exhaustion
-	sqlxDB.SetMaxOpenConns(25)
-	sqlxDB.SetMaxIdleConns(25)
-	sqlxDB.SetConnMaxLifetime(5 * time.Minute)
-
-	// Test the connection
-	if err := sqlxDB.Ping(); err != nil {
-		return nil, err
-	}
-
-	return sqlxDB, nil
-}
-
-// ExecTx provides a helper to wrap logic in a transaction
-func ExecTx(ctx context.Context, db *sqlx.DB, fn func(*sqlx.Tx) error) error {
-	tx, err := db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	err = fn(tx)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-	return tx.Commit()
-}
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+// Option configures optional connection pool behaviour
+type Option func(*options)
+
+type options struct {
+	breaker        *Breaker
+	statementCache *int
+	queryLog       bool
+	stmtTimeout    time.Duration
+}
+
+// WithStatementCache sets how many prepared statements each connection
+// keeps. pgx prepares every query on first use and reuses the statement for
+// identical SQL on the same connection, so the repository's constant query
+// strings (GetAccountWithLock, Debit/Credit, the transaction insert and the
+// rest) are parsed and planned by Postgres once per connection rather than
+// on every call. Zero disables server-side prepared statements, which is
+// required behind a transaction-pooling proxy such as PgBouncer.
+func WithStatementCache(capacity int) Option {
+	return func(o *options) {
+		o.statementCache = &capacity
+	}
+}
+
+// WithStatementTimeout sets Postgres' statement_timeout on every
+// connection, so the server itself cancels any statement running longer
+// than d, even one whose client never gives up on it. It is sent as a
+// startup parameter; PgBouncer rejects those it doesn't know, so behind
+// it set statement_timeout on the database role instead.
+func WithStatementTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.stmtTimeout = d
+	}
+}
+
+// WithBreaker guards every connection and statement with breaker
+func WithBreaker(breaker *Breaker) Option {
+	return func(o *options) {
+		o.breaker = breaker
+	}
+}
+
+// NewPostgres creates a connection pool with production settings using pgx/v5
+func NewPostgres(uri string, opts ...Option) (*sqlx.DB, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Parse the connection string
+	config, err := pgx.ParseConfig(uri)
+	if err != nil {
+		return nil, err
+	}
+	if o.statementCache != nil {
+		if *o.statementCache > 0 {
+			config.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+			config.StatementCacheCapacity = *o.statementCache
+		} else {
+			config.DefaultQueryExecMode = pgx.QueryExecModeExec
+			config.StatementCacheCapacity = 0
+		}
+	}
+
+	if o.queryLog {
+		config.Tracer = queryLogger{}
+	}
+	if o.stmtTimeout > 0 {
+		config.RuntimeParams["statement_timeout"] = strconv.FormatInt(o.stmtTimeout.Milliseconds(), 10)
+	}
+
+	// Use pgx/v5 stdlib driver with sqlx
+	connector := stdlib.GetConnector(*config)
+	if o.breaker != nil {
+		connector = &breakerConnector{Connector: connector, breaker: o.breaker}
+	}
+	db := sql.OpenDB(connector)
+
+	// Wrap with sqlx
+	sqlxDB := sqlx.NewDb(db, "pgx")
+
+	// Production settings: prevent connection exhaustion. Idle connections
+	// are kept up to the pool size so their statement caches stay warm.
+	sqlxDB.SetMaxOpenConns(25)
+	sqlxDB.SetMaxIdleConns(25)
+	sqlxDB.SetConnMaxLifetime(5 * time.Minute)
+
+	// Test the connection
+	if err := sqlxDB.Ping(); err != nil {
+		return nil, err
+	}
+
+	return sqlxDB, nil
+}
+
+// ExecTx provides a helper to wrap logic in a transaction
+func ExecTx(ctx context.Context, db *sqlx.DB, fn func(*sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
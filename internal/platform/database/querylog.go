@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// maskedPrefix is how many leading characters of a string parameter a
+// logged query shows; the rest is redacted
+const maskedPrefix = 4
+
+// WithQueryLog logs every statement with its duration and masked
+// parameters, for debugging. It installs a pgx query tracer, so without
+// this option queries pay nothing for the feature.
+func WithQueryLog() Option {
+	return func(o *options) {
+		o.queryLog = true
+	}
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+// queryLogger is a pgx.QueryTracer that logs each statement once it ends
+type queryLogger struct{}
+
+func (queryLogger) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, &queryStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+func (queryLogger) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(*queryStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.at).Round(time.Microsecond)
+	sql := strings.Join(strings.Fields(start.sql), " ")
+	if data.Err != nil {
+		log.Printf("debug: query failed after %s: %s args=%s: %v", elapsed, sql, maskArgs(start.args), data.Err)
+		return
+	}
+	log.Printf("debug: query took %s (%s): %s args=%s", elapsed, data.CommandTag, sql, maskArgs(start.args))
+}
+
+// maskArgs renders query parameters for the log. Numbers, booleans and
+// times are shown as is; strings keep only their first few characters,
+// enough to tell account IDs apart, and byte values only their length, so
+// owner IDs, reasons, references and metadata don't end up in logs.
+func maskArgs(args []any) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("$%d=%s", i+1, maskArg(arg))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+func maskArg(arg any) string {
+	switch v := arg.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return maskString(v)
+	case *string:
+		if v == nil {
+			return "NULL"
+		}
+		return maskString(*v)
+	case []byte:
+		return fmt.Sprintf("<%d bytes>", len(v))
+	case []string:
+		masked := make([]string, len(v))
+		for i, s := range v {
+			masked[i] = maskString(s)
+		}
+		return "{" + strings.Join(masked, ",") + "}"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool:
+		return fmt.Sprint(v)
+	case *int64:
+		if v == nil {
+			return "NULL"
+		}
+		return fmt.Sprint(*v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	}
+	return fmt.Sprintf("<%T>", arg)
+}
+
+func maskString(s string) string {
+	r := []rune(s)
+	if len(r) <= maskedPrefix {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%q", string(r[:maskedPrefix])+"***")
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx carrying tx, so code further down the
+// call chain can run its queries inside it
+func ContextWithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok && tx != nil
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none
+func Conn(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
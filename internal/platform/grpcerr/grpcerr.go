@@ -0,0 +1,24 @@
+// Package grpcerr builds gRPC statuses that carry standard error details
+package grpcerr
+
+import (
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Unavailable returns an UNAVAILABLE status with a google.rpc.RetryInfo
+// detail suggesting how long clients should wait before retrying
+func Unavailable(msg string, retryAfter time.Duration) error {
+	st := status.New(codes.Unavailable, msg)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
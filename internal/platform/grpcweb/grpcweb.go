@@ -0,0 +1,167 @@
+// Package grpcweb lets browser clients reach a gRPC server over HTTP/1.1
+// using the gRPC-Web protocol. Requests are translated to native gRPC and
+// served by the grpc.Server itself, so every interceptor (auth included)
+// applies exactly as it does on the native port.
+//
+// Both the binary (application/grpc-web+proto) and text
+// (application/grpc-web-text) encodings are supported. Client streaming is
+// not part of gRPC-Web and is therefore unavailable.
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	contentTypeWeb     = "application/grpc-web"
+	contentTypeWebText = "application/grpc-web-text"
+
+	// trailerFlag marks the frame carrying the trailers at the end of a
+	// gRPC-Web response body
+	trailerFlag byte = 0x80
+)
+
+// exposedHeaders are the response headers a browser script must be able to
+// read to decode a gRPC-Web response
+var exposedHeaders = "grpc-status, grpc-message, grpc-status-details-bin"
+
+// allowedHeaders are the request headers gRPC-Web clients send
+var allowedHeaders = "authorization, content-type, grpc-timeout, x-grpc-web, x-user-agent"
+
+// Handler serves gRPC-Web requests by translating them for a grpc.Server
+type Handler struct {
+	server  *grpc.Server
+	origins []string
+}
+
+// NewHandler wraps server. origins lists the browser origins allowed to call
+// it cross-origin; "*" allows any origin and an empty list allows none.
+func NewHandler(server *grpc.Server, origins []string) *Handler {
+	return &Handler{server: server, origins: origins}
+}
+
+// ServeHTTP handles CORS preflights and gRPC-Web calls
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && h.originAllowed(origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+	}
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "POST")
+		w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+		w.Header().Set("Access-Control-Max-Age", "600")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if r.Method != http.MethodPost || !strings.HasPrefix(contentType, contentTypeWeb) {
+		http.Error(w, "expected a gRPC-Web POST request", http.StatusUnsupportedMediaType)
+		return
+	}
+	text := strings.HasPrefix(contentType, contentTypeWebText)
+
+	// Present the call to grpc.Server as native gRPC over HTTP/2
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2"
+	req.Header.Set("Content-Type", "application/grpc+proto")
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	if text {
+		req.Body = io.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	}
+
+	rw := &responseWriter{w: w, header: make(http.Header), text: text, contentType: contentType}
+	h.server.ServeHTTP(rw, req)
+	rw.finish()
+}
+
+func (h *Handler) originAllowed(origin string) bool {
+	return slices.Contains(h.origins, "*") || slices.Contains(h.origins, origin)
+}
+
+// responseWriter turns grpc.Server's HTTP/2 response into a gRPC-Web one:
+// headers pass through, and trailers set after the body become a trailer
+// frame appended to the body
+type responseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	sent        http.Header
+	text        bool
+	contentType string
+	pending     bytes.Buffer // text mode: bytes awaiting base64 encoding
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.sent != nil {
+		return
+	}
+	rw.sent = rw.header.Clone()
+	out := rw.w.Header()
+	for k, vv := range rw.header {
+		if k == "Trailer" || k == "Content-Type" || strings.HasPrefix(k, http.TrailerPrefix) {
+			continue
+		}
+		out[k] = vv
+	}
+	out.Set("Content-Type", rw.contentType)
+	rw.w.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	if rw.text {
+		return rw.pending.Write(p)
+	}
+	return rw.w.Write(p)
+}
+
+func (rw *responseWriter) Flush() {
+	rw.WriteHeader(http.StatusOK)
+	if rw.text && rw.pending.Len() > 0 {
+		// Each flush is encoded as its own padded base64 chunk, which
+		// gRPC-Web text clients decode incrementally
+		io.WriteString(rw.w, base64.StdEncoding.EncodeToString(rw.pending.Bytes()))
+		rw.pending.Reset()
+	}
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes the trailer frame once the call has completed
+func (rw *responseWriter) finish() {
+	rw.WriteHeader(http.StatusOK)
+
+	var trailers bytes.Buffer
+	for k, vv := range rw.header {
+		name := strings.TrimPrefix(k, http.TrailerPrefix)
+		if k == "Trailer" || (name == k && slices.Equal(rw.sent[k], vv)) {
+			continue
+		}
+		for _, v := range vv {
+			trailers.WriteString(strings.ToLower(name) + ": " + v + "\r\n")
+		}
+	}
+
+	frame := make([]byte, 5, 5+trailers.Len())
+	frame[0] = trailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(trailers.Len()))
+	frame = append(frame, trailers.Bytes()...)
+	rw.Write(frame)
+	rw.Flush()
+}
@@ -0,0 +1,151 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Service names reported through the gRPC health service. The empty name,
+// the server as a whole, follows readiness.
+const (
+	LivenessService  = "liveness"
+	ReadinessService = "readiness"
+)
+
+// checkTimeout bounds a single readiness check
+const checkTimeout = 2 * time.Second
+
+// Check returns nil when a dependency is fit to serve traffic
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Probes tracks liveness and readiness. Liveness is SERVING for as long as
+// the process can answer at all; readiness is SERVING only while every
+// readiness check passes and the server is not draining for shutdown.
+type Probes struct {
+	server *grpchealth.Server
+	checks []namedCheck
+
+	mu       sync.Mutex
+	draining bool
+	notReady error // why the last evaluation was not ready, nil if ready
+}
+
+// NewProbes creates probes that start live but not ready, until the first
+// evaluation passes
+func NewProbes() *Probes {
+	p := &Probes{server: grpchealth.NewServer(), notReady: errors.New("not yet checked")}
+	p.server.SetServingStatus(LivenessService, healthpb.HealthCheckResponse_SERVING)
+	p.server.SetServingStatus(ReadinessService, healthpb.HealthCheckResponse_NOT_SERVING)
+	p.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return p
+}
+
+// AddReadinessCheck adds a check that must pass for the server to be ready.
+// Checks must be added before Run.
+func (p *Probes) AddReadinessCheck(name string, check Check) {
+	p.checks = append(p.checks, namedCheck{name: name, check: check})
+}
+
+// Register exposes the probes as the standard grpc.health.v1.Health service
+func (p *Probes) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, p.server)
+}
+
+// Run evaluates readiness immediately and then every interval until ctx is
+// cancelled
+func (p *Probes) Run(ctx context.Context, interval time.Duration) {
+	p.evaluate(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.evaluate(ctx)
+		}
+	}
+}
+
+// Drain marks the server not ready for good, so load balancers stop sending
+// new traffic while in-flight requests finish. Liveness is unaffected.
+func (p *Probes) Drain() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.draining = true
+	p.setLocked(errors.New("shutting down"))
+}
+
+func (p *Probes) evaluate(ctx context.Context) {
+	var notReady error
+	for _, c := range p.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := c.check(checkCtx)
+		cancel()
+		if err != nil {
+			notReady = fmt.Errorf("%s: %w", c.name, err)
+			break
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// A check still running when Drain was called must not flip back to ready
+	if p.draining {
+		return
+	}
+	p.setLocked(notReady)
+}
+
+func (p *Probes) setLocked(notReady error) {
+	p.notReady = notReady
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if notReady != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	p.server.SetServingStatus(ReadinessService, status)
+	p.server.SetServingStatus("", status)
+}
+
+// Ready returns nil when the server is ready, or the reason it is not
+func (p *Probes) Ready() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.notReady
+}
+
+// LivenessHandler answers 200 for as long as the process is up, for HTTP
+// liveness probes
+func (p *Probes) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// ReadinessHandler answers 200 when ready and 503 with the reason otherwise,
+// for HTTP readiness probes
+func (p *Probes) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Ready(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+}
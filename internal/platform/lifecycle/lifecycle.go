@@ -0,0 +1,116 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RunFunc is a background component's main loop. It must return soon after
+// ctx is cancelled.
+type RunFunc func(ctx context.Context)
+
+type component struct {
+	name string
+	run  RunFunc
+	done chan struct{}
+}
+
+// Manager starts background components (schedulers, reconciliation,
+// snapshots, dispatchers) under one shared context and stops them together,
+// so none is left running, or cut off mid-batch without notice, when the
+// server shuts down
+type Manager struct {
+	mu         sync.Mutex
+	components []*component
+	ctx        context.Context
+	cancel     context.CancelFunc
+	stopped    bool
+}
+
+// NewManager creates a manager with no components
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Add registers a component under name. Components added after Start are
+// started immediately; those added after Stop never run.
+func (m *Manager) Add(name string, run RunFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		log.Printf("Background component %s not started: shutting down", name)
+		return
+	}
+	c := &component{name: name, run: run, done: make(chan struct{})}
+	m.components = append(m.components, c)
+	if m.cancel != nil {
+		m.start(c)
+	}
+}
+
+// Start runs every registered component on its own goroutine under a
+// context derived from ctx. Calling it again has no effect.
+func (m *Manager) Start(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel != nil || m.stopped {
+		return
+	}
+	ctx, m.cancel = context.WithCancel(ctx)
+	m.ctx = ctx
+	for _, c := range m.components {
+		m.start(c)
+	}
+}
+
+// start launches c; m.mu must be held
+func (m *Manager) start(c *component) {
+	go func() {
+		defer close(c.done)
+		c.run(m.ctx)
+	}()
+}
+
+// Stop cancels the shared context and waits for every started component to
+// return, logging each as it does. If ctx ends first, Stop gives up and
+// returns an error naming the components still running. Calls after the
+// first do nothing.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return nil
+	}
+	m.stopped = true
+	cancel := m.cancel
+	components := slices.Clone(m.components)
+	m.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	start := time.Now()
+	var pending []string
+	for _, c := range components {
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+		}
+		select {
+		case <-c.done:
+			log.Printf("Background component %s stopped after %s", c.name, time.Since(start).Round(time.Millisecond))
+		default:
+			pending = append(pending, c.name)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("background components still running after shutdown timeout: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+)
+
+// NewCounter registers a process-wide counter published under name.
+// Counters are exposed as JSON on /debug/vars by Handler.
+func NewCounter(name string) *expvar.Int {
+	return expvar.NewInt(name)
+}
+
+// NewGauge publishes the value returned by fn under name; fn is called each
+// time the metrics are read
+func NewGauge(name string, fn func() any) {
+	expvar.Publish(name, expvar.Func(fn))
+}
+
+// Handler serves all registered metrics
+func Handler() http.Handler {
+	return expvar.Handler()
+}
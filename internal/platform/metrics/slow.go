@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+var slowOperations = NewCounter("slow_operations")
+
+// SlowLog reports operations that take at least a threshold: each one is
+// logged with the accounts involved and counted in slow_operations. A nil
+// SlowLog or a zero threshold reports nothing, and operations under the
+// threshold cost a single clock read.
+type SlowLog struct {
+	threshold time.Duration
+}
+
+// NewSlowLog creates a SlowLog reporting operations slower than threshold
+func NewSlowLog(threshold time.Duration) *SlowLog {
+	return &SlowLog{threshold: threshold}
+}
+
+// Observe reports op if it has been running since start for at least the
+// threshold. It is meant to be deferred:
+//
+//	defer slow.Observe("Debit", time.Now(), accountID)
+func (l *SlowLog) Observe(op string, start time.Time, accountIDs ...string) {
+	if l == nil || l.threshold <= 0 {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < l.threshold {
+		return
+	}
+	slowOperations.Add(1)
+	accountIDs = slices.DeleteFunc(slices.Clone(accountIDs), func(id string) bool { return id == "" })
+	if len(accountIDs) > 0 {
+		log.Printf("Slow %s took %s (accounts: %s)", op, elapsed, strings.Join(accountIDs, ", "))
+	} else {
+		log.Printf("Slow %s took %s", op, elapsed)
+	}
+}
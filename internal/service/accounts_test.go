@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"apex-ledger/internal/account"
+)
+
+func TestImportAccountsChecksBalanceSign(t *testing.T) {
+	store := newMemStore()
+	db, mock := newMockDB(t)
+	// Every record fails before its savepoint, so only the import itself
+	// is committed
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(store, db, nil)
+
+	accs := []account.Account{
+		{ID: "acc-liability", OwnerID: "user-1", Currency: "USD", Type: account.AccountTypeLiability, BalanceCents: 1},
+		{ID: "acc-bogus", OwnerID: "user-1", Currency: "USD", Type: "bogus"},
+	}
+	failures, err := svc.ImportAccounts(context.Background(), accs)
+	if err != nil {
+		t.Fatalf("ImportAccounts: %v", err)
+	}
+	for i, want := range []error{account.ErrBalanceSign, account.ErrInvalidAccountType} {
+		if !errors.Is(failures[i], want) {
+			t.Fatalf("record %d failed with %v, want %v", i, failures[i], want)
+		}
+	}
+	if len(store.accounts) != 0 {
+		t.Fatalf("imported %d accounts, want none", len(store.accounts))
+	}
+}
+
+func TestCreateAccountRequiresOwner(t *testing.T) {
+	// Rejected before a transaction is opened
+	db, _ := newMockDB(t)
+	svc := NewLedgerService(newMemStore(), db, nil)
+	if _, err := svc.CreateAccount(context.Background(), "", "", 0, "USD", ""); !errors.Is(err, account.ErrOwnerRequired) {
+		t.Fatalf("got %v, want ErrOwnerRequired", err)
+	}
+}
+
+func TestImportAccountsRequiresOwner(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(newMemStore(), db, nil)
+
+	failures, err := svc.ImportAccounts(context.Background(), []account.Account{{ID: "acc-a", Currency: "USD"}})
+	if err != nil {
+		t.Fatalf("ImportAccounts: %v", err)
+	}
+	if !errors.Is(failures[0], account.ErrOwnerRequired) {
+		t.Fatalf("record failed with %v, want ErrOwnerRequired", failures[0])
+	}
+}
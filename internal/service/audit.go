@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/auth"
+	"apex-ledger/internal/platform/metrics"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Operations recorded in the audit log
+const (
+	AuditTransfer              = "transfer"
+	AuditBatchTransfer         = "batch_transfer"
+	AuditCrossCurrencyTransfer = "cross_currency_transfer"
+	AuditReverseTransfer       = "reverse_transfer"
+	AuditAdjustBalance         = "adjust_balance"
+	AuditDeposit               = "deposit"
+	AuditAccrueInterest        = "accrue_interest"
+	AuditOverdraftPenalty      = "overdraft_penalty"
+	AuditCreateAccount         = "create_account"
+	AuditImportAccounts        = "import_accounts"
+	AuditUpdateAccount         = "update_account"
+	AuditDeleteAccount         = "delete_account"
+	AuditActivateAccount       = "activate_account"
+	AuditSetParentAccount      = "set_parent_account"
+	AuditSetNotification       = "set_notification_preference"
+	AuditSetSensitive          = "set_sensitive"
+)
+
+// Read operations, recorded only for sensitive accounts. Their names start
+// with read_ so QueryAuditLog can select them together.
+const (
+	AuditReadBalance = "read_balance"
+	AuditReadAccount = "read_account"
+)
+
+// systemActor is recorded for operations with no authenticated caller
+const systemActor = "system"
+
+// auditWriteTimeout bounds recording a failure, which runs after the
+// request's own context may already be done
+const auditWriteTimeout = 5 * time.Second
+
+var auditWriteFailures = metrics.NewCounter("audit_write_failures")
+
+// auditActor returns the authenticated caller's ID, as set by the auth
+// interceptor, or systemActor for background jobs, public methods and
+// tokens without a sub claim
+func auditActor(ctx context.Context) string {
+	if user, ok := auth.UserFromContext(ctx); ok && user.ID != "" {
+		return user.ID
+	}
+	return systemActor
+}
+
+// audit records a successful operation within tx, so the entry can't be lost
+// without the change it describes also rolling back
+func (s *LedgerService) audit(ctx context.Context, tx *sqlx.Tx, operation, transactionID string, accountIDs ...string) error {
+	return s.accountRepo.RecordAuditTx(ctx, tx, &account.AuditEntry{
+		ActorID:       auditActor(ctx),
+		Operation:     operation,
+		AccountIDs:    nonEmpty(accountIDs),
+		TransactionID: transactionID,
+		Outcome:       account.AuditSuccess,
+	})
+}
+
+// auditFailure records operation as failed if *errp is set. It is deferred
+// by each mutating method; the failed operation's transaction has rolled
+// back, so the entry is written on its own, and a failure to write it is
+// logged rather than masking the original error.
+func (s *LedgerService) auditFailure(ctx context.Context, operation string, errp *error, accountIDs ...string) {
+	if *errp == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditWriteTimeout)
+	defer cancel()
+	s.recordAudit(ctx, &account.AuditEntry{
+		ActorID:    auditActor(ctx),
+		Operation:  operation,
+		AccountIDs: nonEmpty(accountIDs),
+		Outcome:    account.AuditFailure,
+		Error:      (*errp).Error(),
+	})
+}
+
+// recordAudit writes an entry outside any transaction, logging failures
+func (s *LedgerService) recordAudit(ctx context.Context, e *account.AuditEntry) {
+	if err := s.accountRepo.RecordAudit(ctx, e); err != nil {
+		auditWriteFailures.Add(1)
+		log.Printf("Audit entry for %s by %s (%s) not recorded: %v", e.Operation, e.ActorID, e.Outcome, err)
+	}
+}
+
+// auditRead records that the caller read acc, if acc is sensitive. Unlike
+// auditFailure it fails the read when the entry can't be written: a
+// sensitive account is never disclosed without a record of who saw it.
+func (s *LedgerService) auditRead(ctx context.Context, operation string, acc *account.Account) error {
+	if !acc.Sensitive {
+		return nil
+	}
+	err := s.accountRepo.RecordAudit(ctx, &account.AuditEntry{
+		ActorID:    auditActor(ctx),
+		Operation:  operation,
+		AccountIDs: []string{acc.ID},
+		Outcome:    account.AuditSuccess,
+	})
+	if err != nil {
+		auditWriteFailures.Add(1)
+		return fmt.Errorf("failed to audit read of account %s: %w", acc.ID, err)
+	}
+	return nil
+}
+
+// nonEmpty drops empty IDs, such as an account ID a failed request omitted
+func nonEmpty(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// batchAccountIDs lists each account a batch touches once, in first-seen order
+func batchAccountIDs(entries []account.TransferEntry) []string {
+	seen := make(map[string]bool, 2*len(entries))
+	var ids []string
+	for _, e := range entries {
+		for _, id := range []string{e.FromID, e.ToID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// adjustmentAccountIDs lists each account a bulk adjustment touches once, in
+// first-seen order
+func adjustmentAccountIDs(adjs []account.BalanceAdjustment) []string {
+	seen := make(map[string]bool, len(adjs))
+	var ids []string
+	for _, adj := range adjs {
+		if !seen[adj.AccountID] {
+			seen[adj.AccountID] = true
+			ids = append(ids, adj.AccountID)
+		}
+	}
+	return ids
+}
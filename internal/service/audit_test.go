@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/auth"
+)
+
+func TestTransferAuditActor(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  *auth.User
+		actor string
+	}{
+		{name: "token subject", user: &auth.User{ID: "user-1"}, actor: "user-1"},
+		{name: "token without subject", user: &auth.User{}, actor: systemActor},
+		{name: "no caller", actor: systemActor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTransferStore()
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			svc := NewLedgerService(store, db, nil)
+
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = auth.ContextWithUser(ctx, tt.user)
+			}
+			txID, err := svc.PerformTransfer(ctx, "acc-a", "acc-b", 100)
+			if err != nil {
+				t.Fatalf("PerformTransfer: %v", err)
+			}
+
+			if len(store.audits) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(store.audits))
+			}
+			entry := store.audits[0]
+			if entry.ActorID != tt.actor || entry.Operation != AuditTransfer || entry.TransactionID != txID || entry.Outcome != account.AuditSuccess {
+				t.Fatalf("audit entry %+v, want a successful %s of %s by %s", entry, AuditTransfer, txID, tt.actor)
+			}
+			if got := store.txs[0].ActorID; got != tt.actor {
+				t.Fatalf("transaction actor = %q, want %q", got, tt.actor)
+			}
+		})
+	}
+}
+
+func TestInterestAndPenaltyAudited(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	accrue := func(svc *LedgerService, id string) (*account.Transaction, error) {
+		return svc.AccrueInterest(context.Background(), id, day, day.AddDate(0, 1, 0), DayCountActual365)
+	}
+	charge := func(svc *LedgerService, id string) (*account.Transaction, error) {
+		return svc.ChargeOverdraftPenalty(context.Background(), id, day, day.AddDate(0, 0, 1), day, 2000, DayCountActual365)
+	}
+	tests := []struct {
+		name      string
+		run       func(svc *LedgerService, id string) (*account.Transaction, error)
+		accountID string
+		operation string
+		outcome   string
+	}{
+		{name: "interest credited", run: accrue, accountID: "acc-a", operation: AuditAccrueInterest, outcome: account.AuditSuccess},
+		{name: "interest failed", run: accrue, accountID: "acc-missing", operation: AuditAccrueInterest, outcome: account.AuditFailure},
+		{name: "penalty charged", run: charge, accountID: "acc-o", operation: AuditOverdraftPenalty, outcome: account.AuditSuccess},
+		{name: "penalty failed", run: charge, accountID: "acc-missing", operation: AuditOverdraftPenalty, outcome: account.AuditFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore(
+				&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 100000, InterestRateBps: 500},
+				&account.Account{ID: "acc-o", Currency: "USD", BalanceCents: -100000},
+			)
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			if tt.outcome == account.AuditSuccess {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+			svc := NewLedgerService(store, db, nil)
+
+			record, _ := tt.run(svc, tt.accountID)
+			if len(store.audits) != 1 {
+				t.Fatalf("recorded %d audit entries, want 1", len(store.audits))
+			}
+			entry := store.audits[0]
+			if entry.Operation != tt.operation || entry.Outcome != tt.outcome || !slices.Equal(entry.AccountIDs, []string{tt.accountID}) {
+				t.Fatalf("audit entry %+v, want %s %s of %s", entry, tt.operation, tt.outcome, tt.accountID)
+			}
+			if record != nil && entry.TransactionID != record.ID {
+				t.Fatalf("audited transaction %q, want %q", entry.TransactionID, record.ID)
+			}
+		})
+	}
+}
+
+func TestFailureAuditNamesAccounts(t *testing.T) {
+	t.Run("reversal", func(t *testing.T) {
+		store := newTransferStore()
+		store.txs = append(store.txs, &account.Transaction{
+			ID: "tx-1", FromAccountID: "acc-a", ToAccountID: "acc-b", AmountCents: 100, Currency: "USD", Kind: account.TransactionKindTransfer,
+		})
+		db, mock := newMockDB(t)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		svc := NewLedgerService(store, db, nil)
+
+		if _, _, err := svc.ReverseTransfer(context.Background(), "tx-1", 500, "refund", "admin-1"); !errors.Is(err, account.ErrReversalExceedsTotal) {
+			t.Fatalf("got %v, want ErrReversalExceedsTotal", err)
+		}
+		if len(store.audits) != 1 || !slices.Equal(store.audits[0].AccountIDs, []string{"acc-b", "acc-a"}) {
+			t.Fatalf("audit entries %+v, want one failure naming acc-b and acc-a", store.audits)
+		}
+	})
+
+	t.Run("bulk adjustment", func(t *testing.T) {
+		store := newTransferStore()
+		db, mock := newMockDB(t)
+		mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+		svc := NewLedgerService(store, db, nil)
+
+		adjs := []account.BalanceAdjustment{
+			{AccountID: "acc-b", DeltaCents: 5, Reason: "fee"},
+			{AccountID: "acc-a", DeltaCents: -5, Reason: "fee"},
+			{AccountID: "acc-b", DeltaCents: 1, Reason: "fee"},
+		}
+		if _, err := svc.BulkAdjustBalance(context.Background(), adjs, "admin-1"); err == nil {
+			t.Fatal("bulk adjustment succeeded without a transaction")
+		}
+		if len(store.audits) != 1 || !slices.Equal(store.audits[0].AccountIDs, []string{"acc-b", "acc-a"}) {
+			t.Fatalf("audit entries %+v, want one failure naming acc-b and acc-a", store.audits)
+		}
+	})
+}
+
+func TestBatchGetBalanceAuditsSensitiveReads(t *testing.T) {
+	store := newMemStore(
+		&account.Account{ID: "acc-a", Currency: "USD", Sensitive: true},
+		&account.Account{ID: "acc-b", Currency: "USD"},
+		&account.Account{ID: "acc-c", Currency: "USD", Sensitive: true},
+	)
+	svc := NewLedgerService(store, nil, nil)
+	ctx := auth.ContextWithUser(context.Background(), &auth.User{ID: "user-1"})
+
+	found, notFound, _, err := svc.BatchGetBalance(ctx, []string{"acc-a", "acc-b", "acc-c", "acc-missing"})
+	if err != nil {
+		t.Fatalf("BatchGetBalance: %v", err)
+	}
+	if len(found) != 3 || !slices.Equal(notFound, []string{"acc-missing"}) {
+		t.Fatalf("found %d accounts, not found %v; want 3 and [acc-missing]", len(found), notFound)
+	}
+	var audited []string
+	for _, e := range store.audits {
+		if e.Operation != AuditReadBalance || e.ActorID != "user-1" {
+			t.Fatalf("audit entry %+v, want a %s by user-1", e, AuditReadBalance)
+		}
+		audited = append(audited, e.AccountIDs...)
+	}
+	if !slices.Equal(audited, []string{"acc-a", "acc-c"}) {
+		t.Fatalf("audited reads of %v, want the sensitive acc-a and acc-c", audited)
+	}
+
+	// The access check behind transaction reads shows the caller nothing,
+	// so it records nothing
+	store.audits = nil
+	if _, err := svc.LookupAccounts(ctx, []string{"acc-a", "acc-c"}); err != nil {
+		t.Fatalf("LookupAccounts: %v", err)
+	}
+	if len(store.audits) != 0 {
+		t.Fatalf("LookupAccounts recorded %d audit entries, want none", len(store.audits))
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"apex-ledger/internal/account"
+)
+
+func TestBatchTransferCrossingEntries(t *testing.T) {
+	store := newMemStore(
+		&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 100},
+		&account.Account{ID: "acc-b", Currency: "USD"},
+		&account.Account{ID: "acc-c", Currency: "USD"},
+	)
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(store, db, nil)
+
+	// b starts empty and passes on money it receives earlier in the batch
+	ids, err := svc.BatchTransfer(context.Background(), []account.TransferEntry{
+		{FromID: "acc-a", ToID: "acc-b", AmountCents: 100},
+		{FromID: "acc-b", ToID: "acc-a", AmountCents: 30},
+		{FromID: "acc-b", ToID: "acc-c", AmountCents: 50},
+	})
+	if err != nil {
+		t.Fatalf("BatchTransfer: %v", err)
+	}
+	if len(ids) != 3 {
+		t.Fatalf("got %d transaction IDs, want 3", len(ids))
+	}
+
+	// Each account is locked once, in sorted order
+	if want := []string{"acc-a", "acc-b", "acc-c"}; !slices.Equal(store.locked, want) {
+		t.Errorf("locked %v, want %v", store.locked, want)
+	}
+
+	// Each balance moves once, by its net
+	wantDebits := map[string][]int64{"acc-a": {70}}
+	wantCredits := map[string][]int64{"acc-b": {20}, "acc-c": {50}}
+	for id, want := range wantDebits {
+		if got := store.debits[id]; !slices.Equal(got, want) {
+			t.Errorf("debits of %s = %v, want %v", id, got, want)
+		}
+	}
+	for id, want := range wantCredits {
+		if got := store.credits[id]; !slices.Equal(got, want) {
+			t.Errorf("credits of %s = %v, want %v", id, got, want)
+		}
+	}
+	if len(store.debits) != len(wantDebits) || len(store.credits) != len(wantCredits) {
+		t.Errorf("debits %v, credits %v; want only %v and %v", store.debits, store.credits, wantDebits, wantCredits)
+	}
+
+	for id, want := range map[string]int64{"acc-a": 30, "acc-b": 20, "acc-c": 50} {
+		if got := store.balance(id); got != want {
+			t.Errorf("balance of %s = %d, want %d", id, got, want)
+		}
+	}
+
+	// Every entry is recorded with the running balances after it
+	if len(store.txs) != 3 {
+		t.Fatalf("recorded %d transactions, want 3", len(store.txs))
+	}
+	wantAfter := [][2]int64{{0, 100}, {70, 30}, {20, 50}}
+	for i, rec := range store.txs {
+		if got := [2]int64{*rec.FromBalanceAfter, *rec.ToBalanceAfter}; got != wantAfter[i] {
+			t.Errorf("transaction %d balances after = %v, want %v", i, got, wantAfter[i])
+		}
+	}
+}
+
+func TestBatchTransferNetOverdrawn(t *testing.T) {
+	store := newMemStore(
+		&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 50},
+		&account.Account{ID: "acc-b", Currency: "USD"},
+	)
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	svc := NewLedgerService(store, db, nil)
+
+	// a receives 30 back but still needs 70 more than it holds
+	_, err := svc.BatchTransfer(context.Background(), []account.TransferEntry{
+		{FromID: "acc-a", ToID: "acc-b", AmountCents: 100},
+		{FromID: "acc-b", ToID: "acc-a", AmountCents: 30},
+	})
+	var insufficient *account.InsufficientFundsError
+	if !errors.As(err, &insufficient) {
+		t.Fatalf("got %v, want InsufficientFundsError", err)
+	}
+	// The fake store doesn't roll back; the expected rollback undoes any
+	// balance already moved, so only check nothing was recorded
+	if len(store.txs) != 0 {
+		t.Errorf("recorded %d transactions", len(store.txs))
+	}
+}
+
+func TestBatchTransferLeavesEntriesAlone(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	store := newTransferStore()
+	svc := NewLedgerService(store, db, nil)
+
+	entries := []account.TransferEntry{{FromID: "acc-a", ToID: "acc-b", AmountCents: 100, Category: "  Rent "}}
+	if _, err := svc.BatchTransfer(context.Background(), entries); err != nil {
+		t.Fatalf("BatchTransfer: %v", err)
+	}
+	if entries[0].Category != "  Rent " {
+		t.Fatalf("caller's category changed to %q", entries[0].Category)
+	}
+	if got := store.txs[0].Category; got != "rent" {
+		t.Fatalf("recorded category %q, want rent", got)
+	}
+}
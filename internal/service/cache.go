@@ -0,0 +1,81 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var (
+	cacheHits   = metrics.NewCounter("balance_cache_hits")
+	cacheMisses = metrics.NewCounter("balance_cache_misses")
+)
+
+// sweepThreshold is the entry count above which expired entries are purged on write
+const sweepThreshold = 10000
+
+// balanceCache holds recently read accounts keyed by ID for at most ttl.
+// Entries are dropped after any committed balance change, so a stale read can
+// only happen in the window between a DB read and a concurrent commit, and is
+// still bounded by the TTL.
+type balanceCache struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	acc       account.Account
+	expiresAt time.Time
+}
+
+func newBalanceCache(ttl time.Duration) *balanceCache {
+	return &balanceCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns a copy of the cached account if present and not expired
+func (c *balanceCache) get(id string) (*account.Account, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[id]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(e.expiresAt) {
+		cacheMisses.Add(1)
+		return nil, false
+	}
+	cacheHits.Add(1)
+	acc := e.acc
+	return &acc, true
+}
+
+// set stores a copy of acc
+func (c *balanceCache) set(acc *account.Account) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= sweepThreshold {
+		for id, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+	}
+	c.entries[acc.ID] = cacheEntry{acc: *acc, expiresAt: now.Add(c.ttl)}
+}
+
+// invalidate drops the given accounts; call it after the change has committed
+func (c *balanceCache) invalidate(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.entries, id)
+	}
+}
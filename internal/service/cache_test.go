@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+)
+
+// counted runs fn and reports how many cache hits and misses it recorded
+func counted(fn func()) (hits, misses int64) {
+	h, m := cacheHits.Value(), cacheMisses.Value()
+	fn()
+	return cacheHits.Value() - h, cacheMisses.Value() - m
+}
+
+func TestBalanceCacheHitAndMiss(t *testing.T) {
+	c := newBalanceCache(time.Minute)
+
+	hits, misses := counted(func() {
+		if _, ok := c.get("acc-a"); ok {
+			t.Fatal("empty cache returned an entry")
+		}
+	})
+	if hits != 0 || misses != 1 {
+		t.Fatalf("cold read counted %d hits, %d misses; want 0, 1", hits, misses)
+	}
+
+	c.set(&account.Account{ID: "acc-a", BalanceCents: 500, Currency: "USD"})
+	hits, misses = counted(func() {
+		acc, ok := c.get("acc-a")
+		if !ok {
+			t.Fatal("cached account not returned")
+		}
+		if acc.BalanceCents != 500 {
+			t.Fatalf("balance = %d, want 500", acc.BalanceCents)
+		}
+	})
+	if hits != 1 || misses != 0 {
+		t.Fatalf("warm read counted %d hits, %d misses; want 1, 0", hits, misses)
+	}
+}
+
+func TestBalanceCacheCopies(t *testing.T) {
+	c := newBalanceCache(time.Minute)
+	acc := &account.Account{ID: "acc-a", BalanceCents: 500}
+	c.set(acc)
+	acc.BalanceCents = 1
+
+	got, _ := c.get("acc-a")
+	if got.BalanceCents != 500 {
+		t.Fatalf("changing the stored account changed the cache: balance = %d", got.BalanceCents)
+	}
+	got.BalanceCents = 2
+	if again, _ := c.get("acc-a"); again.BalanceCents != 500 {
+		t.Fatalf("changing a returned account changed the cache: balance = %d", again.BalanceCents)
+	}
+}
+
+func TestBalanceCacheExpiry(t *testing.T) {
+	c := newBalanceCache(20 * time.Millisecond)
+	c.set(&account.Account{ID: "acc-a", BalanceCents: 500})
+	if _, ok := c.get("acc-a"); !ok {
+		t.Fatal("fresh entry not returned")
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	hits, misses := counted(func() {
+		if _, ok := c.get("acc-a"); ok {
+			t.Fatal("expired entry returned")
+		}
+	})
+	if hits != 0 || misses != 1 {
+		t.Fatalf("expired read counted %d hits, %d misses; want 0, 1", hits, misses)
+	}
+
+	// Setting it again starts a new TTL
+	c.set(&account.Account{ID: "acc-a", BalanceCents: 700})
+	if acc, ok := c.get("acc-a"); !ok || acc.BalanceCents != 700 {
+		t.Fatalf("refreshed entry = %v, %v; want balance 700", acc, ok)
+	}
+}
+
+func TestBalanceCacheInvalidate(t *testing.T) {
+	c := newBalanceCache(time.Minute)
+	for _, id := range []string{"acc-a", "acc-b", "acc-c"} {
+		c.set(&account.Account{ID: id})
+	}
+
+	c.invalidate("acc-a", "acc-b", "acc-missing")
+	for _, id := range []string{"acc-a", "acc-b"} {
+		if _, ok := c.get(id); ok {
+			t.Fatalf("%s still cached after invalidate", id)
+		}
+	}
+	if _, ok := c.get("acc-c"); !ok {
+		t.Fatal("acc-c dropped though it wasn't invalidated")
+	}
+}
+
+func TestBalanceCacheSweep(t *testing.T) {
+	c := newBalanceCache(time.Minute)
+	past := time.Now().Add(-time.Second)
+	for i := range sweepThreshold {
+		id := fmt.Sprintf("expired-%d", i)
+		c.entries[id] = cacheEntry{acc: account.Account{ID: id}, expiresAt: past}
+	}
+	c.entries["live"] = cacheEntry{acc: account.Account{ID: "live"}, expiresAt: time.Now().Add(time.Minute)}
+
+	c.set(&account.Account{ID: "new"})
+	if len(c.entries) != 2 {
+		t.Fatalf("%d entries after the sweep, want the live and new ones", len(c.entries))
+	}
+}
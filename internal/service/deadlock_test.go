@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// rowLocks is a database/sql connector whose transactions take exclusive
+// row locks, held until commit or rollback like Postgres's SELECT FOR
+// UPDATE. "LOCK <id>" is the only statement it runs.
+type rowLocks struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+func (r *rowLocks) lock(id string) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.locks == nil {
+		r.locks = make(map[string]chan struct{})
+	}
+	l, ok := r.locks[id]
+	if !ok {
+		l = make(chan struct{}, 1)
+		r.locks[id] = l
+	}
+	return l
+}
+
+func (r *rowLocks) Connect(context.Context) (driver.Conn, error) { return &rowLockConn{locks: r}, nil }
+func (r *rowLocks) Driver() driver.Driver                        { return nil }
+
+type rowLockConn struct {
+	locks *rowLocks
+	held  []chan struct{}
+}
+
+func (c *rowLockConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *rowLockConn) Close() error                        { return nil }
+func (c *rowLockConn) Begin() (driver.Tx, error)           { return c, nil }
+
+// ExecContext takes the lock named by the single argument, waiting for it
+// as long as ctx allows
+func (c *rowLockConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if query != "LOCK" || len(args) != 1 {
+		return nil, errors.New("unsupported statement " + query)
+	}
+	l := c.locks.lock(args[0].Value.(string))
+	select {
+	case l <- struct{}{}:
+		c.held = append(c.held, l)
+		return driver.RowsAffected(0), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *rowLockConn) Commit() error   { return c.release() }
+func (c *rowLockConn) Rollback() error { return c.release() }
+
+func (c *rowLockConn) release() error {
+	for _, l := range c.held {
+		<-l
+	}
+	c.held = nil
+	return nil
+}
+
+// lockingStore is a memStore whose locking reads hold the row lock until the
+// transaction ends. It pauses after each lock so concurrent transfers
+// interleave, as they do when the database is slow.
+type lockingStore struct {
+	*memStore
+}
+
+func (s lockingStore) GetAccountWithLock(ctx context.Context, tx *sqlx.Tx, id string) (*account.Account, error) {
+	if _, err := tx.ExecContext(ctx, "LOCK", id); err != nil {
+		return nil, err
+	}
+	time.Sleep(time.Millisecond)
+	return s.memStore.GetAccountWithLock(ctx, tx, id)
+}
+
+func TestOpposingTransfersDontDeadlock(t *testing.T) {
+	store := lockingStore{newMemStore(
+		&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 10000},
+		&account.Account{ID: "acc-b", Currency: "USD", BalanceCents: 10000},
+	)}
+	db := sqlx.NewDb(sql.OpenDB(&rowLocks{}), "rowlocks")
+	defer db.Close()
+	svc := NewLedgerService(store, db, nil)
+
+	// Taken in argument order, A->B and B->A would each hold the lock the
+	// other waits for until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	const transfers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*transfers)
+	for i := 0; i < transfers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := svc.PerformTransfer(ctx, "acc-a", "acc-b", 10)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := svc.PerformTransfer(ctx, "acc-b", "acc-a", 10)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("transfer failed: %v", err)
+		}
+	}
+
+	if a, b := store.balance("acc-a"), store.balance("acc-b"); a != 10000 || b != 10000 {
+		t.Fatalf("balances a=%d b=%d, want 10000 each", a, b)
+	}
+	if len(store.txs) != 2*transfers {
+		t.Fatalf("recorded %d transactions, want %d", len(store.txs), 2*transfers)
+	}
+}
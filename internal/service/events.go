@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var eventsDropped = metrics.NewCounter("transfer_events_dropped")
+
+// subscriberBuffer is the number of events queued per subscriber before new
+// events for it are dropped
+const subscriberBuffer = 256
+
+// TransferSubscriber receives committed transfers. It runs on its own
+// goroutine, so it may block without holding up transfers or other
+// subscribers, but events arriving while its buffer is full are dropped.
+type TransferSubscriber func(account.TransferEvent)
+
+// eventBus fans committed transfers out to every subscriber
+type eventBus struct {
+	mu     sync.RWMutex
+	subs   map[int]chan account.TransferEvent
+	nextID int
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[int]chan account.TransferEvent)}
+}
+
+// subscribe starts delivering events to fn and returns a function that stops it
+func (b *eventBus) subscribe(fn TransferSubscriber) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan account.TransferEvent, subscriberBuffer)
+	b.subs[id] = ch
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for ev := range ch {
+			fn(ev)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if ch, ok := b.subs[id]; ok {
+				delete(b.subs, id)
+				close(ch)
+			}
+		})
+	}
+}
+
+// publish hands ev to every subscriber without blocking
+func (b *eventBus) publish(ev account.TransferEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for id, ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+			eventsDropped.Add(1)
+			log.Printf("Transfer event %s dropped: subscriber %d is falling behind", ev.TransactionID, id)
+		}
+	}
+}
+
+// close stops accepting events and waits for subscribers to finish the
+// events already queued
+func (b *eventBus) close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for id, ch := range b.subs {
+			delete(b.subs, id)
+			close(ch)
+		}
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
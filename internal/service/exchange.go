@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"apex-ledger/internal/account"
+)
+
+// ExchangeRateProvider supplies the rate for converting one currency into another
+type ExchangeRateProvider interface {
+	// Rate returns how many units of to one unit of from buys
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticRateProvider serves rates from a fixed table keyed "FROM/TO".
+// A missing pair falls back to the inverse of the opposite pair if present.
+type StaticRateProvider struct {
+	rates map[string]float64
+}
+
+// NewStaticRateProvider creates a provider over rates keyed "FROM/TO"
+func NewStaticRateProvider(rates map[string]float64) *StaticRateProvider {
+	normalized := make(map[string]float64, len(rates))
+	for pair, rate := range rates {
+		normalized[strings.ToUpper(pair)] = rate
+	}
+	return &StaticRateProvider{rates: normalized}
+}
+
+// Rate implements ExchangeRateProvider
+func (p *StaticRateProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if rate, ok := p.rates[from+"/"+to]; ok && rate > 0 {
+		return rate, nil
+	}
+	if rate, ok := p.rates[to+"/"+from]; ok && rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("no exchange rate for %s/%s", from, to)
+}
+
+// RoundingMode is how a converted amount is rounded to whole minor units.
+// Its value is recorded on each cross-currency transaction.
+type RoundingMode string
+
+const (
+	// RoundHalfEven rounds to the nearest unit, ties to the even one
+	// (banker's rounding)
+	RoundHalfEven RoundingMode = "half-even"
+	// RoundHalfUp rounds to the nearest unit, ties away from zero
+	RoundHalfUp RoundingMode = "half-up"
+	// RoundFloor rounds down, so the receiver never gets a fraction of a
+	// unit more than the rate gives
+	RoundFloor RoundingMode = "floor"
+)
+
+// ParseRoundingMode maps a rounding mode name from configuration to its value
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	switch mode := RoundingMode(name); mode {
+	case RoundHalfEven, RoundHalfUp, RoundFloor:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown rounding mode %q", name)
+}
+
+// round rounds x to a whole number according to mode
+func (mode RoundingMode) round(x float64) float64 {
+	switch mode {
+	case RoundHalfEven:
+		return math.RoundToEven(x)
+	case RoundFloor:
+		return math.Floor(x)
+	default:
+		return math.Round(x)
+	}
+}
+
+// convertAmount converts amount minor units at rate, rounding to a whole unit
+// with mode. It also returns the adjustment rounding made, in minor units of
+// the target currency, so that converted = amount × rate + adjustment.
+func convertAmount(amount int64, rate float64, mode RoundingMode) (int64, float64, error) {
+	exact := float64(amount) * rate
+	converted := mode.round(exact)
+	// float64(math.MaxInt64) rounds up to 2^63, so >= catches every overflow
+	if converted >= math.MaxInt64 || converted < math.MinInt64 {
+		return 0, 0, fmt.Errorf("converting %d at rate %g: %w", amount, rate, account.ErrAmountOverflow)
+	}
+	return int64(converted), converted - exact, nil
+}
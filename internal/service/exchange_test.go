@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"apex-ledger/internal/account"
+)
+
+func TestConvertAmountRounding(t *testing.T) {
+	tests := []struct {
+		amount int64
+		rate   float64
+		mode   RoundingMode
+		want   int64
+		adjust float64
+	}{
+		// Ties: 2.5 and 3.5
+		{amount: 5, rate: 0.5, mode: RoundHalfEven, want: 2, adjust: -0.5},
+		{amount: 7, rate: 0.5, mode: RoundHalfEven, want: 4, adjust: 0.5},
+		{amount: 5, rate: 0.5, mode: RoundHalfUp, want: 3, adjust: 0.5},
+		{amount: 7, rate: 0.5, mode: RoundHalfUp, want: 4, adjust: 0.5},
+		{amount: 5, rate: 0.5, mode: RoundFloor, want: 2, adjust: -0.5},
+		{amount: 7, rate: 0.5, mode: RoundFloor, want: 3, adjust: -0.5},
+		// Off a tie every nearest mode agrees; floor still rounds down
+		{amount: 100, rate: 0.257, mode: RoundHalfEven, want: 26, adjust: 0.3},
+		{amount: 100, rate: 0.257, mode: RoundHalfUp, want: 26, adjust: 0.3},
+		{amount: 100, rate: 0.257, mode: RoundFloor, want: 25, adjust: -0.7},
+		// Exact conversions need no adjustment
+		{amount: 1000, rate: 2, mode: RoundHalfEven, want: 2000},
+	}
+	for _, tt := range tests {
+		got, adjust, err := convertAmount(tt.amount, tt.rate, tt.mode)
+		if err != nil {
+			t.Fatalf("convertAmount(%d, %g, %s): %v", tt.amount, tt.rate, tt.mode, err)
+		}
+		if got != tt.want || math.Abs(adjust-tt.adjust) > 1e-9 {
+			t.Errorf("convertAmount(%d, %g, %s) = %d, %g; want %d, %g", tt.amount, tt.rate, tt.mode, got, adjust, tt.want, tt.adjust)
+		}
+	}
+}
+
+func TestConvertAmountOverflow(t *testing.T) {
+	_, _, err := convertAmount(math.MaxInt64/2, 4, RoundHalfUp)
+	if !errors.Is(err, account.ErrAmountOverflow) {
+		t.Fatalf("got %v, want ErrAmountOverflow", err)
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for _, name := range []string{"half-even", "half-up", "floor"} {
+		if mode, err := ParseRoundingMode(name); err != nil || string(mode) != name {
+			t.Errorf("ParseRoundingMode(%q) = %q, %v", name, mode, err)
+		}
+	}
+	if _, err := ParseRoundingMode("ceiling"); err == nil {
+		t.Error("ParseRoundingMode accepted ceiling")
+	}
+}
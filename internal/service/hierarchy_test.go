@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"apex-ledger/internal/account"
+)
+
+func TestSetParentAccountMissingParent(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	svc := NewLedgerService(newMemStore(&account.Account{ID: "acc-a", Currency: "USD"}), db, nil)
+
+	_, err := svc.SetParentAccount(context.Background(), "acc-a", "acc-missing")
+	if !errors.Is(err, account.ErrParentNotFound) {
+		t.Fatalf("got %v, want ErrParentNotFound", err)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator produces IDs for new accounts, transactions and quotes
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc adapts a function to IDGenerator, e.g. a counter for
+// predictable IDs in tests
+type IDGeneratorFunc func() string
+
+// NewID implements IDGenerator
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// UUIDv4Generator generates random UUIDs. It is the default.
+type UUIDv4Generator struct{}
+
+// NewID implements IDGenerator
+func (UUIDv4Generator) NewID() string {
+	return uuid.New().String()
+}
+
+// UUIDv7Generator generates time-ordered UUIDs, so IDs sort by creation time
+// and index inserts stay local
+type UUIDv7Generator struct{}
+
+// NewID implements IDGenerator
+func (UUIDv7Generator) NewID() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		// Only fails if the system random source does; fall back rather than panic
+		return uuid.New().String()
+	}
+	return id.String()
+}
+
+// NewIDGenerator returns the generator for a format name: "uuidv4" (or "")
+// or "uuidv7"
+func NewIDGenerator(format string) (IDGenerator, error) {
+	switch format {
+	case "", "uuidv4":
+		return UUIDv4Generator{}, nil
+	case "uuidv7":
+		return UUIDv7Generator{}, nil
+	}
+	return nil, fmt.Errorf("unknown ID format %q", format)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var (
+	interestRuns     = metrics.NewCounter("interest_accrual_runs")
+	interestAccruals = metrics.NewCounter("interest_accruals")
+)
+
+// accrualCheckInterval is how often the accruer looks for a newly completed
+// period. Periods already credited are skipped, so checking more often than
+// the period only costs a query.
+const accrualCheckInterval = time.Hour
+
+// AccrualPeriod is how often interest is credited
+type AccrualPeriod int
+
+const (
+	AccrualDaily AccrualPeriod = iota
+	AccrualMonthly
+)
+
+// ParseAccrualPeriod maps a period name from configuration to its value
+func ParseAccrualPeriod(name string) (AccrualPeriod, error) {
+	switch name {
+	case "daily":
+		return AccrualDaily, nil
+	case "monthly":
+		return AccrualMonthly, nil
+	}
+	return 0, fmt.Errorf("unknown accrual period %q", name)
+}
+
+// lastCompleted returns the bounds [start, end) of the most recent period,
+// in UTC, that ended at or before now
+func (p AccrualPeriod) lastCompleted(now time.Time) (start, end time.Time) {
+	now = now.UTC()
+	switch p {
+	case AccrualMonthly:
+		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, -1, 0), end
+	default:
+		end = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, 0, -1), end
+	}
+}
+
+// DayCount is the convention that turns a period into a fraction of a year
+type DayCount int
+
+const (
+	DayCountActual365 DayCount = iota
+	DayCountActual360
+	DayCount30360
+)
+
+// ParseDayCount maps a day-count convention name from configuration to its value
+func ParseDayCount(name string) (DayCount, error) {
+	switch name {
+	case "actual/365":
+		return DayCountActual365, nil
+	case "actual/360":
+		return DayCountActual360, nil
+	case "30/360":
+		return DayCount30360, nil
+	}
+	return 0, fmt.Errorf("unknown day-count convention %q", name)
+}
+
+// String returns the configuration name of the convention
+func (d DayCount) String() string {
+	switch d {
+	case DayCountActual360:
+		return "actual/360"
+	case DayCount30360:
+		return "30/360"
+	default:
+		return "actual/365"
+	}
+}
+
+// yearFraction returns the period [start, end) as days over days-per-year
+func (d DayCount) yearFraction(start, end time.Time) (days, basis int64) {
+	switch d {
+	case DayCountActual360:
+		return actualDays(start, end), 360
+	case DayCount30360:
+		// 30E/360: every month counts as 30 days, the 31st as the 30th
+		d1, d2 := min(start.Day(), 30), min(end.Day(), 30)
+		days = 360*int64(end.Year()-start.Year()) + 30*int64(end.Month()-start.Month()) + int64(d2-d1)
+		return days, 360
+	default:
+		return actualDays(start, end), 365
+	}
+}
+
+func actualDays(start, end time.Time) int64 {
+	return int64(end.Sub(start).Round(time.Hour).Hours() / 24)
+}
+
+// accruedInterest is balance * rate * days / basis, rounded down to whole
+// cents so the ledger never credits interest that wasn't earned. It is
+// computed in arbitrary precision, so large balances cannot overflow.
+func accruedInterest(balanceCents, rateBps, days, basis int64) int64 {
+	n := new(big.Int).Mul(big.NewInt(balanceCents), big.NewInt(rateBps))
+	n.Mul(n, big.NewInt(days))
+	n.Quo(n, big.NewInt(10000*basis))
+	if !n.IsInt64() {
+		return 0
+	}
+	return n.Int64()
+}
+
+// interestReference is the external reference recorded on an accrual. The
+// unique index on external_reference makes each (period, account) pair
+// creditable only once, however often the job runs.
+func interestReference(periodStart time.Time, accountID string) string {
+	return interestReferencePrefix(periodStart) + accountID
+}
+
+func interestReferencePrefix(periodStart time.Time) string {
+	return "interest:" + periodStart.Format("2006-01-02") + ":"
+}
+
+// InterestAccruer credits interest-bearing accounts once per accrual period.
+// Each account is credited in its own transaction, so a restart mid-run
+// resumes with the accounts not yet credited for the period.
+type InterestAccruer struct {
+	ledger      *LedgerService
+	accountRepo account.Store
+	period      AccrualPeriod
+	dayCount    DayCount
+	batchSize   int
+
+	// lastDone is the start of the last period fully credited by this process
+	lastDone time.Time
+}
+
+// NewInterestAccruer creates an accruer that credits every period using the
+// dayCount convention
+func NewInterestAccruer(ledger *LedgerService, accountRepo account.Store, period AccrualPeriod, dayCount DayCount) *InterestAccruer {
+	return &InterestAccruer{
+		ledger:      ledger,
+		accountRepo: accountRepo,
+		period:      period,
+		dayCount:    dayCount,
+		batchSize:   500,
+	}
+}
+
+// Run credits any completed period not yet accrued immediately, then checks
+// for a new one every accrualCheckInterval, until ctx is cancelled
+func (a *InterestAccruer) Run(ctx context.Context) {
+	a.accrue(ctx)
+
+	ticker := time.NewTicker(accrualCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.accrue(ctx)
+		}
+	}
+}
+
+// accrue credits the most recently completed period to every eligible account
+func (a *InterestAccruer) accrue(ctx context.Context) {
+	start, end := a.period.lastCompleted(time.Now())
+	if start.Equal(a.lastDone) {
+		return
+	}
+	interestRuns.Add(1)
+
+	prefix := interestReferencePrefix(start)
+	afterID := ""
+	credited, failed := 0, 0
+	for {
+		accounts, err := a.accountRepo.GetInterestBearingAccountsAfter(ctx, afterID, prefix, a.batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Interest accrual for %s failed: %v", start.Format("2006-01-02"), err)
+			}
+			return
+		}
+
+		for _, acc := range accounts {
+			t, err := a.ledger.AccrueInterest(ctx, acc.ID, start, end, a.dayCount)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failed++
+				log.Printf("Interest accrual for account %s failed: %v", acc.ID, err)
+				continue
+			}
+			if t != nil {
+				credited++
+				interestAccruals.Add(1)
+			}
+		}
+
+		if len(accounts) < a.batchSize {
+			break
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+
+	if credited > 0 || failed > 0 {
+		log.Printf("Interest accrual for %s: %d accounts credited, %d failed", start.Format("2006-01-02"), credited, failed)
+	}
+	// Failed accounts are retried on the next check
+	if failed == 0 {
+		a.lastDone = start
+	}
+}
@@ -1,257 +1,2789 @@
-package service
-
-import (
-	"context"
-	"fmt"
-	"time"
-
-	"apex-ledger/internal/account"
-
-	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
-)
-
-// LedgerService handles business logic for ledger operations
-type LedgerService struct {
-	accountRepo *account.Repository
-	db          *sqlx.DB
-}
-
-// NewLedgerService creates a new ledger service
-func NewLedgerService(accountRepo *account.Repository, db *sqlx.DB) *LedgerService {
-	return &LedgerService{
-		accountRepo: accountRepo,
-		db:          db,
-	}
-}
-
-// PerformTransfer executes a double-entry transfer between two accounts
-func (s *LedgerService) PerformTransfer(ctx context.Context, fromID, toID string, amount int64) (string, error) {
-	// Validate inputs
-	if fromID == "" || toID == "" {
-		return "", fmt.Errorf("account IDs cannot be empty")
-	}
-	if fromID == toID {
-		return "", fmt.Errorf("cannot transfer to the same account")
-	}
-	if amount <= 0 {
-		return "", fmt.Errorf("amount must be positive")
-	}
-
-	// Generate transaction ID
-	txID := uuid.New().String()
-
-	// Start transaction
-	tx, err := s.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	// Lock both accounts in sorted order to prevent deadlocks
-	// Always lock in alphabetical order
-	var fromAcc, toAcc *account.Account
-	if fromID < toID {
-		fromAcc, err = s.accountRepo.GetAccountWithLock(ctx, tx, fromID)
-		if err != nil {
-			return "", err
-		}
-		toAcc, err = s.accountRepo.GetAccountWithLock(ctx, tx, toID)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		toAcc, err = s.accountRepo.GetAccountWithLock(ctx, tx, toID)
-		if err != nil {
-			return "", err
-		}
-		fromAcc, err = s.accountRepo.GetAccountWithLock(ctx, tx, fromID)
-		if err != nil {
-			return "", err
-		}
-	}
-
-	// Check currency match
-	if fromAcc.Currency != toAcc.Currency {
-		return "", fmt.Errorf("currency mismatch: %s != %s", fromAcc.Currency, toAcc.Currency)
-	}
-
-	// Check sufficient funds
-	if fromAcc.BalanceCents < amount {
-		return "", fmt.Errorf("insufficient funds in account %s: balance %d, required %d", fromID, fromAcc.BalanceCents, amount)
-	}
-
-	// Perform double-entry updates
-	if err := s.accountRepo.UpdateBalance(ctx, tx, fromID, -amount); err != nil {
-		return "", fmt.Errorf("failed to debit account %s: %w", fromID, err)
-	}
-
-	if err := s.accountRepo.UpdateBalance(ctx, tx, toID, amount); err != nil {
-		return "", fmt.Errorf("failed to credit account %s: %w", toID, err)
-	}
-
-	// Record transaction in ledger (optional but recommended)
-	if err := s.recordTransaction(ctx, tx, txID, fromID, toID, amount, fromAcc.Currency); err != nil {
-		return "", fmt.Errorf("failed to record transaction: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return txID, nil
-}
-
-// GetBalance retrieves the current balance of an account
-func (s *LedgerService) GetBalance(ctx context.Context, accountID string) (*account.Account, error) {
-	if accountID == "" {
-		return nil, fmt.Errorf("account ID cannot be empty")
-	}
-
-	acc, err := s.accountRepo.GetAccount(ctx, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return acc, nil
-}
-
-// CreateAccount creates a new account
-func (s *LedgerService) CreateAccount(ctx context.Context, id string, balanceCents int64, currency string) (*account.Account, error) {
-	// Validate inputs
-	if currency == "" {
-		return nil, fmt.Errorf("currency is required")
-	}
-	if len(currency) > 10 {
-		return nil, fmt.Errorf("currency code must be 10 characters or less")
-	}
-	if balanceCents < 0 {
-		return nil, fmt.Errorf("initial balance cannot be negative")
-	}
-
-	// Generate ID if not provided
-	if id == "" {
-		id = uuid.New().String()
-	}
-
-	// Create account
-	acc := &account.Account{
-		ID:           id,
-		BalanceCents: balanceCents,
-		Currency:     currency,
-	}
-
-	if err := s.accountRepo.CreateAccount(ctx, acc); err != nil {
-		return nil, fmt.Errorf("failed to create account: %w", err)
-	}
-
-	// Fetch the created account to get timestamps
-	createdAcc, err := s.accountRepo.GetAccount(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch created account: %w", err)
-	}
-
-	return createdAcc, nil
-}
-
-// GetAccount retrieves full account details
-func (s *LedgerService) GetAccount(ctx context.Context, accountID string) (*account.Account, error) {
-	if accountID == "" {
-		return nil, fmt.Errorf("account ID cannot be empty")
-	}
-
-	acc, err := s.accountRepo.GetAccount(ctx, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	return acc, nil
-}
-
-// UpdateAccount updates account currency
-func (s *LedgerService) UpdateAccount(ctx context.Context, accountID string, currency string) (*account.Account, error) {
-	if accountID == "" {
-		return nil, fmt.Errorf("account ID cannot be empty")
-	}
-	if currency == "" {
-		return nil, fmt.Errorf("currency is required")
-	}
-	if len(currency) > 10 {
-		return nil, fmt.Errorf("currency code must be 10 characters or less")
-	}
-
-	// Check if account exists
-	_, err := s.accountRepo.GetAccount(ctx, accountID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Update account
-	if err := s.accountRepo.UpdateAccount(ctx, accountID, currency); err != nil {
-		return nil, fmt.Errorf("failed to update account: %w", err)
-	}
-
-	// Fetch updated account
-	updatedAcc, err := s.accountRepo.GetAccount(ctx, accountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch updated account: %w", err)
-	}
-
-	return updatedAcc, nil
-}
-
-// DeleteAccount deletes an account
-func (s *LedgerService) DeleteAccount(ctx context.Context, accountID string) error {
-	if accountID == "" {
-		return fmt.Errorf("account ID cannot be empty")
-	}
-
-	// Check if account exists
-	_, err := s.accountRepo.GetAccount(ctx, accountID)
-	if err != nil {
-		return err
-	}
-
-	// Delete account
-	if err := s.accountRepo.DeleteAccount(ctx, accountID); err != nil {
-		return fmt.Errorf("failed to delete account: %w", err)
-	}
-
-	return nil
-}
-
-// ListAccounts retrieves all accounts with pagination
-func (s *LedgerService) ListAccounts(ctx context.Context, limit, offset int) ([]account.Account, int, error) {
-	if limit <= 0 {
-		limit = 100 // Default limit
-	}
-	if limit > 1000 {
-		limit = 1000 // Max limit
-	}
-	if offset < 0 {
-		offset = 0
-	}
-
-	accounts, err := s.accountRepo.GetAllAccounts(ctx, limit, offset)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list accounts: %w", err)
-	}
-
-	total, err := s.accountRepo.GetAccountCount(ctx)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get account count: %w", err)
-	}
-
-	return accounts, total, nil
-}
-
-// recordTransaction records the transfer in the transactions table
-func (s *LedgerService) recordTransaction(ctx context.Context, tx *sqlx.Tx, txID, fromID, toID string, amount int64, currency string) error {
-	query := `
-		INSERT INTO transactions (id, from_account_id, to_account_id, amount_cents, currency, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-	`
-	_, err := tx.ExecContext(ctx, query, txID, fromID, toID, amount, currency, time.Now())
-	return err
-}
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/auth"
+	"apex-ledger/internal/platform/database"
+	"apex-ledger/internal/platform/metrics"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LedgerService handles business logic for ledger operations
+type LedgerService struct {
+	accountRepo account.Store
+	db          *sqlx.DB
+	notifier    notificationQueue
+	cache       *balanceCache
+	ids         IDGenerator
+	lockTimeout time.Duration
+	locking     LockStrategy
+	isolation   sql.IsolationLevel
+	events      *eventBus
+	slow        *metrics.SlowLog
+
+	// acquireTimeout bounds the wait for a pooled connection; 0 waits as
+	// long as the request's deadline allows
+	acquireTimeout time.Duration
+
+	// defaultCurrency is used for new accounts that don't specify one
+	defaultCurrency string
+	// currencies restricts the currencies accounts may hold; nil allows any
+	currencies []account.Currency
+	// stripes serializes transfers on the same accounts within this process
+	// ahead of the database locks; nil disables it
+	stripes *accountStripes
+
+	// maxAccountsPerOwner caps how many accounts a non-admin owner can
+	// hold; 0 is unlimited
+	maxAccountsPerOwner int
+	// initialStatus is the status new accounts start in
+	initialStatus string
+
+	// accountIDPattern must match every client-supplied account ID
+	accountIDPattern *regexp.Regexp
+	// maxPageSize caps every page read by the listing methods
+	maxPageSize int
+	// selfTransfers records a transfer from an account to itself as a
+	// no-op trace instead of rejecting it
+	selfTransfers bool
+	// transferRetries is how many times a transfer that deadlocked or hit a
+	// serialization failure is retried before the error is returned
+	transferRetries int
+
+	// denominations maps a currency to the step, in cents, its transfer
+	// amounts must be a multiple of; currencies not listed are unrestricted
+	denominations map[string]int64
+	// categories restricts transfer categories; nil allows any up to
+	// maxCategoryLength
+	categories map[string]bool
+
+	// Cross-currency support; rates is nil when FX is disabled
+	rates    ExchangeRateProvider
+	quotes   *quoteStore
+	quoteTTL time.Duration
+	rounding RoundingMode
+}
+
+// Option configures optional LedgerService behaviour
+type Option func(*LedgerService)
+
+// WithBalanceCache enables an in-memory GetBalance cache whose entries live
+// for at most ttl
+func WithBalanceCache(ttl time.Duration) Option {
+	return func(s *LedgerService) {
+		s.cache = newBalanceCache(ttl)
+	}
+}
+
+// WithExchangeRates enables cross-currency transfers priced by rates.
+// Conversion quotes stay valid for quoteTTL.
+func WithExchangeRates(rates ExchangeRateProvider, quoteTTL time.Duration) Option {
+	return func(s *LedgerService) {
+		s.rates = rates
+		s.quotes = newQuoteStore()
+		s.quoteTTL = quoteTTL
+	}
+}
+
+// WithIDGenerator replaces the default UUIDv4 generator used for new
+// account, transaction and quote IDs
+func WithIDGenerator(g IDGenerator) Option {
+	return func(s *LedgerService) {
+		s.ids = g
+	}
+}
+
+// WithLockTimeout bounds how long a transfer waits for another transaction's
+// row locks before failing; zero waits indefinitely
+func WithLockTimeout(d time.Duration) Option {
+	return func(s *LedgerService) {
+		s.lockTimeout = d
+	}
+}
+
+// WithAcquireTimeout makes operations fail with database.ErrPoolExhausted
+// when no database connection frees up within d, instead of queueing until
+// their deadline; zero waits indefinitely
+func WithAcquireTimeout(d time.Duration) Option {
+	return func(s *LedgerService) {
+		s.acquireTimeout = d
+	}
+}
+
+// WithSupportedCurrencies restricts new and updated accounts to currencies,
+// which ListCurrencies then reports instead of the whole ISO 4217 table
+func WithSupportedCurrencies(currencies []account.Currency) Option {
+	return func(s *LedgerService) {
+		s.currencies = currencies
+	}
+}
+
+// ListCurrencies returns the currencies accounts may be created in
+func (s *LedgerService) ListCurrencies() []account.Currency {
+	if s.currencies == nil {
+		return account.ISOCurrencies()
+	}
+	return s.currencies
+}
+
+// checkCurrency rejects a currency outside the supported set
+func (s *LedgerService) checkCurrency(code string) error {
+	if s.currencies == nil {
+		return nil
+	}
+	for _, c := range s.currencies {
+		if c.Code == code {
+			return nil
+		}
+	}
+	return fmt.Errorf("currency %s is not supported", code)
+}
+
+// WithSlowLog reports transfers slower than slow's threshold
+func WithSlowLog(slow *metrics.SlowLog) Option {
+	return func(s *LedgerService) {
+		s.slow = slow
+	}
+}
+
+// WithDefaultCurrency makes accounts created without a currency use code
+func WithDefaultCurrency(code string) Option {
+	return func(s *LedgerService) {
+		s.defaultCurrency = code
+	}
+}
+
+// WithAccountLockStripes queues transfers touching the same accounts in
+// process, on n striped locks, before they contend for database locks. Zero
+// disables striping.
+func WithAccountLockStripes(n int) Option {
+	return func(s *LedgerService) {
+		if n > 0 {
+			s.stripes = newAccountStripes(n)
+		}
+	}
+}
+
+// WithMaxAccountsPerOwner caps the accounts each owner can hold at n;
+// admins may create accounts past it
+func WithMaxAccountsPerOwner(n int) Option {
+	return func(s *LedgerService) {
+		s.maxAccountsPerOwner = n
+	}
+}
+
+// WithInitialAccountStatus sets the status new accounts start in;
+// account.AccountStatusPending keeps them from transferring until activated
+func WithInitialAccountStatus(status string) Option {
+	return func(s *LedgerService) {
+		s.initialStatus = status
+	}
+}
+
+// WithDenominations restricts transfer amounts in each listed currency to
+// multiples of its step in cents, e.g. {"JPY": 100}
+func WithDenominations(steps map[string]int) Option {
+	return func(s *LedgerService) {
+		s.denominations = make(map[string]int64, len(steps))
+		for currency, step := range steps {
+			s.denominations[currency] = int64(step)
+		}
+	}
+}
+
+// maxCategoryLength is the longest transfer category, the column's size
+const maxCategoryLength = 64
+
+// WithTransactionCategories restricts transfer categories to the given
+// set; without it any category up to maxCategoryLength is accepted
+func WithTransactionCategories(categories []string) Option {
+	return func(s *LedgerService) {
+		s.categories = make(map[string]bool, len(categories))
+		for _, c := range categories {
+			s.categories[strings.ToLower(strings.TrimSpace(c))] = true
+		}
+	}
+}
+
+// WithTransferRetries retries a transfer, or any other call that moves
+// money, up to n times, with jittered backoff, when Postgres aborts it for
+// a deadlock (40P01) or serialization failure (40001). The default is 0:
+// the error goes straight to the client.
+func WithTransferRetries(n int) Option {
+	return func(s *LedgerService) {
+		s.transferRetries = n
+	}
+}
+
+// WithSelfTransfers accepts transfers from an account to itself, recording
+// them as self_transfer transactions that leave the balance unchanged, for
+// tracing and testing workflows. Without it they are rejected.
+func WithSelfTransfers() Option {
+	return func(s *LedgerService) {
+		s.selfTransfers = true
+	}
+}
+
+// WithMaxPageSize caps the rows any listing method reads per page, exports
+// included (default account.DefaultMaxPageSize)
+func WithMaxPageSize(n int) Option {
+	return func(s *LedgerService) {
+		s.maxPageSize = n
+	}
+}
+
+// DefaultAccountIDPattern accepts letters, digits, '-' and '_' up to 64
+// characters, which covers generated UUIDs and IDs like "account-001"
+const DefaultAccountIDPattern = `[A-Za-z0-9_-]{1,64}`
+
+// CompileAccountIDPattern compiles an account ID pattern from configuration.
+// The pattern must match the whole ID, so it is anchored at both ends.
+func CompileAccountIDPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// WithAccountIDPattern replaces DefaultAccountIDPattern as the check applied
+// to client-supplied account IDs; see CompileAccountIDPattern
+func WithAccountIDPattern(re *regexp.Regexp) Option {
+	return func(s *LedgerService) {
+		s.accountIDPattern = re
+	}
+}
+
+// WithIsolation sets the isolation level of transactions that move money;
+// the default uses the database's own default (read committed on Postgres)
+func WithIsolation(level sql.IsolationLevel) Option {
+	return func(s *LedgerService) {
+		s.isolation = level
+	}
+}
+
+// LockStrategy is how changes to an account serialize against each other.
+// Every operation that changes an existing account takes it through the
+// strategy, so a transfer never sees a balance, status or currency change
+// under it; only creating an account locks nothing, as no one else can see
+// the row before it commits.
+type LockStrategy int
+
+const (
+	// LockRow reads accounts with SELECT FOR UPDATE, holding row locks
+	// for the rest of the transaction
+	LockRow LockStrategy = iota
+	// LockAdvisory takes a transaction-scoped advisory lock per account,
+	// then reads the rows without locking them
+	LockAdvisory
+	// LockOptimistic takes no locks up front and runs at repeatable read
+	// or stricter, so of two concurrent changes to the same account one
+	// aborts with a serialization failure for the client to retry
+	LockOptimistic
+)
+
+// ParseLockStrategy maps a locking strategy name from configuration to its value
+func ParseLockStrategy(name string) (LockStrategy, error) {
+	switch name {
+	case "row":
+		return LockRow, nil
+	case "advisory":
+		return LockAdvisory, nil
+	case "optimistic":
+		return LockOptimistic, nil
+	}
+	return 0, fmt.Errorf("unknown locking strategy %q", name)
+}
+
+// WithLockStrategy selects how accounts are locked; the default is LockRow
+func WithLockStrategy(strategy LockStrategy) Option {
+	return func(s *LedgerService) {
+		s.locking = strategy
+	}
+}
+
+// WithFXRounding selects how cross-currency transfers round converted
+// amounts; the default is RoundHalfUp
+func WithFXRounding(mode RoundingMode) Option {
+	return func(s *LedgerService) {
+		s.rounding = mode
+	}
+}
+
+// ParseIsolation maps an isolation name from configuration to its level
+func ParseIsolation(name string) (sql.IsolationLevel, error) {
+	switch name {
+	case "", "default":
+		return sql.LevelDefault, nil
+	case "read_committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable_read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	}
+	return sql.LevelDefault, fmt.Errorf("unknown isolation level %q", name)
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(accountRepo account.Store, db *sqlx.DB, notifier *account.NotificationWorkerPool, opts ...Option) *LedgerService {
+	s := &LedgerService{
+		accountRepo: accountRepo,
+		db:          db,
+		ids:         UUIDv4Generator{},
+		events:      newEventBus(),
+		rounding:    RoundHalfUp,
+
+		accountIDPattern: regexp.MustCompile(`^(?:` + DefaultAccountIDPattern + `)$`),
+		maxPageSize:      account.DefaultMaxPageSize,
+	}
+	if notifier != nil {
+		s.notifier = notifier
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// notificationQueue is the part of account.NotificationWorkerPool the
+// service uses
+type notificationQueue interface {
+	Enqueue(n account.Notification)
+	Stop(ctx context.Context) error
+}
+
+// Close stops the background work owned by the service, draining any
+// queued notifications and transfer events. It must be called before the DB handle is closed.
+func (s *LedgerService) Close(ctx context.Context) error {
+	if err := s.events.close(ctx); err != nil {
+		return fmt.Errorf("failed to drain transfer events: %w", err)
+	}
+	if s.notifier != nil {
+		if err := s.notifier.Stop(ctx); err != nil {
+			return fmt.Errorf("failed to drain notification queue: %w", err)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers fn to receive every committed transfer, including batch,
+// cross-currency and reversal transfers and single-account movements such as
+// deposits and adjustments. Each subscriber has its own buffered
+// queue, so a slow one never delays a commit; the returned function
+// unsubscribes.
+func (s *LedgerService) Subscribe(fn TransferSubscriber) (unsubscribe func()) {
+	return s.events.subscribe(fn)
+}
+
+// PerformTransfer executes a double-entry transfer between two accounts
+func (s *LedgerService) PerformTransfer(ctx context.Context, fromID, toID string, amount int64) (string, error) {
+	txID, _, err := s.PerformTransferWithOptions(ctx, fromID, toID, amount, account.TransferOptions{})
+	return txID, err
+}
+
+// PerformTransferWithOptions is PerformTransfer with its optional parts.
+// With opts.ExpectedFromSeq set it is a compare-and-swap on the sending
+// account: the transfer only applies if the account's sequence
+// (Account.EventSeq, advanced by every change to its balance) still equals
+// it, otherwise it fails with account.ErrSequenceMismatch. opts.Category is
+// recorded on the transaction. It returns the transaction ID and the
+// sending account's sequence after the transfer, which is what the
+// client's next transfer should expect.
+func (s *LedgerService) PerformTransferWithOptions(ctx context.Context, fromID, toID string, amount int64, opts account.TransferOptions) (_ string, fromSeq int64, err error) {
+	defer s.slow.Observe("PerformTransfer", time.Now(), fromID, toID)
+	defer s.auditFailure(ctx, AuditTransfer, &err, fromID, toID)
+	// Validate inputs
+	if fromID == "" || toID == "" {
+		return "", 0, fmt.Errorf("account IDs cannot be empty")
+	}
+	if fromID == toID && !s.selfTransfers {
+		return "", 0, fmt.Errorf("cannot transfer to the same account")
+	}
+	if err := checkAmount(amount); err != nil {
+		return "", 0, err
+	}
+	category, err := s.checkCategory(opts.Category)
+	if err != nil {
+		return "", 0, err
+	}
+	if fromID == toID {
+		return s.selfTransfer(ctx, fromID, amount, category, opts)
+	}
+
+	// Generate transaction ID
+	txID := s.ids.NewID()
+
+	unlock, err := s.lockStripes(ctx, fromID, toID)
+	if err != nil {
+		return "", 0, err
+	}
+	defer unlock()
+
+	// Run in the request-scoped transaction, joining the caller's if any
+	transfer := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		// Lock both accounts in sorted order to prevent deadlocks
+		accs, err := s.lockAccountsInOrder(ctx, tx, fromID, toID)
+		if err != nil {
+			return err
+		}
+		fromAcc, toAcc := accs[0], accs[1]
+		if err := checkActive(fromAcc, toAcc); err != nil {
+			return err
+		}
+		// The read above holds the account (or, with optimistic locking,
+		// its snapshot), so the sequence can't move before the debit
+		if expected := opts.ExpectedFromSeq; expected != nil && fromAcc.EventSeq != *expected {
+			return fmt.Errorf("account %s is at sequence %d, expected %d: %w",
+				fromID, fromAcc.EventSeq, *expected, account.ErrSequenceMismatch)
+		}
+		if err := checkCurrencyAssertion(fromAcc, opts.FromCurrency); err != nil {
+			return err
+		}
+		if err := checkCurrencyAssertion(toAcc, opts.ToCurrency); err != nil {
+			return err
+		}
+
+		// Check currency match
+		if !balanceOf(fromAcc).IsSameCurrency(balanceOf(toAcc)) {
+			return &account.CurrencyMismatchError{FromCurrency: fromAcc.Currency, ToCurrency: toAcc.Currency}
+		}
+		if err := s.checkDenomination(fromAcc.Currency, amount); err != nil {
+			return err
+		}
+
+		// Check sufficient funds, including any overdraft
+		if err := checkFunds(fromAcc, Money{Cents: amount, Currency: fromAcc.Currency}); err != nil {
+			return err
+		}
+
+		// Perform double-entry updates
+		fromBalance, err := s.accountRepo.Debit(ctx, tx, fromID, amount)
+		if err != nil {
+			return err
+		}
+
+		toBalance, err := s.accountRepo.Credit(ctx, tx, toID, amount)
+		if err != nil {
+			return err
+		}
+		if err := checkBalanceSigns(fromAcc, fromBalance, toAcc, toBalance); err != nil {
+			return err
+		}
+
+		// Record transaction in ledger (optional but recommended)
+		record := &account.Transaction{
+			ID:               txID,
+			FromAccountID:    fromID,
+			ToAccountID:      toID,
+			AmountCents:      amount,
+			Currency:         fromAcc.Currency,
+			Kind:             account.TransactionKindTransfer,
+			FromBalanceAfter: &fromBalance,
+			ToBalanceAfter:   &toBalance,
+			Category:         category,
+			ActorID:          auditActor(ctx),
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, record); err != nil {
+			return err
+		}
+		// Recording the transfer journaled one event for the sender
+		fromSeq = fromAcc.EventSeq + 1
+		if err := s.audit(ctx, tx, AuditTransfer, txID, fromID, toID); err != nil {
+			return err
+		}
+
+		// Notify both parties once the money has actually moved
+		afterCommit(ctx, func() {
+			s.invalidate(fromID, toID)
+			s.notifyTransfer(ctx, txID, fromAcc, toAcc, amount, fromAcc.Currency)
+			s.publishTransfer(record)
+		})
+		return nil
+	}
+	// Sorted locking still leaves deadlocks possible against batches and
+	// other writers, so a transfer Postgres aborts is retried whole. The
+	// transaction ID is reused: a failed attempt rolled back everything it
+	// recorded, so only the attempt that commits leaves a trace.
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, transfer)
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	return txID, fromSeq, nil
+}
+
+// selfTransfer records a transfer from an account to itself without
+// touching its balance. The row is a self_transfer, not a transfer, so it
+// can't be reversed and isn't counted with real transfers; no notification
+// or transfer event is sent for it.
+func (s *LedgerService) selfTransfer(ctx context.Context, accountID string, amount int64, category string, opts account.TransferOptions) (txID string, seq int64, err error) {
+	txID = s.ids.NewID()
+
+	unlock, err := s.lockStripes(ctx, accountID)
+	if err != nil {
+		return "", 0, err
+	}
+	defer unlock()
+
+	err = s.WithTx(ctx, func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		accs, err := s.lockAccountsInOrder(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+		acc := accs[0]
+		if err := checkActive(acc); err != nil {
+			return err
+		}
+		if expected := opts.ExpectedFromSeq; expected != nil && acc.EventSeq != *expected {
+			return fmt.Errorf("account %s is at sequence %d, expected %d: %w",
+				accountID, acc.EventSeq, *expected, account.ErrSequenceMismatch)
+		}
+		if err := checkCurrencyAssertion(acc, opts.FromCurrency); err != nil {
+			return err
+		}
+		if err := checkCurrencyAssertion(acc, opts.ToCurrency); err != nil {
+			return err
+		}
+
+		balance := acc.BalanceCents
+		record := &account.Transaction{
+			ID:               txID,
+			FromAccountID:    accountID,
+			ToAccountID:      accountID,
+			AmountCents:      amount,
+			Currency:         acc.Currency,
+			Kind:             account.TransactionKindSelfTransfer,
+			FromBalanceAfter: &balance,
+			ToBalanceAfter:   &balance,
+			Category:         category,
+			ActorID:          auditActor(ctx),
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, record); err != nil {
+			return err
+		}
+		seq = acc.EventSeq + 1
+		if err := s.audit(ctx, tx, AuditTransfer, txID, accountID); err != nil {
+			return err
+		}
+		afterCommit(ctx, func() {
+			s.invalidate(accountID)
+		})
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return txID, seq, nil
+}
+
+// GetConversionQuote prices converting amount from one currency to another and
+// stores the result as a quote that CrossCurrencyTransfer can redeem until it
+// expires
+func (s *LedgerService) GetConversionQuote(ctx context.Context, fromCurrency, toCurrency string, amount int64) (*account.ConversionQuote, error) {
+	if s.rates == nil {
+		return nil, account.ErrFXDisabled
+	}
+	if fromCurrency == "" || toCurrency == "" {
+		return nil, fmt.Errorf("currencies cannot be empty")
+	}
+	if fromCurrency == toCurrency {
+		return nil, fmt.Errorf("currencies must differ")
+	}
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+
+	rate, err := s.rates.Rate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	converted, _, err := convertAmount(amount, rate, s.rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	q := account.ConversionQuote{
+		ID:             s.ids.NewID(),
+		FromCurrency:   fromCurrency,
+		ToCurrency:     toCurrency,
+		AmountCents:    amount,
+		ConvertedCents: converted,
+		Rate:           rate,
+		ExpiresAt:      time.Now().Add(s.quoteTTL),
+	}
+	s.quotes.put(q)
+	return &q, nil
+}
+
+// CrossCurrencyTransfer moves amount (in the sender's currency) to an account
+// in a different currency, crediting the converted amount. If quoteID is set
+// its rate is used, otherwise the provider's current rate is.
+func (s *LedgerService) CrossCurrencyTransfer(ctx context.Context, fromID, toID string, amount int64, quoteID string) (_ *account.Transaction, err error) {
+	defer s.slow.Observe("CrossCurrencyTransfer", time.Now(), fromID, toID)
+	defer s.auditFailure(ctx, AuditCrossCurrencyTransfer, &err, fromID, toID)
+	if s.rates == nil {
+		return nil, account.ErrFXDisabled
+	}
+	if fromID == "" || toID == "" {
+		return nil, fmt.Errorf("account IDs cannot be empty")
+	}
+	if fromID == toID {
+		return nil, fmt.Errorf("cannot transfer to the same account")
+	}
+	if err := checkAmount(amount); err != nil {
+		return nil, err
+	}
+
+	var quote *account.ConversionQuote
+	if quoteID != "" {
+		q, ok := s.quotes.get(quoteID)
+		if !ok {
+			return nil, account.ErrQuoteNotFound
+		}
+		if time.Now().After(q.ExpiresAt) {
+			return nil, account.ErrQuoteExpired
+		}
+		quote = &q
+	}
+
+	txID := s.ids.NewID()
+
+	unlock, err := s.lockStripes(ctx, fromID, toID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var record *account.Transaction
+	transfer := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		accs, err := s.lockAccountsInOrder(ctx, tx, fromID, toID)
+		if err != nil {
+			return err
+		}
+		fromAcc, toAcc := accs[0], accs[1]
+		if err := checkActive(fromAcc, toAcc); err != nil {
+			return err
+		}
+		if fromAcc.Currency == toAcc.Currency {
+			return fmt.Errorf("accounts share currency %s; use Transfer", fromAcc.Currency)
+		}
+
+		var rate float64
+		if quote != nil {
+			if quote.FromCurrency != fromAcc.Currency || quote.ToCurrency != toAcc.Currency {
+				return account.ErrQuoteMismatch
+			}
+			rate = quote.Rate
+		} else {
+			rate, err = s.rates.Rate(ctx, fromAcc.Currency, toAcc.Currency)
+			if err != nil {
+				return fmt.Errorf("failed to get exchange rate: %w", err)
+			}
+		}
+		converted, adjustment, err := convertAmount(amount, rate, s.rounding)
+		if err != nil {
+			return err
+		}
+		if converted <= 0 {
+			return fmt.Errorf("amount %d %s converts to nothing at rate %g", amount, fromAcc.Currency, rate)
+		}
+
+		if err := checkFunds(fromAcc, Money{Cents: amount, Currency: fromAcc.Currency}); err != nil {
+			return err
+		}
+
+		fromBalance, err := s.accountRepo.Debit(ctx, tx, fromID, amount)
+		if err != nil {
+			return err
+		}
+		toBalance, err := s.accountRepo.Credit(ctx, tx, toID, converted)
+		if err != nil {
+			return err
+		}
+		if err := checkBalanceSigns(fromAcc, fromBalance, toAcc, toBalance); err != nil {
+			return err
+		}
+
+		record = &account.Transaction{
+			ID:                   txID,
+			FromAccountID:        fromID,
+			ToAccountID:          toID,
+			AmountCents:          amount,
+			Currency:             fromAcc.Currency,
+			Kind:                 account.TransactionKindTransfer,
+			FromBalanceAfter:     &fromBalance,
+			ToBalanceAfter:       &toBalance,
+			ConvertedAmountCents: &converted,
+			ConvertedCurrency:    toAcc.Currency,
+			ExchangeRate:         &rate,
+			FXRounding:           string(s.rounding),
+			FXRoundingAdjustment: &adjustment,
+			ActorID:              auditActor(ctx),
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, record); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, tx, AuditCrossCurrencyTransfer, txID, fromID, toID); err != nil {
+			return err
+		}
+
+		afterCommit(ctx, func() {
+			s.invalidate(fromID, toID)
+			s.notify(txID, account.Notification{
+				ID:        txID + ":debit",
+				AccountID: fromID,
+				Channel:   fromAcc.NotificationChannel,
+				ActorID:   auditActor(ctx),
+				Message:   fmt.Sprintf("Debited %d %s (transaction %s)", amount, fromAcc.Currency, txID),
+			}, account.Notification{
+				ID:        txID + ":credit",
+				AccountID: toID,
+				Channel:   toAcc.NotificationChannel,
+				ActorID:   auditActor(ctx),
+				Message:   fmt.Sprintf("Credited %d %s (transaction %s)", converted, toAcc.Currency, txID),
+			})
+			s.publishTransfer(record)
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, transfer)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// BatchTransfer executes several transfers atomically: either every entry is
+// applied or none is. Each account is locked once, in sorted order, however
+// many entries it appears in, and its balance is updated once by the net of
+// its entries. Funds are checked against that net, so an account may pass on
+// money it receives earlier in the same batch.
+func (s *LedgerService) BatchTransfer(ctx context.Context, entries []account.TransferEntry) (_ []string, err error) {
+	defer s.slow.Observe("BatchTransfer", time.Now())
+	defer s.auditFailure(ctx, AuditBatchTransfer, &err, batchAccountIDs(entries)...)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("batch must contain at least one transfer")
+	}
+
+	// Normalise a copy, leaving the caller's entries as they were
+	entries = slices.Clone(entries)
+
+	// Validate entries and aggregate the net effect on each account
+	net := make(map[string]int64)
+	for i, e := range entries {
+		if e.FromID == "" || e.ToID == "" {
+			return nil, fmt.Errorf("transfer %d: account IDs cannot be empty", i)
+		}
+		if e.FromID == e.ToID {
+			return nil, fmt.Errorf("transfer %d: cannot transfer to the same account", i)
+		}
+		if err := checkAmount(e.AmountCents); err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", i, err)
+		}
+		category, err := s.checkCategory(e.Category)
+		if err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", i, err)
+		}
+		entries[i].Category = category
+		if net[e.FromID], err = checkedSub(net[e.FromID], e.AmountCents); err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", i, err)
+		}
+		if net[e.ToID], err = checkedAdd(net[e.ToID], e.AmountCents); err != nil {
+			return nil, fmt.Errorf("transfer %d: %w", i, err)
+		}
+	}
+
+	ids := make([]string, 0, len(net))
+	for id := range net {
+		ids = append(ids, id)
+	}
+
+	unlock, err := s.lockStripes(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// IDs are generated once so that a retried attempt records the same ones
+	txIDs := make([]string, len(entries))
+	for i := range txIDs {
+		txIDs[i] = s.ids.NewID()
+	}
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	batch := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		// Lock every distinct account once, in sorted order to prevent deadlocks
+		locked, err := s.lockAccountsInOrder(ctx, tx, ids...)
+		if err != nil {
+			return err
+		}
+		if err := checkActive(locked...); err != nil {
+			return err
+		}
+		accs := make(map[string]*account.Account, len(ids))
+		for i, id := range ids {
+			accs[id] = locked[i]
+		}
+
+		// Replay the entries in order to get each one's balance snapshots
+		running := make(map[string]Money, len(ids))
+		for id, acc := range accs {
+			running[id] = balanceOf(acc)
+		}
+		records := make([]*account.Transaction, len(entries))
+		for i, e := range entries {
+			from, to := accs[e.FromID], accs[e.ToID]
+			if err := checkCurrencyAssertion(from, e.FromCurrency); err != nil {
+				return fmt.Errorf("transfer %d: %w", i, err)
+			}
+			if err := checkCurrencyAssertion(to, e.ToCurrency); err != nil {
+				return fmt.Errorf("transfer %d: %w", i, err)
+			}
+			if !running[e.FromID].IsSameCurrency(running[e.ToID]) {
+				return fmt.Errorf("transfer %d: %w", i, &account.CurrencyMismatchError{FromCurrency: from.Currency, ToCurrency: to.Currency})
+			}
+			if err := s.checkDenomination(from.Currency, e.AmountCents); err != nil {
+				return fmt.Errorf("transfer %d: %w", i, err)
+			}
+			amount := Money{Cents: e.AmountCents, Currency: from.Currency}
+			fromMoney, err := running[e.FromID].Sub(amount)
+			if err != nil {
+				return fmt.Errorf("transfer %d: %w", i, err)
+			}
+			toMoney, err := running[e.ToID].Add(amount)
+			if err != nil {
+				return fmt.Errorf("transfer %d: %w", i, err)
+			}
+			running[e.FromID], running[e.ToID] = fromMoney, toMoney
+			fromBalance, toBalance := fromMoney.Cents, toMoney.Cents
+			records[i] = &account.Transaction{
+				ID:               txIDs[i],
+				FromAccountID:    e.FromID,
+				ToAccountID:      e.ToID,
+				AmountCents:      e.AmountCents,
+				Currency:         from.Currency,
+				Kind:             account.TransactionKindTransfer,
+				FromBalanceAfter: &fromBalance,
+				ToBalanceAfter:   &toBalance,
+				Category:         e.Category,
+				ActorID:          auditActor(ctx),
+			}
+		}
+
+		// Apply each account's net change with a single update
+		for _, id := range ids {
+			acc := accs[id]
+			switch delta := net[id]; {
+			case delta < 0:
+				if err := checkFunds(acc, Money{Cents: -delta, Currency: acc.Currency}); err != nil {
+					return err
+				}
+				balance, err := s.accountRepo.Debit(ctx, tx, id, -delta)
+				if err != nil {
+					return err
+				}
+				if err := checkBalanceSign(acc, balance); err != nil {
+					return err
+				}
+			case delta > 0:
+				balance, err := s.accountRepo.Credit(ctx, tx, id, delta)
+				if err != nil {
+					return err
+				}
+				if err := checkBalanceSign(acc, balance); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, rec := range records {
+			if err := s.accountRepo.RecordTransaction(ctx, tx, rec); err != nil {
+				return err
+			}
+			if err := s.audit(ctx, tx, AuditBatchTransfer, rec.ID, rec.FromAccountID, rec.ToAccountID); err != nil {
+				return err
+			}
+		}
+
+		afterCommit(ctx, func() {
+			s.invalidate(ids...)
+			for _, rec := range records {
+				s.notifyTransfer(ctx, rec.ID, accs[rec.FromAccountID], accs[rec.ToAccountID], rec.AmountCents, rec.Currency)
+				s.publishTransfer(rec)
+			}
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return txIDs, nil
+}
+
+// AdjustBalance applies a signed correction to an account's balance and
+// records it as an adjustment transaction carrying the reason and the acting
+// user. A negative delta may not take the account past its overdraft limit.
+func (s *LedgerService) AdjustBalance(ctx context.Context, accountID string, deltaCents int64, reason, actorID string) (_ string, _ *account.Account, err error) {
+	defer s.slow.Observe("AdjustBalance", time.Now(), accountID)
+	defer s.auditFailure(ctx, AuditAdjustBalance, &err, accountID)
+	if accountID == "" {
+		return "", nil, fmt.Errorf("account ID cannot be empty")
+	}
+	if deltaCents == 0 {
+		return "", nil, fmt.Errorf("adjustment delta cannot be zero")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return "", nil, account.ErrReasonRequired
+	}
+
+	txID := s.ids.NewID()
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var updated *account.Account
+	adjust := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		// Lock once, so the funds and sign checks and the update all see
+		// the same row
+		accs, err := s.lockAccountsInOrder(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+		acc := accs[0]
+		entry, balance, err := s.applyAdjustment(ctx, tx, txID, acc, deltaCents, reason, actorID)
+		if err != nil {
+			return err
+		}
+		// Recording the adjustment journaled one event for the account
+		updated = acc
+		updated.BalanceCents = balance
+		updated.EventSeq++
+
+		afterCommit(ctx, func() {
+			s.invalidate(accountID)
+			s.publishTransfer(entry)
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, adjust)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return txID, updated, nil
+}
+
+// applyAdjustment applies deltaCents to acc, which the caller has locked,
+// and records the adjustment transaction and its audit entry within tx. It
+// returns the transaction and the account's new balance.
+func (s *LedgerService) applyAdjustment(ctx context.Context, tx *sqlx.Tx, txID string, acc *account.Account, deltaCents int64, reason, actorID string) (*account.Transaction, int64, error) {
+	accountID := acc.ID
+
+	entry := &account.Transaction{
+		ID:       txID,
+		Currency: acc.Currency,
+		Kind:     account.TransactionKindAdjustment,
+		Reason:   reason,
+		ActorID:  actorID,
+	}
+	var balance int64
+	var err error
+	if deltaCents < 0 {
+		amount := -deltaCents
+		if err := checkFunds(acc, Money{Cents: amount, Currency: acc.Currency}); err != nil {
+			return nil, 0, err
+		}
+		balance, err = s.accountRepo.Debit(ctx, tx, accountID, amount)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := checkBalanceSign(acc, balance); err != nil {
+			return nil, 0, err
+		}
+		entry.FromAccountID = accountID
+		entry.AmountCents = amount
+		entry.FromBalanceAfter = &balance
+	} else {
+		balance, err = s.accountRepo.Credit(ctx, tx, accountID, deltaCents)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := checkBalanceSign(acc, balance); err != nil {
+			return nil, 0, err
+		}
+		entry.ToAccountID = accountID
+		entry.AmountCents = deltaCents
+		entry.ToBalanceAfter = &balance
+	}
+
+	if err := s.accountRepo.RecordTransaction(ctx, tx, entry); err != nil {
+		return nil, 0, err
+	}
+	if err := s.audit(ctx, tx, AuditAdjustBalance, txID, accountID); err != nil {
+		return nil, 0, err
+	}
+	return entry, balance, nil
+}
+
+// lockAndAdjust locks adj's account and applies adj to it within tx
+func (s *LedgerService) lockAndAdjust(ctx context.Context, tx *sqlx.Tx, txID string, adj account.BalanceAdjustment, actorID string) (*account.Transaction, error) {
+	accs, err := s.lockAccountsInOrder(ctx, tx, adj.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	entry, _, err := s.applyAdjustment(ctx, tx, txID, accs[0], adj.DeltaCents, adj.Reason, actorID)
+	return entry, err
+}
+
+// BulkAdjustBalance applies a batch of balance adjustments in one
+// transaction, each like AdjustBalance. A record that can't be applied, such
+// as one naming a missing account, is rolled back to its savepoint and
+// reported in its result without affecting the others; only a failure of
+// the transaction itself fails the call. Records are applied in account ID
+// order, keeping each account's records in batch order, so the row locks
+// are taken in the same order as transfers take them. Every applied
+// adjustment is audited in the transaction and every failed one after it.
+func (s *LedgerService) BulkAdjustBalance(ctx context.Context, adjs []account.BalanceAdjustment, actorID string) (_ []account.AdjustmentResult, err error) {
+	defer s.slow.Observe("BulkAdjustBalance", time.Now())
+	defer s.auditFailure(ctx, AuditAdjustBalance, &err, adjustmentAccountIDs(adjs)...)
+
+	// IDs are generated once so that a retried attempt records the same ones
+	txIDs := make([]string, len(adjs))
+	for i := range txIDs {
+		txIDs[i] = s.ids.NewID()
+	}
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var results []account.AdjustmentResult
+	bulk := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		order := make([]int, len(adjs))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			return strings.Compare(adjs[a].AccountID, adjs[b].AccountID)
+		})
+
+		results = make([]account.AdjustmentResult, len(adjs))
+		var applied []*account.Transaction
+		for _, i := range order {
+			adj := adjs[i]
+			if err := validateAdjustment(adj); err != nil {
+				results[i].Err = err
+				continue
+			}
+
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_adjustment"); err != nil {
+				return fmt.Errorf("failed to create savepoint: %w", err)
+			}
+			entry, err := s.lockAndAdjust(ctx, tx, txIDs[i], adj, actorID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				// A conflict is the transaction's, not the record's, so the
+				// whole batch is retried rather than the record reported
+				if account.IsSerializationFailure(err) {
+					return err
+				}
+				results[i].Err = err
+				if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_adjustment"); err != nil {
+					return fmt.Errorf("failed to roll back to savepoint: %w", err)
+				}
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_adjustment"); err != nil {
+				return fmt.Errorf("failed to release savepoint: %w", err)
+			}
+			results[i].TransactionID = entry.ID
+			if entry.ToBalanceAfter != nil {
+				results[i].BalanceCents = *entry.ToBalanceAfter
+			} else {
+				results[i].BalanceCents = *entry.FromBalanceAfter
+			}
+			applied = append(applied, entry)
+		}
+
+		afterCommit(ctx, func() {
+			for _, entry := range applied {
+				s.invalidate(nonEmpty([]string{entry.FromAccountID, entry.ToAccountID})...)
+				s.publishTransfer(entry)
+			}
+			for i, r := range results {
+				if r.Err != nil {
+					ferr := r.Err
+					s.auditFailure(ctx, AuditAdjustBalance, &ferr, adjs[i].AccountID)
+				}
+			}
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, bulk)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// validateAdjustment checks a bulk adjustment record as AdjustBalance
+// checks its arguments
+func validateAdjustment(adj account.BalanceAdjustment) error {
+	if adj.AccountID == "" {
+		return fmt.Errorf("account ID cannot be empty")
+	}
+	if adj.DeltaCents == 0 {
+		return fmt.Errorf("adjustment delta cannot be zero")
+	}
+	if strings.TrimSpace(adj.Reason) == "" {
+		return account.ErrReasonRequired
+	}
+	return nil
+}
+
+// Deposit credits money arriving from outside the ledger. When ref is set it
+// is recorded under a unique constraint, so replaying a deposit (as
+// at-least-once webhooks do) returns the original transaction with duplicate
+// set instead of crediting twice. Inside a caller's WithTx a replay fails
+// with account.ErrDuplicateReference instead, as the failed insert has
+// aborted the caller's transaction.
+func (s *LedgerService) Deposit(ctx context.Context, accountID string, amountCents int64, currency, ref, actorID string) (t *account.Transaction, duplicate bool, err error) {
+	defer s.slow.Observe("Deposit", time.Now(), accountID)
+	defer s.auditFailure(ctx, AuditDeposit, &err, accountID)
+	if accountID == "" {
+		return nil, false, fmt.Errorf("account ID cannot be empty")
+	}
+	if err := checkAmount(amountCents); err != nil {
+		return nil, false, err
+	}
+
+	txID := s.ids.NewID()
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var record *account.Transaction
+	deposit := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		accs, err := s.lockAccountsInOrder(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+		acc := accs[0]
+		if err := checkActive(acc); err != nil {
+			return err
+		}
+		if currency != "" && currency != acc.Currency {
+			return &account.CurrencyMismatchError{FromCurrency: currency, ToCurrency: acc.Currency}
+		}
+
+		balance, err := s.accountRepo.Credit(ctx, tx, accountID, amountCents)
+		if err != nil {
+			return err
+		}
+		if err := checkBalanceSign(acc, balance); err != nil {
+			return err
+		}
+
+		record = &account.Transaction{
+			ID:                txID,
+			ToAccountID:       accountID,
+			AmountCents:       amountCents,
+			Currency:          acc.Currency,
+			Kind:              account.TransactionKindDeposit,
+			ActorID:           actorID,
+			ToBalanceAfter:    &balance,
+			ExternalReference: ref,
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, record); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, tx, AuditDeposit, record.ID, accountID); err != nil {
+			return err
+		}
+
+		afterCommit(ctx, func() {
+			s.invalidate(accountID)
+			s.notify(record.ID, account.Notification{
+				ID:        record.ID + ":credit",
+				AccountID: accountID,
+				Channel:   acc.NotificationChannel,
+				ActorID:   auditActor(ctx),
+				Message:   fmt.Sprintf("Deposited %d %s (transaction %s)", amountCents, acc.Currency, record.ID),
+			})
+			s.publishTransfer(record)
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, deposit)
+	})
+	if _, joined := txFromContext(ctx); !joined && errors.Is(err, account.ErrDuplicateReference) {
+		// The failed insert aborted the transaction, and the credit rolled
+		// back with it
+		return s.priorDeposit(ctx, ref, accountID, amountCents)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return record, false, nil
+}
+
+// priorDeposit returns the deposit already recorded under ref, provided it
+// is the same deposit being replayed
+func (s *LedgerService) priorDeposit(ctx context.Context, ref, accountID string, amountCents int64) (*account.Transaction, bool, error) {
+	prior, err := s.accountRepo.GetTransactionByExternalReference(ctx, ref)
+	if err != nil {
+		return nil, false, err
+	}
+	if prior.Kind != account.TransactionKindDeposit || prior.ToAccountID != accountID || prior.AmountCents != amountCents {
+		return nil, false, fmt.Errorf("reference %s is transaction %s: %w", ref, prior.ID, account.ErrReferenceConflict)
+	}
+	return prior, true, nil
+}
+
+// AccrueInterest credits an account the interest earned on its balance over
+// the period [start, end) at its interest_rate_bps, recording an interest
+// transaction. It returns nil without error when nothing is owed: a zero
+// rate, a non-positive balance, an amount below one cent, or a period that
+// has already been credited.
+func (s *LedgerService) AccrueInterest(ctx context.Context, accountID string, start, end time.Time, dayCount DayCount) (_ *account.Transaction, err error) {
+	defer s.slow.Observe("AccrueInterest", time.Now(), accountID)
+	defer s.auditFailure(ctx, AuditAccrueInterest, &err, accountID)
+
+	txID := s.ids.NewID()
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var record *account.Transaction
+	accrue := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+		// An attempt that finds nothing to do leaves no record behind
+		record = nil
+
+		accs, err := s.lockAccountsInOrder(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+		acc := accs[0]
+		if acc.InterestRateBps <= 0 || acc.BalanceCents <= 0 {
+			return nil
+		}
+		days, basis := dayCount.yearFraction(start, end)
+		amount := accruedInterest(acc.BalanceCents, acc.InterestRateBps, days, basis)
+		if amount <= 0 {
+			return nil
+		}
+
+		balance, err := s.accountRepo.Credit(ctx, tx, accountID, amount)
+		if err != nil {
+			return err
+		}
+
+		record = &account.Transaction{
+			ID:          txID,
+			ToAccountID: accountID,
+			AmountCents: amount,
+			Currency:    acc.Currency,
+			Kind:        account.TransactionKindInterest,
+			Reason: fmt.Sprintf("interest %s to %s at %d bps (%s)",
+				start.Format("2006-01-02"), end.Format("2006-01-02"), acc.InterestRateBps, dayCount),
+			ToBalanceAfter:    &balance,
+			ExternalReference: interestReference(start, accountID),
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, record); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, tx, AuditAccrueInterest, record.ID, accountID); err != nil {
+			return err
+		}
+
+		afterCommit(ctx, func() {
+			s.invalidate(accountID)
+			s.notify(record.ID, account.Notification{
+				ID:        record.ID + ":credit",
+				AccountID: accountID,
+				Channel:   acc.NotificationChannel,
+				ActorID:   auditActor(ctx),
+				Message:   fmt.Sprintf("Interest of %d %s credited (transaction %s)", amount, acc.Currency, record.ID),
+			})
+			s.publishTransfer(record)
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, accrue)
+	})
+	if _, joined := txFromContext(ctx); !joined && errors.Is(err, account.ErrDuplicateReference) {
+		// Another run credited this period first; the credit rolled back
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ChargeOverdraftPenalty debits one day's penalty, at rateBps a year on the
+// negative balance, from an account overdrawn since at or before
+// overdrawnBy. The day is [start, end); it returns nil if the account no
+// longer qualifies or was already charged for the day.
+func (s *LedgerService) ChargeOverdraftPenalty(ctx context.Context, accountID string, start, end, overdrawnBy time.Time, rateBps int64, dayCount DayCount) (_ *account.Transaction, err error) {
+	defer s.slow.Observe("ChargeOverdraftPenalty", time.Now(), accountID)
+	defer s.auditFailure(ctx, AuditOverdraftPenalty, &err, accountID)
+
+	txID := s.ids.NewID()
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var record *account.Transaction
+	charge := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+		// An attempt that finds nothing to do leaves no record behind
+		record = nil
+
+		accs, err := s.lockAccountsInOrder(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+		acc := accs[0]
+		if acc.BalanceCents >= 0 {
+			return nil
+		}
+		days, basis := dayCount.yearFraction(start, end)
+		amount := accruedInterest(-acc.BalanceCents, rateBps, days, basis)
+		if amount <= 0 {
+			return nil
+		}
+
+		balance, charged, err := s.accountRepo.ChargeOverdraftPenalty(ctx, tx, accountID, amount, overdrawnBy)
+		if err != nil || !charged {
+			return err
+		}
+
+		record = &account.Transaction{
+			ID:            txID,
+			FromAccountID: accountID,
+			AmountCents:   amount,
+			Currency:      acc.Currency,
+			Kind:          account.TransactionKindOverdraft,
+			Reason: fmt.Sprintf("overdraft penalty %s on %d at %d bps (%s)",
+				start.Format("2006-01-02"), -acc.BalanceCents, rateBps, dayCount),
+			FromBalanceAfter:  &balance,
+			ExternalReference: overdraftReference(start, accountID),
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, record); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, tx, AuditOverdraftPenalty, record.ID, accountID); err != nil {
+			return err
+		}
+
+		afterCommit(ctx, func() {
+			s.invalidate(accountID)
+			s.notify(record.ID, account.Notification{
+				ID:        record.ID + ":debit",
+				AccountID: accountID,
+				Channel:   acc.NotificationChannel,
+				ActorID:   auditActor(ctx),
+				Message:   fmt.Sprintf("Overdraft penalty of %d %s charged (transaction %s)", amount, acc.Currency, record.ID),
+			})
+			s.publishTransfer(record)
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, charge)
+	})
+	if _, joined := txFromContext(ctx); !joined && errors.Is(err, account.ErrDuplicateReference) {
+		// Another run charged this day first; the debit rolled back
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// GetTransfer retrieves a transfer by transaction ID. Other kinds of
+// transaction are reported as not found.
+func (s *LedgerService) GetTransfer(ctx context.Context, transactionID string) (*account.Transaction, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transaction ID cannot be empty")
+	}
+	t, err := s.accountRepo.GetTransaction(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Kind != account.TransactionKindTransfer {
+		return nil, fmt.Errorf("transaction %s is a %s: %w", transactionID, t.Kind, account.ErrTransactionNotFound)
+	}
+	return t, nil
+}
+
+// GetTransaction retrieves a transaction of any kind by ID
+func (s *LedgerService) GetTransaction(ctx context.Context, transactionID string) (*account.Transaction, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transaction ID cannot be empty")
+	}
+	return s.accountRepo.GetTransaction(ctx, transactionID)
+}
+
+// ReverseTransfer moves amountCents of a prior transfer back from its receiver
+// to its sender. A zero amount reverses whatever is still reversible; partial
+// reversals accumulate on the original until it is fully reversed.
+func (s *LedgerService) ReverseTransfer(ctx context.Context, transactionID string, amountCents int64, reason, actorID string) (_ *account.Transaction, _ *account.Transaction, err error) {
+	defer s.slow.Observe("ReverseTransfer", time.Now())
+	// The accounts are known once the original is read
+	var fromID, toID string
+	defer func() { s.auditFailure(ctx, AuditReverseTransfer, &err, fromID, toID) }()
+	if transactionID == "" {
+		return nil, nil, fmt.Errorf("transaction ID cannot be empty")
+	}
+	if amountCents < 0 {
+		return nil, nil, account.ErrNonPositiveAmount
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, nil, account.ErrReasonRequired
+	}
+
+	reversalID := s.ids.NewID()
+
+	// Run in the request-scoped transaction, joining the caller's if any,
+	// retrying it whole after a deadlock or serialization failure
+	var reversal, updatedOriginal *account.Transaction
+	reverse := func(ctx context.Context) error {
+		tx, _ := txFromContext(ctx)
+
+		// Locking the original first serializes concurrent reversals of it
+		original, err := s.accountRepo.GetTransactionForUpdate(ctx, tx, transactionID)
+		if err != nil {
+			return err
+		}
+		if original.Kind != account.TransactionKindTransfer || original.ConvertedAmountCents != nil ||
+			original.FromAccountID == "" || original.ToAccountID == "" {
+			return fmt.Errorf("transaction %s: %w", transactionID, account.ErrNotReversible)
+		}
+		// Money flows back from the original receiver to the original sender
+		fromID, toID = original.ToAccountID, original.FromAccountID
+
+		// A retry re-reads what remains, so the default is decided per attempt
+		remaining := original.ReversibleCents()
+		amount := amountCents
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount == 0 || amount > remaining {
+			return fmt.Errorf("transaction %s has %d of %d cents left to reverse: %w",
+				transactionID, remaining, original.AmountCents, account.ErrReversalExceedsTotal)
+		}
+
+		accs, err := s.lockAccountsInOrder(ctx, tx, fromID, toID)
+		if err != nil {
+			return err
+		}
+		if err := checkActive(accs...); err != nil {
+			return err
+		}
+		fromAcc := accs[0]
+		if err := checkFunds(fromAcc, Money{Cents: amount, Currency: fromAcc.Currency}); err != nil {
+			return err
+		}
+
+		fromBalance, err := s.accountRepo.Debit(ctx, tx, fromID, amount)
+		if err != nil {
+			return err
+		}
+		toBalance, err := s.accountRepo.Credit(ctx, tx, toID, amount)
+		if err != nil {
+			return err
+		}
+		if err := checkBalanceSigns(fromAcc, fromBalance, accs[1], toBalance); err != nil {
+			return err
+		}
+
+		reversal = &account.Transaction{
+			ID:                    reversalID,
+			FromAccountID:         fromID,
+			ToAccountID:           toID,
+			AmountCents:           amount,
+			Currency:              original.Currency,
+			Kind:                  account.TransactionKindReversal,
+			Reason:                reason,
+			ActorID:               actorID,
+			FromBalanceAfter:      &fromBalance,
+			ToBalanceAfter:        &toBalance,
+			ReversesTransactionID: original.ID,
+		}
+		if err := s.accountRepo.RecordTransaction(ctx, tx, reversal); err != nil {
+			return err
+		}
+		if err := s.accountRepo.AddReversedAmount(ctx, tx, original.ID, amount); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, tx, AuditReverseTransfer, reversal.ID, fromID, toID); err != nil {
+			return err
+		}
+		original.ReversedCents += amount
+		updatedOriginal = original
+
+		afterCommit(ctx, func() {
+			s.invalidate(fromID, toID)
+			s.notifyTransfer(ctx, reversal.ID, fromAcc, accs[1], amount, original.Currency)
+			s.publishTransfer(reversal)
+		})
+		return nil
+	}
+	err = s.retryConflicts(ctx, func() error {
+		return s.WithTx(ctx, reverse)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reversal, updatedOriginal, nil
+}
+
+// windowReversalBatchSize is how many transfers ReverseTransfersInWindow
+// reads per query
+const windowReversalBatchSize = 100
+
+// ReverseTransfersInWindow reverses whatever remains of every same-currency
+// transfer created in [from, to), optionally only those accountID sent or
+// received. Each reversal runs in its own transaction, so one that fails (for
+// instance because the receiver has since spent the money) is reported and
+// skipped without holding up the rest. Transfers already reversed in full
+// are not selected, so running it again only retries what was skipped.
+func (s *LedgerService) ReverseTransfersInWindow(ctx context.Context, from, to time.Time, accountID, reason, actorID string) ([]account.WindowReversal, error) {
+	defer s.slow.Observe("ReverseTransfersInWindow", time.Now(), accountID)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("window start must be before its end")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, account.ErrReasonRequired
+	}
+
+	var results []account.WindowReversal
+	afterID := ""
+	for {
+		txns, err := s.accountRepo.GetReversibleTransfersInWindow(ctx, from, to, accountID, afterID, windowReversalBatchSize)
+		if err != nil {
+			return results, err
+		}
+		for _, t := range txns {
+			reversal, _, err := s.ReverseTransfer(ctx, t.ID, 0, reason, actorID)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return results, ctxErr
+			}
+			result := account.WindowReversal{TransactionID: t.ID, Err: err}
+			if err == nil {
+				result.ReversalID = reversal.ID
+				result.AmountCents = reversal.AmountCents
+			}
+			results = append(results, result)
+		}
+		if len(txns) < windowReversalBatchSize {
+			return results, nil
+		}
+		afterID = txns[len(txns)-1].ID
+	}
+}
+
+// GetBalance retrieves the current balance of an account
+func (s *LedgerService) GetBalance(ctx context.Context, accountID string) (*account.Account, error) {
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+
+	if s.cache != nil {
+		if acc, ok := s.cache.get(accountID); ok {
+			if err := s.auditRead(ctx, AuditReadBalance, acc); err != nil {
+				return nil, err
+			}
+			return acc, nil
+		}
+	}
+
+	acc, err := s.accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cache != nil {
+		s.cache.set(acc)
+	}
+	if err := s.auditRead(ctx, AuditReadBalance, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// GetBalanceAsOf computes an account's balance at a past point in time. It
+// starts from the latest balance snapshot at or before asOf and adds the
+// transactions recorded after it, so only the tail of the history is summed.
+func (s *LedgerService) GetBalanceAsOf(ctx context.Context, accountID string, asOf time.Time) (*account.Account, int64, error) {
+	if accountID == "" {
+		return nil, 0, fmt.Errorf("account ID cannot be empty")
+	}
+
+	acc, err := s.accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if asOf.Before(acc.CreatedAt) {
+		return acc, 0, nil
+	}
+
+	var base int64
+	var since time.Time
+	snap, err := s.accountRepo.GetNearestSnapshot(ctx, accountID, asOf)
+	if err != nil {
+		return nil, 0, err
+	}
+	if snap != nil {
+		base, since = snap.BalanceCents, snap.TakenAt
+	}
+
+	net, err := s.accountRepo.GetNetChange(ctx, accountID, since, asOf)
+	if err != nil {
+		return nil, 0, err
+	}
+	balance, err := Money{Cents: base, Currency: acc.Currency}.Add(Money{Cents: net, Currency: acc.Currency})
+	if err != nil {
+		return nil, 0, err
+	}
+	return acc, balance.Cents, nil
+}
+
+// BatchGetBalance retrieves several accounts at once, in request order.
+// Cached accounts are served from the cache and the rest are read with one
+// query. Rather than failing the call, IDs with no account are returned as
+// notFound, and IDs that don't match the account ID pattern as invalid
+// without being looked up. Like GetBalance it audits each sensitive account
+// it returns.
+func (s *LedgerService) BatchGetBalance(ctx context.Context, accountIDs []string) (found []account.Account, notFound, invalid []string, err error) {
+	// Drop duplicate IDs, keeping the first occurrence
+	unique := make([]string, 0, len(accountIDs))
+	seen := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if s.validateAccountID(id) != nil {
+			invalid = append(invalid, id)
+			continue
+		}
+		unique = append(unique, id)
+	}
+
+	byID, err := s.readAccounts(ctx, unique)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	found = make([]account.Account, 0, len(byID))
+	for _, id := range unique {
+		acc, ok := byID[id]
+		if !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+		if err := s.auditRead(ctx, AuditReadBalance, &acc); err != nil {
+			return nil, nil, nil, err
+		}
+		found = append(found, acc)
+	}
+	return found, notFound, invalid, nil
+}
+
+// LookupAccounts reads the accounts with the given IDs for an access check,
+// skipping IDs that are malformed or have no account. Unlike BatchGetBalance
+// it records no read audit: the caller isn't shown the accounts, only told
+// whether it may proceed.
+func (s *LedgerService) LookupAccounts(ctx context.Context, accountIDs []string) ([]account.Account, error) {
+	valid := make([]string, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		if s.validateAccountID(id) == nil {
+			valid = append(valid, id)
+		}
+	}
+	byID, err := s.readAccounts(ctx, valid)
+	if err != nil {
+		return nil, err
+	}
+	accs := make([]account.Account, 0, len(byID))
+	for _, id := range valid {
+		if acc, ok := byID[id]; ok {
+			accs = append(accs, acc)
+			delete(byID, id)
+		}
+	}
+	return accs, nil
+}
+
+// readAccounts reads the given accounts by ID, serving cached ones from the
+// cache and the rest with one query. IDs with no account are left out.
+func (s *LedgerService) readAccounts(ctx context.Context, ids []string) (map[string]account.Account, error) {
+	byID := make(map[string]account.Account, len(ids))
+	var misses []string
+	for _, id := range ids {
+		if s.cache != nil {
+			if acc, ok := s.cache.get(id); ok {
+				byID[id] = *acc
+				continue
+			}
+		}
+		misses = append(misses, id)
+	}
+
+	if len(misses) > 0 {
+		accs, err := s.accountRepo.GetAccountsByIDs(ctx, misses)
+		if err != nil {
+			return nil, err
+		}
+		for i := range accs {
+			byID[accs[i].ID] = accs[i]
+			if s.cache != nil {
+				s.cache.set(&accs[i])
+			}
+		}
+	}
+	return byID, nil
+}
+
+// CreateAccount creates a new account; an empty accountType makes a standard account
+func (s *LedgerService) CreateAccount(ctx context.Context, id, ownerID string, balanceCents int64, currency, accountType string) (_ *account.Account, err error) {
+	defer s.auditFailure(ctx, AuditCreateAccount, &err, id)
+	// Validate inputs
+	if currency == "" {
+		currency = s.defaultCurrency
+	}
+	if err := validateNewAccount(balanceCents, currency); err != nil {
+		return nil, err
+	}
+	if err := s.checkCurrency(currency); err != nil {
+		return nil, err
+	}
+	if accountType == "" {
+		accountType = account.AccountTypeStandard
+	}
+	if !account.ValidAccountType(accountType) {
+		return nil, fmt.Errorf("%q: %w", accountType, account.ErrInvalidAccountType)
+	}
+	if ownerID == "" {
+		return nil, account.ErrOwnerRequired
+	}
+
+	// Generate ID if not provided
+	if id == "" {
+		id = s.ids.NewID()
+	} else if err := s.validateAccountID(id); err != nil {
+		return nil, err
+	}
+
+	// Create account
+	acc := &account.Account{
+		ID:           id,
+		OwnerID:      ownerID,
+		BalanceCents: balanceCents,
+		Currency:     currency,
+		Type:         accountType,
+	}
+	if err := checkBalanceSign(acc, balanceCents); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.createAccount(ctx, tx, acc); err != nil {
+		return nil, fmt.Errorf("failed to create account: %w", err)
+	}
+	if err := s.audit(ctx, tx, AuditCreateAccount, "", id); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Fetch the created account to get timestamps
+	createdAcc, err := s.accountRepo.GetAccount(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch created account: %w", err)
+	}
+
+	return createdAcc, nil
+}
+
+// ImportAccounts creates a batch of accounts in a single transaction.
+// Each record is inserted under its own savepoint, so a bad record (invalid
+// input, duplicate ID) is reported in the returned slice, aligned with accs,
+// without affecting the others. The error return is reserved for failures
+// that abort the whole batch.
+func (s *LedgerService) ImportAccounts(ctx context.Context, accs []account.Account) (_ []error, err error) {
+	defer s.auditFailure(ctx, AuditImportAccounts, &err)
+
+	tx, err := s.beginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	failures := make([]error, len(accs))
+	var imported []string
+	for i := range accs {
+		acc := &accs[i]
+		if acc.Currency == "" {
+			acc.Currency = s.defaultCurrency
+		}
+		if err := validateNewAccount(acc.BalanceCents, acc.Currency); err != nil {
+			failures[i] = err
+			continue
+		}
+		if acc.OwnerID == "" {
+			failures[i] = account.ErrOwnerRequired
+			continue
+		}
+		if err := s.checkCurrency(acc.Currency); err != nil {
+			failures[i] = err
+			continue
+		}
+		if acc.ID == "" {
+			acc.ID = s.ids.NewID()
+		} else if err := s.validateAccountID(acc.ID); err != nil {
+			failures[i] = err
+			continue
+		}
+		if acc.Type == "" {
+			acc.Type = account.AccountTypeStandard
+		}
+		if !account.ValidAccountType(acc.Type) {
+			failures[i] = fmt.Errorf("%q: %w", acc.Type, account.ErrInvalidAccountType)
+			continue
+		}
+		if err := checkBalanceSign(acc, acc.BalanceCents); err != nil {
+			failures[i] = err
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT import_record"); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		if err := s.createAccount(ctx, tx, acc); err != nil {
+			failures[i] = err
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_record"); err != nil {
+				return nil, fmt.Errorf("failed to roll back to savepoint: %w", err)
+			}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT import_record"); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		imported = append(imported, acc.ID)
+	}
+	if err := s.audit(ctx, tx, AuditImportAccounts, "", imported...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return failures, nil
+}
+
+// GetAccount retrieves full account details
+func (s *LedgerService) GetAccount(ctx context.Context, accountID string) (*account.Account, error) {
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+
+	acc, err := s.accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auditRead(ctx, AuditReadAccount, acc); err != nil {
+		return nil, err
+	}
+
+	return acc, nil
+}
+
+// UpdateAccount updates account currency
+func (s *LedgerService) UpdateAccount(ctx context.Context, accountID string, currency string) (_ *account.Account, err error) {
+	defer s.auditFailure(ctx, AuditUpdateAccount, &err, accountID)
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("currency is required")
+	}
+	if len(currency) > 10 {
+		return nil, fmt.Errorf("currency code must be 10 characters or less")
+	}
+	if err := s.checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// A transfer must not see the currency change under it
+	if _, err := s.lockAccountsInOrder(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+	if err := s.accountRepo.UpdateAccountTx(ctx, tx, accountID, currency); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+	if err := s.audit(ctx, tx, AuditUpdateAccount, "", accountID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.invalidate(accountID)
+
+	// Fetch updated account
+	updatedAcc, err := s.accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch updated account: %w", err)
+	}
+
+	return updatedAcc, nil
+}
+
+// SetParentAccount places an account under parentID, or detaches it when
+// parentID is empty. A parent that is the account itself or one of its
+// descendants is rejected with ErrParentCycle.
+func (s *LedgerService) SetParentAccount(ctx context.Context, accountID, parentID string) (_ *account.Account, err error) {
+	defer s.auditFailure(ctx, AuditSetParentAccount, &err, accountID, parentID)
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := s.accountRepo.LockHierarchy(ctx, tx); err != nil {
+		return nil, err
+	}
+	if parentID != "" {
+		if _, err := s.lockAccountsInOrder(ctx, tx, parentID); err != nil {
+			if errors.Is(err, account.ErrAccountNotFound) {
+				return nil, fmt.Errorf("account %s: %w", parentID, account.ErrParentNotFound)
+			}
+			return nil, err
+		}
+		// The account may not become its own ancestor
+		cycle, err := s.accountRepo.IsAncestorOrSelf(ctx, tx, accountID, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if cycle {
+			return nil, fmt.Errorf("%s under %s: %w", accountID, parentID, account.ErrParentCycle)
+		}
+	}
+	if err := s.accountRepo.SetParent(ctx, tx, accountID, parentID); err != nil {
+		return nil, err
+	}
+	if err := s.audit(ctx, tx, AuditSetParentAccount, "", accountID, parentID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.invalidate(accountID)
+
+	return s.accountRepo.GetAccount(ctx, accountID)
+}
+
+// GetAggregateBalance totals the balances of an account and all its
+// descendants per currency, returning the totals and the number of accounts
+func (s *LedgerService) GetAggregateBalance(ctx context.Context, accountID string) ([]account.CurrencyBalance, int, error) {
+	if accountID == "" {
+		return nil, 0, fmt.Errorf("account ID cannot be empty")
+	}
+	totals, err := s.accountRepo.GetAggregateBalance(ctx, accountID)
+	if err != nil {
+		return nil, 0, err
+	}
+	count := 0
+	for _, t := range totals {
+		count += t.AccountCount
+	}
+	return totals, count, nil
+}
+
+// DeleteAccount deletes an account
+func (s *LedgerService) DeleteAccount(ctx context.Context, accountID string) (err error) {
+	defer s.auditFailure(ctx, AuditDeleteAccount, &err, accountID)
+	if accountID == "" {
+		return fmt.Errorf("account ID cannot be empty")
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := s.lockAccountsInOrder(ctx, tx, accountID); err != nil {
+		return err
+	}
+	if err := s.accountRepo.DeleteAccountTx(ctx, tx, accountID); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	if err := s.audit(ctx, tx, AuditDeleteAccount, "", accountID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.invalidate(accountID)
+
+	return nil
+}
+
+// ActivateAccount makes a pending account active so it can transfer money.
+// Activating an account that is already active is a no-op.
+func (s *LedgerService) ActivateAccount(ctx context.Context, accountID string) (acc *account.Account, err error) {
+	defer s.auditFailure(ctx, AuditActivateAccount, &err, accountID)
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := s.lockAccountsInOrder(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+	activated, err := s.accountRepo.ActivateAccountTx(ctx, tx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if activated {
+		if err := s.audit(ctx, tx, AuditActivateAccount, "", accountID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if activated {
+		s.invalidate(accountID)
+	}
+
+	return s.accountRepo.GetAccount(ctx, accountID)
+}
+
+// SetNotificationPreference sets the channel an account's notifications are
+// delivered through; an empty channel restores the default sender
+func (s *LedgerService) SetNotificationPreference(ctx context.Context, accountID, channel string) (acc *account.Account, err error) {
+	defer s.auditFailure(ctx, AuditSetNotification, &err, accountID)
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if !account.ValidNotificationChannel(channel) {
+		return nil, fmt.Errorf("%q: %w", channel, account.ErrInvalidNotificationChannel)
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := s.lockAccountsInOrder(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+	changed, err := s.accountRepo.SetNotificationChannelTx(ctx, tx, accountID, channel)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := s.audit(ctx, tx, AuditSetNotification, "", accountID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if changed {
+		s.invalidate(accountID)
+	}
+
+	return s.accountRepo.GetAccount(ctx, accountID)
+}
+
+// SetAccountSensitive flags or unflags an account as sensitive. Reads of a
+// sensitive account are audited; reads of others are not, to spare the
+// write on every lookup.
+func (s *LedgerService) SetAccountSensitive(ctx context.Context, accountID string, sensitive bool) (acc *account.Account, err error) {
+	defer s.auditFailure(ctx, AuditSetSensitive, &err, accountID)
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := s.lockAccountsInOrder(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+	changed, err := s.accountRepo.SetSensitiveTx(ctx, tx, accountID, sensitive)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		if err := s.audit(ctx, tx, AuditSetSensitive, "", accountID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	if changed {
+		s.invalidate(accountID)
+	}
+
+	return s.accountRepo.GetAccount(ctx, accountID)
+}
+
+// ListAccounts retrieves all accounts with pagination
+func (s *LedgerService) ListAccounts(ctx context.Context, limit, offset int) ([]account.Account, int64, error) {
+	if limit <= 0 {
+		limit = 100 // Default limit
+	}
+	if limit > s.maxPageSize {
+		limit = s.maxPageSize // Max limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	accounts, err := s.accountRepo.GetAllAccounts(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	total, err := s.accountRepo.GetAccountCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get account count: %w", err)
+	}
+
+	return accounts, total, nil
+}
+
+// GetAccountsByOwner retrieves an owner's accounts with pagination
+func (s *LedgerService) GetAccountsByOwner(ctx context.Context, ownerID string, limit, offset int) ([]account.Account, int64, error) {
+	if ownerID == "" {
+		return nil, 0, fmt.Errorf("owner ID is required")
+	}
+	if limit <= 0 {
+		limit = 100 // Default limit
+	}
+	if limit > s.maxPageSize {
+		limit = s.maxPageSize // Max limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	accounts, err := s.accountRepo.GetAccountsByOwner(ctx, ownerID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	total, err := s.accountRepo.GetAccountCountByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get account count: %w", err)
+	}
+
+	return accounts, total, nil
+}
+
+// ListAccountsByCurrency retrieves one currency's accounts with pagination,
+// along with the account count and the sum of all their balances
+func (s *LedgerService) ListAccountsByCurrency(ctx context.Context, currency string, limit, offset int) ([]account.Account, int64, int64, error) {
+	if currency == "" {
+		return nil, 0, 0, fmt.Errorf("currency is required")
+	}
+	if limit <= 0 {
+		limit = 100 // Default limit
+	}
+	if limit > s.maxPageSize {
+		limit = s.maxPageSize // Max limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	accounts, err := s.accountRepo.GetAccountsByCurrency(ctx, currency, limit, offset)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	total, totalBalance, err := s.accountRepo.GetCurrencyTotals(ctx, currency)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to get currency totals: %w", err)
+	}
+
+	return accounts, total, totalBalance, nil
+}
+
+// ListAccountsAfter retrieves the page of accounts following afterID in ID
+// order. It backs cursor-based scans such as exports.
+func (s *LedgerService) ListAccountsAfter(ctx context.Context, afterID, currency string, limit int) ([]account.Account, error) {
+	if limit <= 0 {
+		limit = 100 // Default limit
+	}
+	if limit > s.maxPageSize {
+		limit = s.maxPageSize // Max limit
+	}
+
+	accounts, err := s.accountRepo.GetAccountsAfter(ctx, afterID, currency, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// ListTransactionsAfter retrieves the page of transactions matching filter
+// that follows after in (created_at, id) order, oldest first. It backs
+// cursor-based scans such as exports.
+func (s *LedgerService) ListTransactionsAfter(ctx context.Context, filter account.TransactionFilter, after *account.HistoryCursor, limit int) ([]account.Transaction, error) {
+	if limit <= 0 {
+		limit = 100 // Default limit
+	}
+	if limit > s.maxPageSize {
+		limit = s.maxPageSize // Max limit
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return nil, fmt.Errorf("since must be before until")
+	}
+
+	txns, err := s.accountRepo.GetTransactionsAfter(ctx, filter, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	return txns, nil
+}
+
+// ReadEvents returns up to limit of an account's balance-change events after
+// sequence number fromSeq, oldest first. Passing the last sequence number
+// seen resumes the stream without gaps or repeats.
+func (s *LedgerService) ReadEvents(ctx context.Context, accountID string, fromSeq int64, limit int) ([]account.LedgerEvent, error) {
+	if accountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty")
+	}
+	if fromSeq < 0 {
+		return nil, fmt.Errorf("from_seq must not be negative")
+	}
+	if limit <= 0 {
+		limit = 100 // Default limit
+	}
+	if limit > s.maxPageSize {
+		limit = s.maxPageSize // Max limit
+	}
+
+	// Check if account exists
+	if _, err := s.accountRepo.GetAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return s.accountRepo.ReadLedgerEvents(ctx, accountID, fromSeq, limit)
+}
+
+// GetTransactionHistory returns one page of an account's transactions, newest
+// first, along with an opaque token for the next page ("" on the last page).
+// A non-empty category limits it to transactions in that category.
+func (s *LedgerService) GetTransactionHistory(ctx context.Context, accountID, category string, pageSize int, pageToken string) ([]account.Transaction, string, error) {
+	if accountID == "" {
+		return nil, "", fmt.Errorf("account ID cannot be empty")
+	}
+	if pageSize <= 0 {
+		pageSize = 50 // Default page size
+	}
+	if pageSize > s.maxPageSize {
+		pageSize = s.maxPageSize // Max page size
+	}
+
+	var cursor *account.HistoryCursor
+	if pageToken != "" {
+		c, err := decodeHistoryCursor(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor = c
+	}
+
+	// Check if account exists
+	if _, err := s.accountRepo.GetAccount(ctx, accountID); err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	txns, err := s.accountRepo.GetTransactionHistory(ctx, accountID, strings.ToLower(strings.TrimSpace(category)), cursor, pageSize+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get transaction history: %w", err)
+	}
+
+	nextToken := ""
+	if len(txns) > pageSize {
+		txns = txns[:pageSize]
+		last := txns[len(txns)-1]
+		nextToken = encodeHistoryCursor(account.HistoryCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return txns, nextToken, nil
+}
+
+// ListDeadLetters returns one page of dead-lettered notifications, oldest
+// first, along with an opaque token for the next page ("" on the last page)
+func (s *LedgerService) ListDeadLetters(ctx context.Context, pageSize int, pageToken string) ([]account.DeadLetter, string, error) {
+	if pageSize <= 0 {
+		pageSize = 50 // Default page size
+	}
+	if pageSize > s.maxPageSize {
+		pageSize = s.maxPageSize // Max page size
+	}
+
+	afterID, err := decodeIDToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	letters, err := s.accountRepo.ListDeadLetters(ctx, afterID, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextToken := ""
+	if len(letters) > pageSize {
+		letters = letters[:pageSize]
+		nextToken = encodeIDToken(letters[len(letters)-1].ID)
+	}
+	return letters, nextToken, nil
+}
+
+// QueryAuditLog pages through audit entries matching filter, oldest first
+func (s *LedgerService) QueryAuditLog(ctx context.Context, filter account.AuditFilter, pageSize int, pageToken string) ([]account.AuditEntry, string, error) {
+	if pageSize <= 0 {
+		pageSize = 50 // Default page size
+	}
+	if pageSize > s.maxPageSize {
+		pageSize = s.maxPageSize // Max page size
+	}
+
+	afterID, err := decodeIDToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	entries, err := s.accountRepo.QueryAuditLog(ctx, filter, afterID, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextToken := ""
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		nextToken = encodeIDToken(entries[len(entries)-1].ID)
+	}
+	return entries, nextToken, nil
+}
+
+// encodeIDToken makes a page token resuming after a numeric row ID
+func encodeIDToken(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeIDToken returns the row ID a page token resumes after; an empty
+// token starts from the beginning
+func decodeIDToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page token")
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid page token")
+	}
+	return id, nil
+}
+
+// RetryDeadLetters removes up to limit dead letters (only those in ids, if
+// given) from the store and enqueues them for delivery again. A retry that
+// fails again is dead-lettered anew.
+func (s *LedgerService) RetryDeadLetters(ctx context.Context, ids []int64, limit int) ([]account.DeadLetter, error) {
+	if s.notifier == nil {
+		return nil, fmt.Errorf("notifications are disabled")
+	}
+	if limit <= 0 {
+		limit = 100 // Default batch
+	}
+	if limit > 1000 {
+		limit = 1000 // Max batch
+	}
+
+	letters, err := s.accountRepo.TakeDeadLetters(ctx, ids, limit)
+	if err != nil {
+		return nil, err
+	}
+	// The letters are already out of the store, so one that fails to
+	// enqueue must not stop the rest
+	for _, d := range letters {
+		s.notify(d.NotificationID, account.Notification{
+			ID:        d.NotificationID,
+			AccountID: d.AccountID,
+			Channel:   d.Channel,
+			ActorID:   d.ActorID,
+			Message:   d.Message,
+		})
+	}
+	return letters, nil
+}
+
+// encodeHistoryCursor packs a keyset position into an opaque page token
+func encodeHistoryCursor(c account.HistoryCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeHistoryCursor reverses encodeHistoryCursor
+func decodeHistoryCursor(token string) (*account.HistoryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	return &account.HistoryCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// createAccount inserts acc within tx and, if it starts with money, records
+// an opening-balance transaction from outside the ledger so the transaction
+// log accounts for every cent from the account's creation
+func (s *LedgerService) createAccount(ctx context.Context, tx *sqlx.Tx, acc *account.Account) error {
+	if err := s.checkAccountLimit(ctx, tx, acc.OwnerID); err != nil {
+		return err
+	}
+	if acc.Status == "" {
+		acc.Status = s.initialStatus
+	}
+	if acc.Status == "" {
+		acc.Status = account.AccountStatusActive
+	}
+	if err := s.accountRepo.CreateAccountTx(ctx, tx, acc); err != nil {
+		return err
+	}
+	if acc.BalanceCents == 0 {
+		return nil
+	}
+	balance := acc.BalanceCents
+	return s.accountRepo.RecordTransaction(ctx, tx, &account.Transaction{
+		ID:             s.ids.NewID(),
+		ToAccountID:    acc.ID,
+		AmountCents:    acc.BalanceCents,
+		Currency:       acc.Currency,
+		Kind:           account.TransactionKindOpeningBalance,
+		ToBalanceAfter: &balance,
+	})
+}
+
+// checkAccountLimit rejects creating another account for ownerID once it
+// holds maxAccountsPerOwner. The count is taken under the owner's lock, so
+// concurrent creations can't both slip under the limit. Admins, and accounts
+// without an owner, are not limited.
+func (s *LedgerService) checkAccountLimit(ctx context.Context, tx *sqlx.Tx, ownerID string) error {
+	if s.maxAccountsPerOwner <= 0 || ownerID == "" {
+		return nil
+	}
+	if user, ok := auth.UserFromContext(ctx); ok && user.IsAdmin() {
+		return nil
+	}
+	count, err := s.accountRepo.CountAccountsByOwnerTx(ctx, tx, ownerID)
+	if err != nil {
+		return err
+	}
+	if count >= int64(s.maxAccountsPerOwner) {
+		return fmt.Errorf("owner %s already has %d accounts: %w", ownerID, count, account.ErrAccountLimitReached)
+	}
+	return nil
+}
+
+// checkActive rejects moving money through any account still pending
+// activation
+func checkActive(accs ...*account.Account) error {
+	for _, acc := range accs {
+		if acc.Status == account.AccountStatusPending {
+			return fmt.Errorf("account %s is %s: %w", acc.ID, acc.Status, account.ErrAccountNotActive)
+		}
+	}
+	return nil
+}
+
+// checkCurrencyAssertion rejects a transfer that names, as expected, a
+// currency acc doesn't hold; an empty expected currency asserts nothing.
+// Callers check the locked account, so the currency can't change before
+// the money moves.
+func checkCurrencyAssertion(acc *account.Account, expected string) error {
+	if expected != "" && !strings.EqualFold(expected, acc.Currency) {
+		return fmt.Errorf("account %s holds %s, expected %s: %w", acc.ID, acc.Currency, strings.ToUpper(expected), account.ErrCurrencyAssertion)
+	}
+	return nil
+}
+
+// validateAccountID rejects a client-supplied account ID that doesn't match
+// the configured pattern
+func (s *LedgerService) validateAccountID(id string) error {
+	if !s.accountIDPattern.MatchString(id) {
+		return fmt.Errorf("account ID %q does not match %s: %w", id, s.accountIDPattern, account.ErrInvalidAccountID)
+	}
+	return nil
+}
+
+// validateNewAccount checks the fields supplied when an account is created
+func validateNewAccount(balanceCents int64, currency string) error {
+	if currency == "" {
+		return fmt.Errorf("currency is required")
+	}
+	if len(currency) > 10 {
+		return fmt.Errorf("currency code must be 10 characters or less")
+	}
+	if balanceCents < 0 {
+		return fmt.Errorf("initial balance cannot be negative")
+	}
+	return nil
+}
+
+// beginTx starts a transaction, waiting at most acquireTimeout for a
+// connection
+func (s *LedgerService) beginTx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	return database.BeginTx(ctx, s.db, opts, s.acquireTimeout)
+}
+
+// beginLockingTx starts a transaction that will take account row locks, at
+// the configured isolation level and with the configured lock_timeout so a
+// contended lock fails fast instead of blocking the request
+func (s *LedgerService) beginLockingTx(ctx context.Context) (*sqlx.Tx, error) {
+	isolation := s.isolation
+	if s.locking == LockOptimistic && isolation < sql.LevelRepeatableRead {
+		// Without locks, only a snapshot detects a concurrent update
+		isolation = sql.LevelRepeatableRead
+	}
+	tx, err := s.beginTx(ctx, &sql.TxOptions{Isolation: isolation})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if s.lockTimeout > 0 {
+		// SET does not take bind parameters; the value is a formatted integer
+		stmt := fmt.Sprintf("SET LOCAL lock_timeout = '%dms'", s.lockTimeout.Milliseconds())
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to set lock timeout: %w", err)
+		}
+	}
+	return tx, nil
+}
+
+// lockAccountsInOrder locks the given accounts using the configured
+// strategy and reads them. Row locks are taken in sorted ID order, each
+// distinct ID once, so any two transactions locking overlapping sets of
+// accounts acquire them in the same order and can't deadlock. The accounts
+// are returned in argument order; a repeated ID yields the same account.
+func (s *LedgerService) lockAccountsInOrder(ctx context.Context, tx *sqlx.Tx, ids ...string) ([]*account.Account, error) {
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	read := s.accountRepo.GetAccountWithLock
+	switch s.locking {
+	case LockAdvisory:
+		if err := s.accountRepo.LockAccountsAdvisory(ctx, tx, sorted); err != nil {
+			return nil, err
+		}
+		read = s.accountRepo.GetAccountTx
+	case LockOptimistic:
+		read = s.accountRepo.GetAccountTx
+	}
+
+	locked := make(map[string]*account.Account, len(sorted))
+	for _, id := range sorted {
+		acc, err := read(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		locked[id] = acc
+	}
+
+	accs := make([]*account.Account, len(ids))
+	for i, id := range ids {
+		accs[i] = locked[id]
+	}
+	return accs, nil
+}
+
+var postCommitFailures = metrics.NewCounter("post_commit_failures")
+
+// recoverPostCommit, deferred by a side effect of an already committed
+// change, keeps a panic in it from failing the operation: the money has
+// moved, so the caller must still get its result. The failure is logged and
+// counted instead.
+func recoverPostCommit(what, id string) {
+	if r := recover(); r != nil {
+		postCommitFailures.Add(1)
+		log.Printf("Post-commit %s for %s failed: %v", what, id, r)
+	}
+}
+
+// notify queues the notifications of a committed change, identified by id.
+// Every notification the service sends goes through it: enqueueing never
+// blocks for long or fails the change, a panic in it is recovered and
+// counted, and an undeliverable notification is dead-lettered.
+func (s *LedgerService) notify(id string, notifications ...account.Notification) {
+	defer recoverPostCommit("notification", id)
+	if s.notifier == nil {
+		return
+	}
+	for _, n := range notifications {
+		s.notifier.Enqueue(n)
+	}
+}
+
+// notifyTransfer queues debit and credit notifications for a committed
+// transfer. ctx supplies the acting user.
+func (s *LedgerService) notifyTransfer(ctx context.Context, txID string, from, to *account.Account, amount int64, currency string) {
+	s.notify(txID, account.Notification{
+		ID:        txID + ":debit",
+		AccountID: from.ID,
+		Channel:   from.NotificationChannel,
+		ActorID:   auditActor(ctx),
+		Message:   fmt.Sprintf("Debited %d %s (transaction %s)", amount, currency, txID),
+	}, account.Notification{
+		ID:        txID + ":credit",
+		AccountID: to.ID,
+		Channel:   to.NotificationChannel,
+		ActorID:   auditActor(ctx),
+		Message:   fmt.Sprintf("Credited %d %s (transaction %s)", amount, currency, txID),
+	})
+}
+
+// checkCategory normalizes a transfer category to lower case and rejects
+// one that is too long or, when a set is configured, not in it
+func (s *LedgerService) checkCategory(category string) (string, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		return "", nil
+	}
+	if len(category) > maxCategoryLength {
+		return "", fmt.Errorf("category is longer than %d bytes: %w", maxCategoryLength, account.ErrInvalidCategory)
+	}
+	if s.categories != nil && !s.categories[category] {
+		return "", fmt.Errorf("category %q is not allowed: %w", category, account.ErrInvalidCategory)
+	}
+	return category, nil
+}
+
+// checkDenomination rejects an amount that is not a multiple of the
+// currency's configured denomination step
+func (s *LedgerService) checkDenomination(currency string, amount int64) error {
+	step := s.denominations[currency]
+	if step > 1 && amount%step != 0 {
+		return fmt.Errorf("%d %s must be a multiple of %d: %w", amount, currency, step, account.ErrInvalidDenomination)
+	}
+	return nil
+}
+
+// publishTransfer hands a committed transfer to the event subscribers
+func (s *LedgerService) publishTransfer(t *account.Transaction) {
+	defer recoverPostCommit("event publication", t.ID)
+	s.events.publish(account.TransferEvent{
+		TransactionID: t.ID,
+		Kind:          t.Kind,
+		FromID:        t.FromAccountID,
+		ToID:          t.ToAccountID,
+		Amount:        t.AmountCents,
+		Currency:      t.Currency,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// invalidate drops cached balances for accounts whose state has changed
+func (s *LedgerService) invalidate(ids ...string) {
+	defer recoverPostCommit("cache invalidation", strings.Join(ids, ","))
+	if s.cache != nil {
+		s.cache.invalidate(ids...)
+	}
+}
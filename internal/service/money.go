@@ -0,0 +1,150 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+
+	"apex-ledger/internal/account"
+)
+
+// checkedAdd returns a+b, or account.ErrAmountOverflow if the result doesn't
+// fit in an int64. Use it wherever cents are accumulated in Go rather than in
+// Postgres, where numeric arithmetic can't wrap.
+func checkedAdd(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, account.ErrAmountOverflow)
+	}
+	return a + b, nil
+}
+
+// checkedSub returns a-b, or account.ErrAmountOverflow if the result doesn't
+// fit in an int64
+func checkedSub(a, b int64) (int64, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, fmt.Errorf("%d - %d: %w", a, b, account.ErrAmountOverflow)
+	}
+	return a - b, nil
+}
+
+// checkAmount rejects a zero or negative amount of money to move. Every
+// operation that moves a client-given amount one way (transfers, quotes,
+// deposits) checks it here, so they all fail alike with
+// account.ErrNonPositiveAmount; signed adjustments and "0 means the rest"
+// reversals check their amounts themselves.
+func checkAmount(cents int64) error {
+	if cents <= 0 {
+		return fmt.Errorf("%d: %w", cents, account.ErrNonPositiveAmount)
+	}
+	return nil
+}
+
+// Money is an amount in cents of one currency. Arithmetic and comparison
+// between two Money values fail with an account.CurrencyMismatchError
+// instead of mixing currencies, and with account.ErrAmountOverflow instead
+// of wrapping, so balance and amount math in the service goes through it
+// rather than through bare int64 cents. Storage and the API keep cents and
+// currency as separate fields.
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+// balanceOf is an account's balance as Money
+func balanceOf(acc *account.Account) Money {
+	return Money{Cents: acc.BalanceCents, Currency: acc.Currency}
+}
+
+// availableOf is what an account can spend, overdraft included, as Money
+func availableOf(acc *account.Account) Money {
+	return Money{Cents: acc.AvailableCents(), Currency: acc.Currency}
+}
+
+// checkFunds rejects spending amount from acc beyond its balance and any
+// overdraft. A liability account has no such floor: debiting it is how its
+// balance goes below zero, which is where it lives.
+func checkFunds(acc *account.Account, amount Money) error {
+	if acc.Type == account.AccountTypeLiability {
+		return balanceOf(acc).checkCurrency(amount)
+	}
+	covered, err := availableOf(acc).Covers(amount)
+	if err != nil {
+		return err
+	}
+	if !covered {
+		return &account.InsufficientFundsError{AccountID: acc.ID, BalanceCents: acc.BalanceCents, OverdraftLimitCents: acc.OverdraftLimitCents, RequiredCents: amount.Cents}
+	}
+	return nil
+}
+
+// checkBalanceSign rejects leaving acc at balance when its type forbids that
+// side of zero: below it for an asset account, above it for a liability
+// account. Callers check the balance the locked update returned, so the
+// overdraft limit can't let an asset account slip under zero.
+func checkBalanceSign(acc *account.Account, balance int64) error {
+	if (acc.Type == account.AccountTypeAsset && balance < 0) ||
+		(acc.Type == account.AccountTypeLiability && balance > 0) {
+		return fmt.Errorf("%s account %s would have balance %d: %w", acc.Type, acc.ID, balance, account.ErrBalanceSign)
+	}
+	return nil
+}
+
+// checkBalanceSigns applies checkBalanceSign to both sides of a transfer
+func checkBalanceSigns(from *account.Account, fromBalance int64, to *account.Account, toBalance int64) error {
+	if err := checkBalanceSign(from, fromBalance); err != nil {
+		return err
+	}
+	return checkBalanceSign(to, toBalance)
+}
+
+// IsSameCurrency reports whether m and o are in the same currency
+func (m Money) IsSameCurrency(o Money) bool {
+	return m.Currency == o.Currency
+}
+
+// Add returns m+o
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	cents, err := checkedAdd(m.Cents, o.Cents)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Cents: cents, Currency: m.Currency}, nil
+}
+
+// Sub returns m-o
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	cents, err := checkedSub(m.Cents, o.Cents)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Cents: cents, Currency: m.Currency}, nil
+}
+
+// Compare returns -1, 0 or +1 as m is less than, equal to or greater than o
+func (m Money) Compare(o Money) (int, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+	return cmp.Compare(m.Cents, o.Cents), nil
+}
+
+// Covers reports whether m is at least o, such as available funds covering
+// an amount
+func (m Money) Covers(o Money) (bool, error) {
+	c, err := m.Compare(o)
+	return c >= 0, err
+}
+
+// checkCurrency rejects combining m with an amount in another currency
+func (m Money) checkCurrency(o Money) error {
+	if !m.IsSameCurrency(o) {
+		return &account.CurrencyMismatchError{FromCurrency: m.Currency, ToCurrency: o.Currency}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"apex-ledger/internal/account"
+)
+
+// panickyQueue is a notification queue whose Enqueue panics for the IDs
+// in fail and records the rest
+type panickyQueue struct {
+	mu     sync.Mutex
+	fail   map[string]bool
+	queued []account.Notification
+}
+
+func (q *panickyQueue) Enqueue(n account.Notification) {
+	if q.fail == nil || q.fail[n.ID] {
+		panic("enqueue failed")
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queued = append(q.queued, n)
+}
+
+func (q *panickyQueue) Stop(ctx context.Context) error { return nil }
+
+func TestNotificationPanicAfterCommit(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(svc *LedgerService) (string, error)
+	}{
+		{
+			name: "transfer",
+			run: func(svc *LedgerService) (string, error) {
+				return svc.PerformTransfer(context.Background(), "acc-a", "acc-b", 100)
+			},
+		},
+		{
+			name: "batch transfer",
+			run: func(svc *LedgerService) (string, error) {
+				ids, err := svc.BatchTransfer(context.Background(), []account.TransferEntry{{FromID: "acc-a", ToID: "acc-b", AmountCents: 100}})
+				if err != nil {
+					return "", err
+				}
+				return ids[0], nil
+			},
+		},
+		{
+			name: "deposit",
+			run: func(svc *LedgerService) (string, error) {
+				t, _, err := svc.Deposit(context.Background(), "acc-a", 100, "USD", "", "system")
+				if err != nil {
+					return "", err
+				}
+				return t.ID, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTransferStore()
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			svc := NewLedgerService(store, db, nil)
+			svc.notifier = &panickyQueue{}
+			failures := postCommitFailures.Value()
+
+			txID, err := tt.run(svc)
+			if err != nil {
+				t.Fatalf("committed operation failed: %v", err)
+			}
+			if txID == "" {
+				t.Fatal("no transaction ID returned")
+			}
+			if got := postCommitFailures.Value() - failures; got != 1 {
+				t.Fatalf("counted %d post-commit failures, want 1", got)
+			}
+		})
+	}
+}
+
+// deadLetterStore serves TakeDeadLetters from a fixed list
+type deadLetterStore struct {
+	*memStore
+	letters []account.DeadLetter
+}
+
+func (s deadLetterStore) TakeDeadLetters(ctx context.Context, ids []int64, limit int) ([]account.DeadLetter, error) {
+	return s.letters, nil
+}
+
+func TestRetryDeadLettersSurvivesEnqueuePanic(t *testing.T) {
+	store := deadLetterStore{memStore: newMemStore(), letters: []account.DeadLetter{
+		{ID: 1, NotificationID: "n-1", AccountID: "acc-a"},
+		{ID: 2, NotificationID: "n-2", AccountID: "acc-a"},
+		{ID: 3, NotificationID: "n-3", AccountID: "acc-b"},
+	}}
+	db, _ := newMockDB(t)
+	svc := NewLedgerService(store, db, nil)
+	queue := &panickyQueue{fail: map[string]bool{"n-2": true}}
+	svc.notifier = queue
+
+	letters, err := svc.RetryDeadLetters(context.Background(), nil, 10)
+	if err != nil {
+		t.Fatalf("RetryDeadLetters: %v", err)
+	}
+	if len(letters) != 3 {
+		t.Fatalf("retried %d letters, want 3", len(letters))
+	}
+	if len(queue.queued) != 2 || queue.queued[0].ID != "n-1" || queue.queued[1].ID != "n-3" {
+		t.Fatalf("queued %v, want n-1 and n-3", queue.queued)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var (
+	overdraftRuns      = metrics.NewCounter("overdraft_penalty_runs")
+	overdraftPenalties = metrics.NewCounter("overdraft_penalties")
+)
+
+// overdraftReference is the external reference recorded on a penalty, making
+// each (day, account) pair chargeable only once
+func overdraftReference(day time.Time, accountID string) string {
+	return overdraftReferencePrefix(day) + accountID
+}
+
+func overdraftReferencePrefix(day time.Time) string {
+	return "overdraft:" + day.Format("2006-01-02") + ":"
+}
+
+// OverdraftPenaltyCharger debits a daily penalty from accounts that have been
+// overdrawn for longer than a grace period. Like InterestAccruer it charges
+// each account in its own transaction, once per day, so a restart mid-run
+// resumes with the accounts not yet charged.
+type OverdraftPenaltyCharger struct {
+	ledger      *LedgerService
+	accountRepo account.Store
+	rateBps     int64
+	grace       time.Duration
+	dayCount    DayCount
+	batchSize   int
+
+	// lastDone is the last day fully charged by this process
+	lastDone time.Time
+}
+
+// NewOverdraftPenaltyCharger creates a charger applying rateBps a year to
+// negative balances, once an account has been overdrawn for graceDays
+func NewOverdraftPenaltyCharger(ledger *LedgerService, accountRepo account.Store, rateBps int64, graceDays int, dayCount DayCount) *OverdraftPenaltyCharger {
+	return &OverdraftPenaltyCharger{
+		ledger:      ledger,
+		accountRepo: accountRepo,
+		rateBps:     rateBps,
+		grace:       time.Duration(graceDays) * 24 * time.Hour,
+		dayCount:    dayCount,
+		batchSize:   500,
+	}
+}
+
+// Run charges the most recent completed day immediately, then checks for a
+// new one every accrualCheckInterval, until ctx is cancelled
+func (c *OverdraftPenaltyCharger) Run(ctx context.Context) {
+	c.charge(ctx)
+
+	ticker := time.NewTicker(accrualCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.charge(ctx)
+		}
+	}
+}
+
+// charge debits the penalty for the most recently completed day from every
+// account still overdrawn that had been overdrawn for the grace period by
+// the end of that day
+func (c *OverdraftPenaltyCharger) charge(ctx context.Context) {
+	start, end := AccrualDaily.lastCompleted(time.Now())
+	if start.Equal(c.lastDone) {
+		return
+	}
+	overdraftRuns.Add(1)
+
+	overdrawnBy := end.Add(-c.grace)
+	prefix := overdraftReferencePrefix(start)
+	afterID := ""
+	charged, failed := 0, 0
+	for {
+		accounts, err := c.accountRepo.GetOverdrawnAccountsAfter(ctx, afterID, prefix, overdrawnBy, c.batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Overdraft penalties for %s failed: %v", start.Format("2006-01-02"), err)
+			}
+			return
+		}
+
+		for _, acc := range accounts {
+			t, err := c.ledger.ChargeOverdraftPenalty(ctx, acc.ID, start, end, overdrawnBy, c.rateBps, c.dayCount)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failed++
+				log.Printf("Overdraft penalty for account %s failed: %v", acc.ID, err)
+				continue
+			}
+			if t != nil {
+				charged++
+				overdraftPenalties.Add(1)
+			}
+		}
+
+		if len(accounts) < c.batchSize {
+			break
+		}
+		afterID = accounts[len(accounts)-1].ID
+	}
+
+	if charged > 0 || failed > 0 {
+		log.Printf("Overdraft penalties for %s: %d accounts charged, %d failed", start.Format("2006-01-02"), charged, failed)
+	}
+	// Failed accounts are retried on the next check
+	if failed == 0 {
+		c.lastDone = start
+	}
+}
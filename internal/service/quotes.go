@@ -0,0 +1,43 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"apex-ledger/internal/account"
+)
+
+// quoteStore holds issued conversion quotes until they expire.
+// Quotes are in-memory, so they don't survive a restart or span replicas.
+type quoteStore struct {
+	mu     sync.Mutex
+	quotes map[string]account.ConversionQuote
+}
+
+func newQuoteStore() *quoteStore {
+	return &quoteStore{quotes: make(map[string]account.ConversionQuote)}
+}
+
+// put stores q, purging expired quotes once the store grows large
+func (s *quoteStore) put(q account.ConversionQuote) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.quotes) >= sweepThreshold {
+		for id, existing := range s.quotes {
+			if now.After(existing.ExpiresAt) {
+				delete(s.quotes, id)
+			}
+		}
+	}
+	s.quotes[q.ID] = q
+}
+
+// get returns the quote with the given ID, whether or not it has expired
+func (s *quoteStore) get(id string) (account.ConversionQuote, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.quotes[id]
+	return q, ok
+}
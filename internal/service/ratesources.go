@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var (
+	rateCacheHits   = metrics.NewCounter("fx_rate_cache_hits")
+	rateCacheMisses = metrics.NewCounter("fx_rate_cache_misses")
+)
+
+// LoadRateFile reads a JSON object of rates keyed "FROM/TO", such as
+// {"USD/EUR": 0.92}, into a StaticRateProvider
+func LoadRateFile(path string) (*StaticRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate file: %w", err)
+	}
+	var rates map[string]float64
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("failed to parse rate file %s: %w", path, err)
+	}
+	for pair, rate := range rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate file %s: rate for %s must be positive, got %g", path, pair, rate)
+		}
+	}
+	return NewStaticRateProvider(rates), nil
+}
+
+// httpRateTimeout bounds a single request to an HTTP rate source
+const httpRateTimeout = 5 * time.Second
+
+// HTTPRateProvider fetches rates from an external FX API. It requests
+// "<endpoint>?from=USD&to=EUR" and reads the rate from a response of the form
+// {"rates": {"EUR": 0.92}}, as served by Frankfurter-style APIs. It fetches on
+// every call; wrap it in a CachingRateProvider.
+type HTTPRateProvider struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPRateProvider creates a provider querying endpoint
+func NewHTTPRateProvider(endpoint string) *HTTPRateProvider {
+	return &HTTPRateProvider{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: httpRateTimeout},
+	}
+}
+
+// Rate implements ExchangeRateProvider. A source that can't be reached or
+// answers with a server error fails with account.ErrRateUnavailable.
+func (p *HTTPRateProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	query := url.Values{"from": {from}, "to": {to}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build rate request: %w", err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("fetching %s/%s: %v: %w", from, to, err, account.ErrRateUnavailable)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity:
+		return 0, fmt.Errorf("no exchange rate for %s/%s", from, to)
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("fetching %s/%s: rate source returned %s: %w", from, to, resp.Status, account.ErrRateUnavailable)
+	}
+
+	var body struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("fetching %s/%s: malformed response: %v: %w", from, to, err, account.ErrRateUnavailable)
+	}
+	rate, ok := body.Rates[to]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate for %s/%s", from, to)
+	}
+	return rate, nil
+}
+
+// CachingRateProvider wraps another provider, remembering each currency
+// pair's rate for ttl. Errors are not cached, so a failed lookup is retried
+// on the next call.
+type CachingRateProvider struct {
+	next ExchangeRateProvider
+	ttl  time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]cachedRate
+}
+
+type cachedRate struct {
+	rate      float64
+	expiresAt time.Time
+}
+
+// NewCachingRateProvider wraps next with a cache holding rates for ttl
+func NewCachingRateProvider(next ExchangeRateProvider, ttl time.Duration) *CachingRateProvider {
+	return &CachingRateProvider{
+		next:    next,
+		ttl:     ttl,
+		entries: make(map[string]cachedRate),
+	}
+}
+
+// Rate implements ExchangeRateProvider
+func (p *CachingRateProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	pair := strings.ToUpper(from) + "/" + strings.ToUpper(to)
+
+	p.mu.RLock()
+	e, ok := p.entries[pair]
+	p.mu.RUnlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		rateCacheHits.Add(1)
+		return e.rate, nil
+	}
+	rateCacheMisses.Add(1)
+
+	rate, err := p.next.Rate(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	p.mu.Lock()
+	p.entries[pair] = cachedRate{rate: rate, expiresAt: time.Now().Add(p.ttl)}
+	p.mu.Unlock()
+	return rate, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var (
+	reconcileRuns          = metrics.NewCounter("reconciliation_runs")
+	reconcileDiscrepancies = metrics.NewCounter("reconciliation_discrepancies")
+)
+
+// Reconciler periodically recomputes every account's balance from its
+// transaction history and reports accounts whose stored balance disagrees.
+// It is a safety net against code paths that move money without recording
+// a transaction; it never modifies balances itself.
+type Reconciler struct {
+	accountRepo account.Store
+	interval    time.Duration
+	batchSize   int
+	quietPeriod time.Duration
+}
+
+// NewReconciler creates a reconciler that scans accounts in batches of
+// batchSize every interval, skipping accounts modified within quietPeriod
+func NewReconciler(accountRepo account.Store, interval time.Duration, batchSize int, quietPeriod time.Duration) *Reconciler {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &Reconciler{
+		accountRepo: accountRepo,
+		interval:    interval,
+		batchSize:   batchSize,
+		quietPeriod: quietPeriod,
+	}
+}
+
+// Run reconciles every interval until ctx is cancelled
+func (r *Reconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reconcile(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Reconciliation failed: %v", err)
+			}
+		}
+	}
+}
+
+// reconcile performs one full pass. Each batch is a single read-only
+// statement, so no row locks are held between batches.
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	reconcileRuns.Add(1)
+
+	afterID := ""
+	checked, mismatched := 0, 0
+	for {
+		checks, err := r.accountRepo.GetBalanceChecks(ctx, afterID, r.batchSize, r.quietPeriod)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range checks {
+			if c.RecentlyModified {
+				continue
+			}
+			checked++
+			if c.BalanceCents != c.ComputedCents {
+				mismatched++
+				reconcileDiscrepancies.Add(1)
+				log.Printf("Reconciliation: account %s balance %d differs from transaction history %d (diff %d)",
+					c.AccountID, c.BalanceCents, c.ComputedCents, c.BalanceCents-c.ComputedCents)
+			}
+		}
+
+		if len(checks) < r.batchSize {
+			break
+		}
+		afterID = checks[len(checks)-1].AccountID
+	}
+
+	log.Printf("Reconciliation complete: %d accounts checked, %d discrepancies", checked, mismatched)
+	return nil
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+// transferRetryBase is the pause before the first retry of a transfer; each
+// later retry doubles it. Every pause is jittered by up to half so that
+// transfers that collided don't collide again in lockstep.
+const transferRetryBase = 10 * time.Millisecond
+
+var transferRetries = metrics.NewCounter("transfer_retries")
+
+// retryConflicts runs attempt, which must begin and end its own transaction,
+// again while it fails with a deadlock or serialization failure, up to
+// s.transferRetries more times. Each attempt re-reads everything it locks, so
+// a retry sees the balances the conflicting transaction left behind. Inside
+// a request-scoped transaction there is nothing to retry: the failure has
+// aborted the caller's transaction, so it is returned for the caller to
+// handle.
+func (s *LedgerService) retryConflicts(ctx context.Context, attempt func() error) error {
+	_, joined := txFromContext(ctx)
+	for retry := 0; ; retry++ {
+		err := attempt()
+		if err == nil || joined || retry >= s.transferRetries || !account.IsSerializationFailure(err) {
+			return err
+		}
+		transferRetries.Add(1)
+		delay := transferRetryBase << retry
+		delay -= rand.N(delay/2 + 1)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
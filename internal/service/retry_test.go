@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/database"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	errSerialization = &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+	errDeadlock      = &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+)
+
+// failingAttempt returns an attempt func failing with errs in turn, then
+// succeeding, and the number of calls made
+func failingAttempt(errs ...error) (func() error, *int) {
+	calls := 0
+	return func() error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func TestRetryConflicts(t *testing.T) {
+	other := errors.New("insufficient funds")
+	tests := []struct {
+		name      string
+		retries   int
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "serialization failure then deadlock", retries: 3, errs: []error{errSerialization, errDeadlock}, wantCalls: 3},
+		{name: "wrapped conflict", retries: 1, errs: []error{errors.Join(errors.New("debit"), errDeadlock)}, wantCalls: 2},
+		{name: "retries exhausted", retries: 2, errs: []error{errDeadlock, errDeadlock, errSerialization}, wantErr: errSerialization, wantCalls: 3},
+		{name: "retries disabled", retries: 0, errs: []error{errDeadlock}, wantErr: errDeadlock, wantCalls: 1},
+		{name: "other errors are not retried", retries: 3, errs: []error{other}, wantErr: other, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewLedgerService(nil, nil, nil, WithTransferRetries(tt.retries))
+			attempt, calls := failingAttempt(tt.errs...)
+			err := svc.retryConflicts(context.Background(), attempt)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+			if *calls != tt.wantCalls {
+				t.Fatalf("made %d attempts, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryConflictsInsideCallerTx(t *testing.T) {
+	svc := NewLedgerService(nil, nil, nil, WithTransferRetries(3))
+	ctx := database.ContextWithTx(context.Background(), &sqlx.Tx{})
+	attempt, calls := failingAttempt(errDeadlock)
+	if err := svc.retryConflicts(ctx, attempt); !errors.Is(err, errDeadlock) {
+		t.Fatalf("got %v, want the deadlock", err)
+	}
+	if *calls != 1 {
+		t.Fatalf("retried %d times inside the caller's transaction", *calls-1)
+	}
+}
+
+// conflictOnceStore fails the first balance update, debit, credit or
+// penalty, with err. memStore can't roll back, so failing the first one keeps the aborted
+// attempt from leaving anything behind.
+type conflictOnceStore struct {
+	*memStore
+	err    error
+	failed bool
+}
+
+func (s *conflictOnceStore) conflict() error {
+	if s.failed {
+		return nil
+	}
+	s.failed = true
+	return s.err
+}
+
+func (s *conflictOnceStore) Debit(ctx context.Context, tx *sqlx.Tx, id string, amount int64) (int64, error) {
+	if err := s.conflict(); err != nil {
+		return 0, err
+	}
+	return s.memStore.Debit(ctx, tx, id, amount)
+}
+
+func (s *conflictOnceStore) ChargeOverdraftPenalty(ctx context.Context, tx *sqlx.Tx, id string, amount int64, overdrawnBy time.Time) (int64, bool, error) {
+	if err := s.conflict(); err != nil {
+		return 0, false, err
+	}
+	return s.memStore.ChargeOverdraftPenalty(ctx, tx, id, amount, overdrawnBy)
+}
+
+func (s *conflictOnceStore) Credit(ctx context.Context, tx *sqlx.Tx, id string, amount int64) (int64, error) {
+	if err := s.conflict(); err != nil {
+		return 0, err
+	}
+	return s.memStore.Credit(ctx, tx, id, amount)
+}
+
+func TestTransferRetriedAfterDeadlock(t *testing.T) {
+	store := &conflictOnceStore{memStore: newTransferStore(), err: errDeadlock}
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(store, db, nil, WithTransferRetries(1))
+
+	if _, err := svc.PerformTransfer(context.Background(), "acc-a", "acc-b", 100); err != nil {
+		t.Fatalf("PerformTransfer: %v", err)
+	}
+	if got := store.balance("acc-a"); got != 900 {
+		t.Fatalf("balance of acc-a = %d, want 900", got)
+	}
+	if len(store.txs) != 1 {
+		t.Fatalf("recorded %d transactions, want 1", len(store.txs))
+	}
+}
+
+func TestBatchTransferRetriedAfterConflict(t *testing.T) {
+	for _, conflict := range []error{errDeadlock, errSerialization} {
+		t.Run(conflict.(*pgconn.PgError).Code, func(t *testing.T) {
+			store := &conflictOnceStore{memStore: newTransferStore(), err: conflict}
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			svc := NewLedgerService(store, db, nil, WithTransferRetries(1))
+
+			txIDs, err := svc.BatchTransfer(context.Background(), []account.TransferEntry{
+				{FromID: "acc-a", ToID: "acc-b", AmountCents: 100},
+				{FromID: "acc-b", ToID: "acc-a", AmountCents: 30},
+			})
+			if err != nil {
+				t.Fatalf("BatchTransfer: %v", err)
+			}
+			if a, b := store.balance("acc-a"), store.balance("acc-b"); a != 930 || b != 1070 {
+				t.Fatalf("balances %d and %d, want 930 and 1070", a, b)
+			}
+			if len(store.txs) != 2 || store.txs[0].ID != txIDs[0] || store.txs[1].ID != txIDs[1] {
+				t.Fatalf("recorded %d transactions, want the 2 returned as %v", len(store.txs), txIDs)
+			}
+		})
+	}
+}
+
+func TestInterestAndPenaltyRetriedAfterConflict(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		accountID string
+		run       func(svc *LedgerService) (*account.Transaction, error)
+	}{
+		{name: "interest", accountID: "acc-a", run: func(svc *LedgerService) (*account.Transaction, error) {
+			return svc.AccrueInterest(context.Background(), "acc-a", day, day.AddDate(0, 1, 0), DayCountActual365)
+		}},
+		{name: "penalty", accountID: "acc-o", run: func(svc *LedgerService) (*account.Transaction, error) {
+			return svc.ChargeOverdraftPenalty(context.Background(), "acc-o", day, day.AddDate(0, 0, 1), day, 2000, DayCountActual365)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &conflictOnceStore{memStore: newMemStore(
+				&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 100000, InterestRateBps: 500},
+				&account.Account{ID: "acc-o", Currency: "USD", BalanceCents: -100000},
+			), err: errDeadlock}
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			mock.ExpectBegin()
+			mock.ExpectCommit()
+			svc := NewLedgerService(store, db, nil, WithTransferRetries(1))
+
+			record, err := tt.run(svc)
+			if err != nil {
+				t.Fatalf("got %v after one deadlock, want it retried", err)
+			}
+			if record == nil || len(store.txs) != 1 || store.txs[0].ID != record.ID {
+				t.Fatalf("recorded %d transactions, want the returned one once", len(store.txs))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"apex-ledger/internal/account"
+	"apex-ledger/internal/platform/metrics"
+)
+
+var snapshotRuns = metrics.NewCounter("balance_snapshot_runs")
+
+// snapshotSettle is how long after a checkpoint time the snapshot is taken,
+// so transfers stamped just before it have committed by then
+const snapshotSettle = 5 * time.Minute
+
+// Snapshotter periodically checkpoints every account's balance so as-of
+// balance queries only need to sum the transactions since the latest
+// checkpoint. Checkpoints fall on multiples of interval (UTC midnight for the
+// daily default).
+type Snapshotter struct {
+	accountRepo *account.Repository
+	interval    time.Duration
+}
+
+// NewSnapshotter creates a snapshotter that checkpoints balances every interval
+func NewSnapshotter(accountRepo *account.Repository, interval time.Duration) *Snapshotter {
+	return &Snapshotter{
+		accountRepo: accountRepo,
+		interval:    interval,
+	}
+}
+
+// Run takes any missed checkpoint immediately, then one every interval, until
+// ctx is cancelled
+func (s *Snapshotter) Run(ctx context.Context) {
+	s.snapshot(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.snapshot(ctx)
+		}
+	}
+}
+
+// snapshot checkpoints balances at the most recent settled checkpoint time
+func (s *Snapshotter) snapshot(ctx context.Context) {
+	snapshotRuns.Add(1)
+
+	takenAt := time.Now().Add(-snapshotSettle).UTC().Truncate(s.interval)
+	n, err := s.accountRepo.CreateBalanceSnapshots(ctx, takenAt)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Balance snapshot failed: %v", err)
+		}
+		return
+	}
+	if n > 0 {
+		log.Printf("Balance snapshot at %s: %d accounts", takenAt.Format(time.RFC3339), n)
+	}
+}
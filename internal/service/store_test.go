@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+// memStore is an in-memory account.Store holding just what the transfer
+// paths touch. It records the calls made on it so tests can assert how the
+// service drove it; methods it doesn't implement panic via the nil Store.
+type memStore struct {
+	account.Store
+
+	mu       sync.Mutex
+	accounts map[string]*account.Account
+	// locked lists the IDs read with GetAccountWithLock, in call order
+	locked []string
+	// advisory lists the IDs passed to LockAccountsAdvisory, in call order
+	advisory []string
+	debits   map[string][]int64
+	credits  map[string][]int64
+	txs      []*account.Transaction
+	audits   []*account.AuditEntry
+}
+
+func newMemStore(accs ...*account.Account) *memStore {
+	m := &memStore{
+		accounts: make(map[string]*account.Account),
+		debits:   make(map[string][]int64),
+		credits:  make(map[string][]int64),
+	}
+	for _, acc := range accs {
+		m.accounts[acc.ID] = acc
+	}
+	return m
+}
+
+func (m *memStore) get(id string) (*account.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	acc, ok := m.accounts[id]
+	if !ok {
+		return nil, fmt.Errorf("account %s: %w", id, account.ErrAccountNotFound)
+	}
+	cp := *acc
+	return &cp, nil
+}
+
+func (m *memStore) balance(id string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.accounts[id].BalanceCents
+}
+
+func (m *memStore) GetAccount(ctx context.Context, id string) (*account.Account, error) {
+	return m.get(id)
+}
+
+func (m *memStore) GetAccountsByIDs(ctx context.Context, ids []string) ([]account.Account, error) {
+	var accs []account.Account
+	for _, id := range ids {
+		if acc, err := m.get(id); err == nil {
+			accs = append(accs, *acc)
+		}
+	}
+	return accs, nil
+}
+
+func (m *memStore) GetAccountWithLock(ctx context.Context, tx *sqlx.Tx, id string) (*account.Account, error) {
+	m.mu.Lock()
+	m.locked = append(m.locked, id)
+	m.mu.Unlock()
+	return m.get(id)
+}
+
+func (m *memStore) GetAccountTx(ctx context.Context, tx *sqlx.Tx, id string) (*account.Account, error) {
+	return m.get(id)
+}
+
+func (m *memStore) LockAccountsAdvisory(ctx context.Context, tx *sqlx.Tx, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advisory = append(m.advisory, ids...)
+	return nil
+}
+
+func (m *memStore) LockHierarchy(ctx context.Context, tx *sqlx.Tx) error {
+	return nil
+}
+
+func (m *memStore) Debit(ctx context.Context, tx *sqlx.Tx, id string, amount int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	acc, ok := m.accounts[id]
+	if !ok {
+		return 0, fmt.Errorf("account %s: %w", id, account.ErrAccountNotFound)
+	}
+	acc.BalanceCents -= amount
+	m.debits[id] = append(m.debits[id], amount)
+	return acc.BalanceCents, nil
+}
+
+func (m *memStore) Credit(ctx context.Context, tx *sqlx.Tx, id string, amount int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	acc, ok := m.accounts[id]
+	if !ok {
+		return 0, fmt.Errorf("account %s: %w", id, account.ErrAccountNotFound)
+	}
+	acc.BalanceCents += amount
+	m.credits[id] = append(m.credits[id], amount)
+	return acc.BalanceCents, nil
+}
+
+func (m *memStore) ChargeOverdraftPenalty(ctx context.Context, tx *sqlx.Tx, id string, amount int64, overdrawnBy time.Time) (int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	acc, ok := m.accounts[id]
+	if !ok || acc.BalanceCents >= 0 {
+		return 0, false, nil
+	}
+	acc.BalanceCents -= amount
+	m.debits[id] = append(m.debits[id], amount)
+	return acc.BalanceCents, true, nil
+}
+
+func (m *memStore) RecordTransaction(ctx context.Context, tx *sqlx.Tx, t *account.Transaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txs = append(m.txs, t)
+	return nil
+}
+
+func (m *memStore) GetTransactionForUpdate(ctx context.Context, tx *sqlx.Tx, id string) (*account.Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range m.txs {
+		if t.ID == id {
+			cp := *t
+			return &cp, nil
+		}
+	}
+	return nil, fmt.Errorf("transaction %s: %w", id, account.ErrTransactionNotFound)
+}
+
+func (m *memStore) RecordAuditTx(ctx context.Context, tx *sqlx.Tx, e *account.AuditEntry) error {
+	return m.RecordAudit(ctx, e)
+}
+
+func (m *memStore) RecordAudit(ctx context.Context, e *account.AuditEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.audits = append(m.audits, e)
+	return nil
+}
+
+// newMockDB returns a sqlx handle over a sqlmock connection that fails the
+// test if its expectations aren't all met
+func newMockDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet database expectations: %v", err)
+		}
+		db.Close()
+	})
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	"apex-ledger/internal/platform/metrics"
+)
+
+var stripeWaits = metrics.NewCounter("account_stripe_waits")
+
+// accountStripes is an in-process striped lock over account IDs. Transfers
+// on the same account within one server queue here instead of at the
+// database, where each waiter would hold a connection and a transaction
+// while blocked on the row lock. It is only an optimization: accounts are
+// still locked in the database, which remains what keeps concurrent
+// transfers, including those from other servers, safe.
+type accountStripes struct {
+	// Each stripe is a one-slot semaphore, so waiting can honor ctx
+	stripes []chan struct{}
+}
+
+func newAccountStripes(n int) *accountStripes {
+	s := &accountStripes{stripes: make([]chan struct{}, n)}
+	for i := range s.stripes {
+		s.stripes[i] = make(chan struct{}, 1)
+	}
+	return s
+}
+
+// lock takes the stripes of ids in stripe order, each once, so two callers
+// with overlapping accounts can't deadlock, and returns the function that
+// releases them. It gives up with ctx's error if ctx ends while waiting.
+func (s *accountStripes) lock(ctx context.Context, ids ...string) (unlock func(), err error) {
+	idx := make([]int, 0, len(ids))
+	for _, id := range ids {
+		h := fnv.New32a()
+		h.Write([]byte(id))
+		idx = append(idx, int(h.Sum32()%uint32(len(s.stripes))))
+	}
+	slices.Sort(idx)
+	idx = slices.Compact(idx)
+
+	held := 0
+	unlock = func() {
+		for _, i := range idx[:held] {
+			<-s.stripes[i]
+		}
+	}
+	for _, i := range idx {
+		select {
+		case s.stripes[i] <- struct{}{}:
+		default:
+			stripeWaits.Add(1)
+			select {
+			case s.stripes[i] <- struct{}{}:
+			case <-ctx.Done():
+				unlock()
+				return nil, fmt.Errorf("waiting for account lock: %w", ctx.Err())
+			}
+		}
+		held++
+	}
+	return unlock, nil
+}
+
+// lockStripes takes the in-process stripes of the given accounts when
+// striping is enabled. It takes none inside a request-scoped transaction,
+// which may already hold database locks a stripe holder is waiting on.
+func (s *LedgerService) lockStripes(ctx context.Context, ids ...string) (unlock func(), err error) {
+	if s.stripes == nil {
+		return func() {}, nil
+	}
+	if _, ok := txFromContext(ctx); ok {
+		return func() {}, nil
+	}
+	return s.stripes.lock(ctx, ids...)
+}
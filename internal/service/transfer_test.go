@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// newTransferStore holds two funded USD accounts, acc-a and acc-b
+func newTransferStore() *memStore {
+	return newMemStore(
+		&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 1000},
+		&account.Account{ID: "acc-b", Currency: "USD", BalanceCents: 1000},
+	)
+}
+
+func TestTransferSetsLockTimeout(t *testing.T) {
+	store := newTransferStore()
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL lock_timeout = '1500ms'`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	svc := NewLedgerService(store, db, nil, WithLockTimeout(1500*time.Millisecond))
+
+	if _, err := svc.PerformTransfer(context.Background(), "acc-a", "acc-b", 100); err != nil {
+		t.Fatalf("PerformTransfer: %v", err)
+	}
+}
+
+func TestTransferWithoutLockTimeout(t *testing.T) {
+	db, mock := newMockDB(t)
+	// Any statement other than begin and commit fails the transfer
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(newTransferStore(), db, nil)
+
+	if _, err := svc.PerformTransfer(context.Background(), "acc-a", "acc-b", 100); err != nil {
+		t.Fatalf("PerformTransfer: %v", err)
+	}
+}
+
+func TestTransferLockTimeoutFailure(t *testing.T) {
+	store := newTransferStore()
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET LOCAL lock_timeout`).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+	svc := NewLedgerService(store, db, nil, WithLockTimeout(time.Second))
+
+	if _, err := svc.PerformTransfer(context.Background(), "acc-a", "acc-b", 100); err == nil {
+		t.Fatal("transfer succeeded without its lock timeout")
+	}
+	if len(store.locked) != 0 {
+		t.Fatalf("locked %v after the lock timeout failed to apply", store.locked)
+	}
+}
+
+func TestPendingAccountsCannotMoveMoney(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(svc *LedgerService) error
+	}{
+		{
+			name: "transfer",
+			run: func(svc *LedgerService) error {
+				_, err := svc.PerformTransfer(context.Background(), "acc-a", "acc-b", 100)
+				return err
+			},
+		},
+		{
+			name: "deposit",
+			run: func(svc *LedgerService) error {
+				_, _, err := svc.Deposit(context.Background(), "acc-b", 100, "USD", "", "admin-1")
+				return err
+			},
+		},
+		{
+			name: "reversal",
+			run: func(svc *LedgerService) error {
+				_, _, err := svc.ReverseTransfer(context.Background(), "tx-settled", 0, "refund", "admin-1")
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore(
+				&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 1000},
+				&account.Account{ID: "acc-b", Currency: "USD", BalanceCents: 1000, Status: account.AccountStatusPending},
+			)
+			// A transfer a->b settled before b was put back to pending
+			store.txs = append(store.txs, &account.Transaction{
+				ID: "tx-settled", FromAccountID: "acc-a", ToAccountID: "acc-b", AmountCents: 100, Currency: "USD", Kind: account.TransactionKindTransfer,
+			})
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectRollback()
+			svc := NewLedgerService(store, db, nil)
+
+			if err := tt.run(svc); !errors.Is(err, account.ErrAccountNotActive) {
+				t.Fatalf("got %v, want ErrAccountNotActive", err)
+			}
+			if len(store.debits) != 0 || len(store.credits) != 0 {
+				t.Fatalf("balances moved: debits %v, credits %v", store.debits, store.credits)
+			}
+		})
+	}
+}
+
+func TestNonPositiveAmountsRejected(t *testing.T) {
+	calls := map[string]func(svc *LedgerService, amount int64) error{
+		"transfer": func(svc *LedgerService, amount int64) error {
+			_, err := svc.PerformTransfer(context.Background(), "acc-a", "acc-b", amount)
+			return err
+		},
+		"batch transfer": func(svc *LedgerService, amount int64) error {
+			_, err := svc.BatchTransfer(context.Background(), []account.TransferEntry{{FromID: "acc-a", ToID: "acc-b", AmountCents: amount}})
+			return err
+		},
+		"deposit": func(svc *LedgerService, amount int64) error {
+			_, _, err := svc.Deposit(context.Background(), "acc-a", amount, "USD", "", "admin-1")
+			return err
+		},
+	}
+	for name, call := range calls {
+		for _, amount := range []int64{0, -1} {
+			t.Run(fmt.Sprintf("%s %d", name, amount), func(t *testing.T) {
+				// Rejected before a transaction is opened
+				db, _ := newMockDB(t)
+				svc := NewLedgerService(newTransferStore(), db, nil)
+				if err := call(svc, amount); !errors.Is(err, account.ErrNonPositiveAmount) {
+					t.Fatalf("got %v, want ErrNonPositiveAmount", err)
+				}
+			})
+		}
+	}
+}
+
+func TestSingleAccountMutationsLockInOrder(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		commit bool
+		run    func(svc *LedgerService) error
+	}{
+		{
+			name:   "deposit",
+			commit: true,
+			run: func(svc *LedgerService) error {
+				_, _, err := svc.Deposit(context.Background(), "acc-a", 100, "USD", "", "admin-1")
+				return err
+			},
+		},
+		{
+			name:   "adjust balance",
+			commit: true,
+			run: func(svc *LedgerService) error {
+				_, _, err := svc.AdjustBalance(context.Background(), "acc-a", -100, "correction", "admin-1")
+				return err
+			},
+		},
+		{
+			name:   "accrue interest",
+			commit: true,
+			run: func(svc *LedgerService) error {
+				_, err := svc.AccrueInterest(context.Background(), "acc-a", day, day.AddDate(0, 1, 0), DayCountActual365)
+				return err
+			},
+		},
+		{
+			name:   "overdraft penalty",
+			commit: true,
+			run: func(svc *LedgerService) error {
+				_, err := svc.ChargeOverdraftPenalty(context.Background(), "acc-o", day, day.AddDate(0, 0, 1), day, 2000, DayCountActual365)
+				return err
+			},
+		},
+		{
+			name: "set parent",
+			run: func(svc *LedgerService) error {
+				// The missing parent is locked before it's found missing
+				_, err := svc.SetParentAccount(context.Background(), "acc-o", "acc-missing")
+				if errors.Is(err, account.ErrParentNotFound) {
+					return nil
+				}
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemStore(
+				&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 100000, InterestRateBps: 500},
+				&account.Account{ID: "acc-o", Currency: "USD", BalanceCents: -100000},
+			)
+			db, mock := newMockDB(t)
+			mock.ExpectBegin()
+			if tt.commit {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+			svc := NewLedgerService(store, db, nil, WithLockStrategy(LockAdvisory))
+
+			if err := tt.run(svc); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			// Only lockAccountsInOrder takes advisory locks
+			if len(store.advisory) == 0 || len(store.locked) != 0 {
+				t.Fatalf("advisory locks %v, row locks %v; want the account locked by the strategy", store.advisory, store.locked)
+			}
+		})
+	}
+}
+
+func TestAdjustBalanceLocksOnce(t *testing.T) {
+	store := newMemStore(&account.Account{ID: "acc-a", Currency: "USD", BalanceCents: 1000, EventSeq: 4})
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(store, db, nil)
+
+	_, updated, err := svc.AdjustBalance(context.Background(), "acc-a", -300, "correction", "admin-1")
+	if err != nil {
+		t.Fatalf("AdjustBalance: %v", err)
+	}
+	if want := []string{"acc-a"}; !slices.Equal(store.locked, want) {
+		t.Errorf("locked %v, want %v", store.locked, want)
+	}
+	if updated.BalanceCents != 700 || updated.EventSeq != 5 {
+		t.Errorf("returned balance %d at seq %d, want 700 at seq 5", updated.BalanceCents, updated.EventSeq)
+	}
+}
+
+func TestAccountChangesFollowLockStrategy(t *testing.T) {
+	changes := map[string]func(svc *LedgerService) error{
+		"update": func(svc *LedgerService) error {
+			_, err := svc.UpdateAccount(context.Background(), "acc-x", "USD")
+			return err
+		},
+		"delete": func(svc *LedgerService) error {
+			return svc.DeleteAccount(context.Background(), "acc-x")
+		},
+		"activate": func(svc *LedgerService) error {
+			_, err := svc.ActivateAccount(context.Background(), "acc-x")
+			return err
+		},
+		"notification preference": func(svc *LedgerService) error {
+			_, err := svc.SetNotificationPreference(context.Background(), "acc-x", "")
+			return err
+		},
+		"sensitive": func(svc *LedgerService) error {
+			_, err := svc.SetAccountSensitive(context.Background(), "acc-x", true)
+			return err
+		},
+	}
+	strategies := map[string]LockStrategy{"row": LockRow, "advisory": LockAdvisory, "optimistic": LockOptimistic}
+	for name, change := range changes {
+		for strategyName, strategy := range strategies {
+			t.Run(name+" "+strategyName, func(t *testing.T) {
+				// The account is missing, so each change stops right after
+				// taking its lock
+				store := newMemStore()
+				db, mock := newMockDB(t)
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				svc := NewLedgerService(store, db, nil, WithLockStrategy(strategy))
+
+				if err := change(svc); !errors.Is(err, account.ErrAccountNotFound) {
+					t.Fatalf("got %v, want ErrAccountNotFound", err)
+				}
+				rowLocked, advisoryLocked := len(store.locked) == 1, len(store.advisory) == 1
+				if rowLocked != (strategy == LockRow) || advisoryLocked != (strategy == LockAdvisory) {
+					t.Fatalf("row locks %v, advisory locks %v under the %s strategy", store.locked, store.advisory, strategyName)
+				}
+			})
+		}
+	}
+}
+
+func TestTransfersThroughLiabilityAccount(t *testing.T) {
+	store := newMemStore(
+		&account.Account{ID: "acc-l", Currency: "USD", Type: account.AccountTypeLiability},
+		&account.Account{ID: "acc-s", Currency: "USD", BalanceCents: 1000},
+	)
+	db, mock := newMockDB(t)
+	for range 3 {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+	}
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	svc := NewLedgerService(store, db, nil)
+	ctx := context.Background()
+
+	// Paying out of the liability, with no overdraft limit, takes it below zero
+	if _, err := svc.PerformTransfer(ctx, "acc-l", "acc-s", 500); err != nil {
+		t.Fatalf("transfer out of the liability: %v", err)
+	}
+	if _, err := svc.PerformTransfer(ctx, "acc-s", "acc-l", 200); err != nil {
+		t.Fatalf("transfer into the liability: %v", err)
+	}
+	if got := store.balance("acc-l"); got != -300 {
+		t.Fatalf("liability balance = %d, want -300", got)
+	}
+	if _, err := svc.PerformTransfer(ctx, "acc-s", "acc-l", 300); err != nil {
+		t.Fatalf("settling the liability: %v", err)
+	}
+	// It still may not go above zero
+	if _, err := svc.PerformTransfer(ctx, "acc-s", "acc-l", 1); !errors.Is(err, account.ErrBalanceSign) {
+		t.Fatalf("crediting a settled liability got %v, want ErrBalanceSign", err)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"apex-ledger/internal/platform/database"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type txScopeKey struct{}
+
+// txScope is the request-scoped transaction WithTx shares between the
+// operations it runs, and the side effects deferred until it commits
+type txScope struct {
+	tx    *sqlx.Tx
+	hooks []func()
+}
+
+// WithTx runs fn inside a request-scoped transaction, carried by the context
+// fn receives. Service operations called with that context, and repository
+// reads made with it, join the transaction instead of opening their own, so
+// a composite operation commits or rolls back as one unit.
+//
+// The transaction is a locking one (configured isolation and lock timeout).
+// It commits when fn returns nil and rolls back otherwise. When ctx already
+// carries a transaction, fn simply joins it and the outermost WithTx decides
+// the outcome.
+func (s *LedgerService) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := s.beginLockingTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	scope := &txScope{tx: tx}
+	scopedCtx := context.WithValue(database.ContextWithTx(ctx, tx), txScopeKey{}, scope)
+	if err := fn(scopedCtx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	for _, hook := range scope.hooks {
+		runPostCommit(hook)
+	}
+	return nil
+}
+
+// runPostCommit runs a hook after its transaction committed; a panic in
+// it is logged rather than failing the committed operation
+func runPostCommit(hook func()) {
+	defer recoverPostCommit("hook", "committed transaction")
+	hook()
+}
+
+// txFromContext returns the request-scoped transaction ctx carries, if any
+func txFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	return database.TxFromContext(ctx)
+}
+
+// afterCommit defers fn, such as a cache invalidation or notification, until
+// the request-scoped transaction in ctx commits; it is dropped if the
+// transaction rolls back. Without a scoped transaction fn runs immediately.
+func afterCommit(ctx context.Context, fn func()) {
+	if scope, ok := ctx.Value(txScopeKey{}).(*txScope); ok {
+		scope.hooks = append(scope.hooks, fn)
+		return
+	}
+	fn()
+}
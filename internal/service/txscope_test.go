@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"apex-ledger/internal/account"
+)
+
+// scopedOperations runs a deposit, an adjustment and a batch transfer with
+// ctx, as one composite operation
+func scopedOperations(svc *LedgerService, ctx context.Context) error {
+	if _, _, err := svc.Deposit(ctx, "acc-a", 100, "USD", "", "admin-1"); err != nil {
+		return err
+	}
+	if _, _, err := svc.AdjustBalance(ctx, "acc-b", -50, "correction", "admin-1"); err != nil {
+		return err
+	}
+	_, err := svc.BatchTransfer(ctx, []account.TransferEntry{{FromID: "acc-a", ToID: "acc-b", AmountCents: 10}})
+	return err
+}
+
+func TestWithTxJoinsMoneyMovers(t *testing.T) {
+	store := newTransferStore()
+	db, mock := newMockDB(t)
+	// One transaction for all three operations
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	svc := NewLedgerService(store, db, nil, WithBalanceCache(time.Minute))
+	svc.cache.set(&account.Account{ID: "acc-a"})
+
+	err := svc.WithTx(context.Background(), func(ctx context.Context) error {
+		if err := scopedOperations(svc, ctx); err != nil {
+			return err
+		}
+		if _, ok := svc.cache.get("acc-a"); !ok {
+			t.Fatal("cache invalidated before the transaction committed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if len(store.txs) != 3 {
+		t.Fatalf("recorded %d transactions, want 3", len(store.txs))
+	}
+	if _, ok := svc.cache.get("acc-a"); ok {
+		t.Fatal("cache not invalidated after the commit")
+	}
+}
+
+func TestWithTxRollbackDropsSideEffects(t *testing.T) {
+	store := newTransferStore()
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	svc := NewLedgerService(store, db, nil, WithBalanceCache(time.Minute))
+	svc.cache.set(&account.Account{ID: "acc-a"})
+
+	abort := errors.New("abort")
+	err := svc.WithTx(context.Background(), func(ctx context.Context) error {
+		if err := scopedOperations(svc, ctx); err != nil {
+			return err
+		}
+		return abort
+	})
+	if !errors.Is(err, abort) {
+		t.Fatalf("got %v, want the abort", err)
+	}
+	if _, ok := svc.cache.get("acc-a"); !ok {
+		t.Fatal("cache invalidated though the transaction rolled back")
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"apex-ledger/internal/account"
+)
+
+// watchPollInterval is how often a balance watch checks the journal without
+// being woken, catching changes the event bus dropped or that were committed
+// by another server
+const watchPollInterval = 10 * time.Second
+
+// watchBatchSize is how many journal events a watch reads at a time
+const watchBatchSize = 500
+
+// WatchBalance sends send an update for every change to an account's
+// balance until ctx is done or send fails. The first update is a snapshot of
+// the current balance; each following one carries the change and the
+// balance after it, read from the account's ledger events in sequence
+// order, so updates are never skipped or reordered. The post-commit event
+// bus wakes the watch as soon as a change to the account commits.
+func (s *LedgerService) WatchBalance(ctx context.Context, accountID string, send func(account.BalanceUpdate) error) error {
+	if accountID == "" {
+		return fmt.Errorf("account ID cannot be empty")
+	}
+
+	// Subscribe before taking the snapshot, so nothing committed after it
+	// goes unnoticed
+	wake := make(chan struct{}, 1)
+	unsubscribe := s.Subscribe(func(ev account.TransferEvent) {
+		if ev.FromID == accountID || ev.ToID == accountID {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+
+	acc, err := s.accountRepo.GetAccount(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	balance, seq := acc.BalanceCents, acc.EventSeq
+	err = send(account.BalanceUpdate{
+		AccountID:    accountID,
+		BalanceCents: balance,
+		Currency:     acc.Currency,
+		Seq:          seq,
+		Snapshot:     true,
+		At:           acc.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+
+		for {
+			events, err := s.accountRepo.ReadLedgerEvents(ctx, accountID, seq, watchBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, ev := range events {
+				balance += ev.DeltaCents
+				if ev.BalanceAfter != nil {
+					balance = *ev.BalanceAfter
+				}
+				seq = ev.Seq
+				err := send(account.BalanceUpdate{
+					AccountID:     accountID,
+					BalanceCents:  balance,
+					Currency:      ev.Currency,
+					Seq:           ev.Seq,
+					DeltaCents:    ev.DeltaCents,
+					TransactionID: ev.TransactionID,
+					Kind:          ev.Kind,
+					At:            ev.CreatedAt,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			if len(events) < watchBatchSize {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,3 @@
+-- Support keyset pagination of an account's history on (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_transactions_from_account_created ON transactions(from_account_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_to_account_created ON transactions(to_account_id, created_at DESC, id DESC);
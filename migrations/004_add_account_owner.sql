@@ -0,0 +1,4 @@
+-- Accounts belong to an owner (customer) so one owner can hold several accounts
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS owner_id VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_accounts_owner ON accounts(owner_id);
@@ -0,0 +1,15 @@
+-- Adjustments and other ledger-external movements only touch one account,
+-- so either side of a transaction may be NULL (but not both)
+ALTER TABLE transactions ALTER COLUMN from_account_id DROP NOT NULL;
+ALTER TABLE transactions ALTER COLUMN to_account_id DROP NOT NULL;
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_has_account
+    CHECK (from_account_id IS NOT NULL OR to_account_id IS NOT NULL);
+
+-- Kind distinguishes transfers from adjustments; reason/actor_id are the audit trail
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS kind VARCHAR(32) NOT NULL DEFAULT 'transfer';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS actor_id VARCHAR(255) NOT NULL DEFAULT '';
+
+-- How far below zero an account may go; 0 means no overdraft
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS overdraft_limit_cents BIGINT NOT NULL DEFAULT 0
+    CHECK (overdraft_limit_cents >= 0);
@@ -0,0 +1,4 @@
+-- Balance of each side immediately after the transaction, for running-balance
+-- statements. NULL on rows recorded before this migration.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS from_balance_after BIGINT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS to_balance_after BIGINT;
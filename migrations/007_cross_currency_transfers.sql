@@ -0,0 +1,5 @@
+-- Cross-currency transfers record what the receiver got and the rate used.
+-- amount_cents/currency remain the sender's side.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS converted_amount_cents BIGINT;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS converted_currency VARCHAR(10);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS exchange_rate DOUBLE PRECISION;
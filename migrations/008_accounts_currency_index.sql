@@ -0,0 +1,3 @@
+-- Supports ListAccountsByCurrency (WHERE currency = $1 ORDER BY id) and the
+-- filtered export scan (id > $1 AND currency = $2)
+CREATE INDEX IF NOT EXISTS idx_accounts_currency_id ON accounts(currency, id);
@@ -0,0 +1,9 @@
+-- Track how much of each transfer has been reversed, and link reversal rows
+-- back to the transfer they undo
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversed_cents BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reverses_transaction_id VARCHAR(255) REFERENCES transactions(id);
+ALTER TABLE transactions ADD CONSTRAINT chk_transactions_reversed_cents
+    CHECK (reversed_cents >= 0 AND reversed_cents <= amount_cents);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_reverses ON transactions(reverses_transaction_id)
+    WHERE reverses_transaction_id IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Daily per-account balance checkpoints. As-of balance queries start from the
+-- latest snapshot at or before the target time and only sum transactions
+-- recorded after it.
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    account_id VARCHAR(255) NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    taken_at TIMESTAMP NOT NULL,
+    balance_cents BIGINT NOT NULL,
+    PRIMARY KEY (account_id, taken_at)
+);
+
+-- The sum-forward step uses the (account, created_at) indexes from 003
+
@@ -0,0 +1,5 @@
+-- Payment-processor reference of a deposit. Unique so a replayed webhook
+-- can't credit the same payment twice; NULL for everything else.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS external_reference VARCHAR(255);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_transactions_external_reference
+    ON transactions(external_reference) WHERE external_reference IS NOT NULL;
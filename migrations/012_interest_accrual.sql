@@ -0,0 +1,6 @@
+-- Annual interest rate in basis points (100 = 1%); 0 means the account earns
+-- no interest. Accruals are recorded as 'interest' transactions whose
+-- external_reference ("interest:<period start>:<account>") makes each period
+-- creditable only once.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS interest_rate_bps BIGINT NOT NULL DEFAULT 0
+    CHECK (interest_rate_bps >= 0);
@@ -0,0 +1,10 @@
+-- Optional parent for aggregate reporting over corporate structures. Money
+-- still moves between individual accounts; the hierarchy is only summed.
+-- Deleting a parent promotes its children to top-level accounts.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS parent_id VARCHAR(255)
+    REFERENCES accounts(id) ON DELETE SET NULL;
+ALTER TABLE accounts ADD CONSTRAINT chk_accounts_parent_not_self
+    CHECK (parent_id IS NULL OR parent_id <> id);
+
+-- Children of a parent, for the recursive aggregate walk
+CREATE INDEX IF NOT EXISTS idx_accounts_parent_id ON accounts(parent_id) WHERE parent_id IS NOT NULL;
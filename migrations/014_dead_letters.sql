@@ -0,0 +1,12 @@
+-- Notifications that exhausted their delivery attempts or were dropped from a
+-- full queue, kept for RetryDeadLetters. No foreign key on account_id: the
+-- record should outlive a deleted account.
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    notification_id VARCHAR(255) NOT NULL DEFAULT '',
+    account_id VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
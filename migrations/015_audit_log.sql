@@ -0,0 +1,17 @@
+-- Who did what: one row per mutating operation, successful or not. Successful
+-- operations write their row in the same transaction as the change itself.
+-- No foreign keys: entries must outlive deleted accounts and transactions.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id VARCHAR(255) NOT NULL,
+    operation VARCHAR(50) NOT NULL,
+    account_ids TEXT[] NOT NULL DEFAULT '{}',
+    transaction_id VARCHAR(255) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('success', 'failure')),
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log (actor_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_account_ids ON audit_log USING GIN (account_ids);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at);
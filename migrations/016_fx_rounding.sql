@@ -0,0 +1,4 @@
+-- Cross-currency transfers record how the converted amount was rounded:
+-- converted_amount_cents = amount_cents * exchange_rate + fx_rounding_adjustment.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_rounding VARCHAR(16);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_rounding_adjustment DOUBLE PRECISION;
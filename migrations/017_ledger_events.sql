@@ -0,0 +1,18 @@
+-- Append-only journal of balance changes for event-sourcing consumers.
+-- accounts.event_seq is the last sequence number issued to the account;
+-- incrementing it in the writing transaction, under the account's row lock,
+-- keeps each account's sequence gap-free. No foreign key on account_id: the
+-- journal should outlive a deleted account.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS event_seq BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS ledger_events (
+    account_id VARCHAR(255) NOT NULL,
+    seq BIGINT NOT NULL,
+    transaction_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    delta_cents BIGINT NOT NULL,
+    balance_after BIGINT,
+    currency VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, seq)
+);
@@ -0,0 +1,7 @@
+-- When an account's balance last went negative; NULL while it is zero or
+-- positive. Debits and credits maintain it, and the overdraft penalty job
+-- uses it to honour the grace period. Penalties are recorded as
+-- 'overdraft_penalty' transactions whose external_reference
+-- ("overdraft:<day>:<account>") makes each day chargeable only once.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS overdrawn_since TIMESTAMP;
+UPDATE accounts SET overdrawn_since = NOW() WHERE balance_cents < 0 AND overdrawn_since IS NULL;
@@ -0,0 +1,6 @@
+-- Lifecycle status of an account. New accounts start 'active' unless the
+-- server is configured (INITIAL_ACCOUNT_STATUS) to open them 'pending', e.g.
+-- until KYC completes; pending accounts can be read but can't transfer money
+-- until ActivateAccount makes them active.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('pending', 'active'));
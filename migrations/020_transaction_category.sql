@@ -0,0 +1,8 @@
+-- Client-assigned category of a transfer ("fees", "payroll", ...); NULL when
+-- none was given. Partial indexes serve an account's history filtered by
+-- category on each side, in the history's keyset order.
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS category VARCHAR(64);
+CREATE INDEX IF NOT EXISTS idx_transactions_from_account_category
+    ON transactions(from_account_id, category, created_at DESC, id DESC) WHERE category IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_to_account_category
+    ON transactions(to_account_id, category, created_at DESC, id DESC) WHERE category IS NOT NULL;
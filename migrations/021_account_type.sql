@@ -0,0 +1,10 @@
+-- Bookkeeping type of an account. 'standard' accounts (the default, and every
+-- existing account) are bounded only by their overdraft limit. An 'asset'
+-- account's balance may never go below zero and a 'liability' account's
+-- never above it, whatever overdraft it has; the service checks this under
+-- the account lock and the constraint backs it up.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS account_type VARCHAR(16) NOT NULL DEFAULT 'standard'
+    CHECK (account_type IN ('standard', 'asset', 'liability'));
+
+ALTER TABLE accounts ADD CONSTRAINT chk_accounts_balance_sign
+    CHECK ((account_type <> 'asset' OR balance_cents >= 0) AND (account_type <> 'liability' OR balance_cents <= 0));
@@ -0,0 +1,7 @@
+-- Preferred notification delivery channel per account. Empty means no
+-- preference: the notification goes to the server's default sender.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS notification_channel VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (notification_channel IN ('', 'email', 'sms', 'webhook'));
+
+-- Dead letters keep the channel so a retry goes to the same sender
+ALTER TABLE dead_letters ADD COLUMN IF NOT EXISTS channel VARCHAR(16) NOT NULL DEFAULT '';
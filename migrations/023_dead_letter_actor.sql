@@ -0,0 +1,3 @@
+-- Dead letters keep the user whose operation sent the notification, so a
+-- retry is still attributed to them
+ALTER TABLE dead_letters ADD COLUMN IF NOT EXISTS actor_id VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Sensitive accounts have their reads (GetBalance, GetAccount) audited as
+-- well as their writes
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS sensitive BOOLEAN NOT NULL DEFAULT false;
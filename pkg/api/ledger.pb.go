@@ -21,12 +21,74 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// TransferStatus is the lifecycle state of a transfer
+type TransferStatus int32
+
+const (
+	TransferStatus_TRANSFER_STATUS_UNSPECIFIED TransferStatus = 0
+	TransferStatus_TRANSFER_STATUS_PENDING     TransferStatus = 1 // Accepted but not yet applied to balances
+	TransferStatus_TRANSFER_STATUS_SETTLED     TransferStatus = 2 // Applied to both balances
+	TransferStatus_TRANSFER_STATUS_REVERSED    TransferStatus = 3 // Settled, then reversed in full
+	TransferStatus_TRANSFER_STATUS_FAILED      TransferStatus = 4 // Will not be applied
+)
+
+// Enum value maps for TransferStatus.
+var (
+	TransferStatus_name = map[int32]string{
+		0: "TRANSFER_STATUS_UNSPECIFIED",
+		1: "TRANSFER_STATUS_PENDING",
+		2: "TRANSFER_STATUS_SETTLED",
+		3: "TRANSFER_STATUS_REVERSED",
+		4: "TRANSFER_STATUS_FAILED",
+	}
+	TransferStatus_value = map[string]int32{
+		"TRANSFER_STATUS_UNSPECIFIED": 0,
+		"TRANSFER_STATUS_PENDING":     1,
+		"TRANSFER_STATUS_SETTLED":     2,
+		"TRANSFER_STATUS_REVERSED":    3,
+		"TRANSFER_STATUS_FAILED":      4,
+	}
+)
+
+func (x TransferStatus) Enum() *TransferStatus {
+	p := new(TransferStatus)
+	*p = x
+	return p
+}
+
+func (x TransferStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ledger_proto_enumTypes[0].Descriptor()
+}
+
+func (TransferStatus) Type() protoreflect.EnumType {
+	return &file_proto_ledger_proto_enumTypes[0]
+}
+
+func (x TransferStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferStatus.Descriptor instead.
+func (TransferStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{0}
+}
+
 type TransferRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FromAccountId string                 `protobuf:"bytes,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
 	ToAccountId   string                 `protobuf:"bytes,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
 	AmountCents   int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"` // Use cents to avoid floating point issues
 	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Optional: apply only if the sending account's sequence still equals this, otherwise ABORTED
+	ExpectedFromSequence *int64 `protobuf:"varint,5,opt,name=expected_from_sequence,json=expectedFromSequence,proto3,oneof" json:"expected_from_sequence,omitempty"`
+	Category             string `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"` // Optional: label for filtering history, e.g. "fees"; see TRANSACTION_CATEGORIES
+	// Optional: apply only if the sending/receiving account holds this currency, otherwise FAILED_PRECONDITION
+	FromCurrency  string `protobuf:"bytes,7,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string `protobuf:"bytes,8,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,12 +151,42 @@ func (x *TransferRequest) GetCurrency() string {
 	return ""
 }
 
+func (x *TransferRequest) GetExpectedFromSequence() int64 {
+	if x != nil && x.ExpectedFromSequence != nil {
+		return *x.ExpectedFromSequence
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
 type TransferResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId  string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // Deprecated: always "SUCCESS"; use transfer_status
+	TransferStatus TransferStatus         `protobuf:"varint,3,opt,name=transfer_status,json=transferStatus,proto3,enum=ledger.TransferStatus" json:"transfer_status,omitempty"`
+	FromSequence   int64                  `protobuf:"varint,4,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"` // The sending account's sequence after the transfer; set when expected_from_sequence was
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TransferResponse) Reset() {
@@ -141,6 +233,20 @@ func (x *TransferResponse) GetStatus() string {
 	return ""
 }
 
+func (x *TransferResponse) GetTransferStatus() TransferStatus {
+	if x != nil {
+		return x.TransferStatus
+	}
+	return TransferStatus_TRANSFER_STATUS_UNSPECIFIED
+}
+
+func (x *TransferResponse) GetFromSequence() int64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
 type BalanceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -237,30 +343,28 @@ func (x *BalanceResponse) GetCurrency() string {
 	return ""
 }
 
-// CRUD Request/Response messages
-type CreateAccountRequest struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                 // Optional: if not provided, UUID will be generated
-	InitialBalanceCents int64                  `protobuf:"varint,2,opt,name=initial_balance_cents,json=initialBalanceCents,proto3" json:"initial_balance_cents,omitempty"` // Default: 0
-	Currency            string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                                     // Required, e.g., "USD", "EUR"
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type BalanceAsOfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AsOf          string                 `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"` // RFC 3339 timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAccountRequest) Reset() {
-	*x = CreateAccountRequest{}
+func (x *BalanceAsOfRequest) Reset() {
+	*x = BalanceAsOfRequest{}
 	mi := &file_proto_ledger_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAccountRequest) String() string {
+func (x *BalanceAsOfRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAccountRequest) ProtoMessage() {}
+func (*BalanceAsOfRequest) ProtoMessage() {}
 
-func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *BalanceAsOfRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -272,56 +376,50 @@ func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
-func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BalanceAsOfRequest.ProtoReflect.Descriptor instead.
+func (*BalanceAsOfRequest) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateAccountRequest) GetId() string {
+func (x *BalanceAsOfRequest) GetAccountId() string {
 	if x != nil {
-		return x.Id
+		return x.AccountId
 	}
 	return ""
 }
 
-func (x *CreateAccountRequest) GetInitialBalanceCents() int64 {
-	if x != nil {
-		return x.InitialBalanceCents
-	}
-	return 0
-}
-
-func (x *CreateAccountRequest) GetCurrency() string {
+func (x *BalanceAsOfRequest) GetAsOf() string {
 	if x != nil {
-		return x.Currency
+		return x.AsOf
 	}
 	return ""
 }
 
-type CreateAccountResponse struct {
+type BalanceAsOfResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	BalanceCents  int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
 	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	AsOf          string                 `protobuf:"bytes,4,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	AsOfUnixMs    int64                  `protobuf:"varint,5,opt,name=as_of_unix_ms,json=asOfUnixMs,proto3" json:"as_of_unix_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAccountResponse) Reset() {
-	*x = CreateAccountResponse{}
+func (x *BalanceAsOfResponse) Reset() {
+	*x = BalanceAsOfResponse{}
 	mi := &file_proto_ledger_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAccountResponse) String() string {
+func (x *BalanceAsOfResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAccountResponse) ProtoMessage() {}
+func (*BalanceAsOfResponse) ProtoMessage() {}
 
-func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
+func (x *BalanceAsOfResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -333,60 +431,67 @@ func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAccountResponse.ProtoReflect.Descriptor instead.
-func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BalanceAsOfResponse.ProtoReflect.Descriptor instead.
+func (*BalanceAsOfResponse) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CreateAccountResponse) GetAccountId() string {
+func (x *BalanceAsOfResponse) GetAccountId() string {
 	if x != nil {
 		return x.AccountId
 	}
 	return ""
 }
 
-func (x *CreateAccountResponse) GetBalanceCents() int64 {
+func (x *BalanceAsOfResponse) GetBalanceCents() int64 {
 	if x != nil {
 		return x.BalanceCents
 	}
 	return 0
 }
 
-func (x *CreateAccountResponse) GetCurrency() string {
+func (x *BalanceAsOfResponse) GetCurrency() string {
 	if x != nil {
 		return x.Currency
 	}
 	return ""
 }
 
-func (x *CreateAccountResponse) GetStatus() string {
+func (x *BalanceAsOfResponse) GetAsOf() string {
 	if x != nil {
-		return x.Status
+		return x.AsOf
 	}
 	return ""
 }
 
-type GetAccountRequest struct {
+func (x *BalanceAsOfResponse) GetAsOfUnixMs() int64 {
+	if x != nil {
+		return x.AsOfUnixMs
+	}
+	return 0
+}
+
+type BatchGetBalanceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountIds    []string               `protobuf:"bytes,1,rep,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"` // Up to 1000 IDs; duplicates are ignored
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAccountRequest) Reset() {
-	*x = GetAccountRequest{}
+func (x *BatchGetBalanceRequest) Reset() {
+	*x = BatchGetBalanceRequest{}
 	mi := &file_proto_ledger_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAccountRequest) String() string {
+func (x *BatchGetBalanceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAccountRequest) ProtoMessage() {}
+func (*BatchGetBalanceRequest) ProtoMessage() {}
 
-func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetBalanceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,43 +503,41 @@ func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
-func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetBalanceRequest) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetAccountRequest) GetAccountId() string {
+func (x *BatchGetBalanceRequest) GetAccountIds() []string {
 	if x != nil {
-		return x.AccountId
+		return x.AccountIds
 	}
-	return ""
+	return nil
 }
 
-type GetAccountResponse struct {
+type AccountBalance struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	BalanceCents  int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
 	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAccountResponse) Reset() {
-	*x = GetAccountResponse{}
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
 	mi := &file_proto_ledger_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAccountResponse) String() string {
+func (x *AccountBalance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAccountResponse) ProtoMessage() {}
+func (*AccountBalance) ProtoMessage() {}
 
-func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -446,68 +549,55 @@ func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
-func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetAccountResponse) GetAccountId() string {
+func (x *AccountBalance) GetAccountId() string {
 	if x != nil {
 		return x.AccountId
 	}
 	return ""
 }
 
-func (x *GetAccountResponse) GetBalanceCents() int64 {
+func (x *AccountBalance) GetBalanceCents() int64 {
 	if x != nil {
 		return x.BalanceCents
 	}
 	return 0
 }
 
-func (x *GetAccountResponse) GetCurrency() string {
+func (x *AccountBalance) GetCurrency() string {
 	if x != nil {
 		return x.Currency
 	}
 	return ""
 }
 
-func (x *GetAccountResponse) GetCreatedAt() string {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return ""
-}
-
-func (x *GetAccountResponse) GetUpdatedAt() string {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return ""
-}
-
-type UpdateAccountRequest struct {
+type BatchGetBalanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"` // Optional: update currency
+	Balances      []*AccountBalance      `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`                            // In request order
+	NotFoundIds   []string               `protobuf:"bytes,2,rep,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"` // Well-formed requested IDs with no account
+	InvalidIds    []string               `protobuf:"bytes,3,rep,name=invalid_ids,json=invalidIds,proto3" json:"invalid_ids,omitempty"`      // Requested IDs that don't match the account ID format; not looked up
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAccountRequest) Reset() {
-	*x = UpdateAccountRequest{}
+func (x *BatchGetBalanceResponse) Reset() {
+	*x = BatchGetBalanceResponse{}
 	mi := &file_proto_ledger_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountRequest) String() string {
+func (x *BatchGetBalanceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountRequest) ProtoMessage() {}
+func (*BatchGetBalanceResponse) ProtoMessage() {}
 
-func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetBalanceResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -519,48 +609,58 @@ func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetBalanceResponse) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateAccountRequest) GetAccountId() string {
+func (x *BatchGetBalanceResponse) GetBalances() []*AccountBalance {
 	if x != nil {
-		return x.AccountId
+		return x.Balances
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateAccountRequest) GetCurrency() string {
+func (x *BatchGetBalanceResponse) GetNotFoundIds() []string {
 	if x != nil {
-		return x.Currency
+		return x.NotFoundIds
 	}
-	return ""
+	return nil
 }
 
-type UpdateAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BatchGetBalanceResponse) GetInvalidIds() []string {
+	if x != nil {
+		return x.InvalidIds
+	}
+	return nil
 }
 
-func (x *UpdateAccountResponse) Reset() {
-	*x = UpdateAccountResponse{}
+// CRUD Request/Response messages
+type CreateAccountRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                 // Optional: if not provided, UUID will be generated
+	InitialBalanceCents int64                  `protobuf:"varint,2,opt,name=initial_balance_cents,json=initialBalanceCents,proto3" json:"initial_balance_cents,omitempty"` // Default: 0
+	Currency            string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                                     // e.g., "USD", "EUR"; required unless the server sets DEFAULT_CURRENCY
+	OwnerId             string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                                        // Optional: defaults to the authenticated caller
+	AccountType         string                 `protobuf:"bytes,5,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`                            // Optional: "standard" (default), "asset" (never below zero) or "liability" (never above zero)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
 	mi := &file_proto_ledger_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAccountResponse) String() string {
+func (x *CreateAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountResponse) ProtoMessage() {}
+func (*CreateAccountRequest) ProtoMessage() {}
 
-func (x *UpdateAccountResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -572,53 +672,73 @@ func (x *UpdateAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAccountResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateAccountResponse) GetAccountId() string {
+func (x *CreateAccountRequest) GetId() string {
 	if x != nil {
-		return x.AccountId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateAccountResponse) GetCurrency() string {
+func (x *CreateAccountRequest) GetInitialBalanceCents() int64 {
+	if x != nil {
+		return x.InitialBalanceCents
+	}
+	return 0
+}
+
+func (x *CreateAccountRequest) GetCurrency() string {
 	if x != nil {
 		return x.Currency
 	}
 	return ""
 }
 
-func (x *UpdateAccountResponse) GetStatus() string {
+func (x *CreateAccountRequest) GetOwnerId() string {
 	if x != nil {
-		return x.Status
+		return x.OwnerId
 	}
 	return ""
 }
 
-type DeleteAccountRequest struct {
+func (x *CreateAccountRequest) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+type CreateAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	BalanceCents  int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	AccountStatus string                 `protobuf:"bytes,6,opt,name=account_status,json=accountStatus,proto3" json:"account_status,omitempty"` // "active", or "pending" until ActivateAccount
+	AccountType   string                 `protobuf:"bytes,7,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAccountRequest) Reset() {
-	*x = DeleteAccountRequest{}
+func (x *CreateAccountResponse) Reset() {
+	*x = CreateAccountResponse{}
 	mi := &file_proto_ledger_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAccountRequest) String() string {
+func (x *CreateAccountResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountRequest) ProtoMessage() {}
+func (*CreateAccountResponse) ProtoMessage() {}
 
-func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_ledger_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -630,41 +750,4669 @@ func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAccountResponse.ProtoReflect.Descriptor instead.
+func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
 	return file_proto_ledger_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *DeleteAccountRequest) GetAccountId() string {
+func (x *CreateAccountResponse) GetAccountId() string {
 	if x != nil {
 		return x.AccountId
 	}
 	return ""
 }
 
-type DeleteAccountResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateAccountResponse) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
 }
 
-func (x *DeleteAccountResponse) Reset() {
-	*x = DeleteAccountResponse{}
-	mi := &file_proto_ledger_proto_msgTypes[11]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateAccountResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
 }
 
-func (x *DeleteAccountResponse) String() string {
+func (x *CreateAccountResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateAccountResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *CreateAccountResponse) GetAccountStatus() string {
+	if x != nil {
+		return x.AccountStatus
+	}
+	return ""
+}
+
+func (x *CreateAccountResponse) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+type GetAccountRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TimestampFormat string                 `protobuf:"bytes,2,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: "rfc3339", "rfc3339nano", "datetime" or a Go layout; always UTC
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetAccountRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+type GetAccountResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AccountId           string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	BalanceCents        int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
+	Currency            string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	OwnerId             string                 `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	CreatedAtUnixMs     int64                  `protobuf:"varint,7,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	UpdatedAtUnixMs     int64                  `protobuf:"varint,8,opt,name=updated_at_unix_ms,json=updatedAtUnixMs,proto3" json:"updated_at_unix_ms,omitempty"`
+	ParentId            string                 `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`                                   // Empty for a top-level account
+	AccountStatus       string                 `protobuf:"bytes,10,opt,name=account_status,json=accountStatus,proto3" json:"account_status,omitempty"`                   // "active", or "pending" until ActivateAccount
+	Sequence            int64                  `protobuf:"varint,11,opt,name=sequence,proto3" json:"sequence,omitempty"`                                                 // Advanced by every balance change; see TransferRequest.expected_from_sequence
+	AccountType         string                 `protobuf:"bytes,12,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`                         // "standard", "asset" or "liability"
+	NotificationChannel string                 `protobuf:"bytes,13,opt,name=notification_channel,json=notificationChannel,proto3" json:"notification_channel,omitempty"` // Empty when the account has no preference
+	Sensitive           bool                   `protobuf:"varint,14,opt,name=sensitive,proto3" json:"sensitive,omitempty"`                                               // Reads of the account are audited
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetAccountResponse) Reset() {
+	*x = GetAccountResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountResponse) ProtoMessage() {}
+
+func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetAccountResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetUpdatedAtUnixMs() int64 {
+	if x != nil {
+		return x.UpdatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetAccountStatus() string {
+	if x != nil {
+		return x.AccountStatus
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *GetAccountResponse) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetNotificationChannel() string {
+	if x != nil {
+		return x.NotificationChannel
+	}
+	return ""
+}
+
+func (x *GetAccountResponse) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+type UpdateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"` // Optional: update currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountRequest) Reset() {
+	*x = UpdateAccountRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountRequest) ProtoMessage() {}
+
+func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type UpdateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAccountResponse) Reset() {
+	*x = UpdateAccountResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAccountResponse) ProtoMessage() {}
+
+func (x *UpdateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAccountResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateAccountResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UpdateAccountResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateAccountResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteAccountResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DeleteAccountResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ActivateAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivateAccountRequest) Reset() {
+	*x = ActivateAccountRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateAccountRequest) ProtoMessage() {}
+
+func (x *ActivateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateAccountRequest.ProtoReflect.Descriptor instead.
+func (*ActivateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ActivateAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ActivateAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountStatus string                 `protobuf:"bytes,2,opt,name=account_status,json=accountStatus,proto3" json:"account_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivateAccountResponse) Reset() {
+	*x = ActivateAccountResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivateAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateAccountResponse) ProtoMessage() {}
+
+func (x *ActivateAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateAccountResponse.ProtoReflect.Descriptor instead.
+func (*ActivateAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ActivateAccountResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ActivateAccountResponse) GetAccountStatus() string {
+	if x != nil {
+		return x.AccountStatus
+	}
+	return ""
+}
+
+type SetNotificationPreferenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"` // "email", "sms" or "webhook"; empty for the server default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNotificationPreferenceRequest) Reset() {
+	*x = SetNotificationPreferenceRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNotificationPreferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNotificationPreferenceRequest) ProtoMessage() {}
+
+func (x *SetNotificationPreferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNotificationPreferenceRequest.ProtoReflect.Descriptor instead.
+func (*SetNotificationPreferenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetNotificationPreferenceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetNotificationPreferenceRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type SetNotificationPreferenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNotificationPreferenceResponse) Reset() {
+	*x = SetNotificationPreferenceResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNotificationPreferenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNotificationPreferenceResponse) ProtoMessage() {}
+
+func (x *SetNotificationPreferenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNotificationPreferenceResponse.ProtoReflect.Descriptor instead.
+func (*SetNotificationPreferenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetNotificationPreferenceResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetNotificationPreferenceResponse) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type SetAccountSensitiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Sensitive     bool                   `protobuf:"varint,2,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAccountSensitiveRequest) Reset() {
+	*x = SetAccountSensitiveRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAccountSensitiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountSensitiveRequest) ProtoMessage() {}
+
+func (x *SetAccountSensitiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountSensitiveRequest.ProtoReflect.Descriptor instead.
+func (*SetAccountSensitiveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetAccountSensitiveRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetAccountSensitiveRequest) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+type SetAccountSensitiveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Sensitive     bool                   `protobuf:"varint,2,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAccountSensitiveResponse) Reset() {
+	*x = SetAccountSensitiveResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAccountSensitiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAccountSensitiveResponse) ProtoMessage() {}
+
+func (x *SetAccountSensitiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAccountSensitiveResponse.ProtoReflect.Descriptor instead.
+func (*SetAccountSensitiveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetAccountSensitiveResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetAccountSensitiveResponse) GetSensitive() bool {
+	if x != nil {
+		return x.Sensitive
+	}
+	return false
+}
+
+type ListAccountsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Limit           int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                                           // Optional: limit results (default: 100)
+	Offset          int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                                         // Optional: pagination offset (default: 0)
+	TimestampFormat string                 `protobuf:"bytes,3,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListAccountsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Accounts        []*GetAccountResponse  `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Total           int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                                              // Widened from int32; the wire encoding is compatible
+	PageSizeClamped bool                   `protobuf:"varint,3,opt,name=page_size_clamped,json=pageSizeClamped,proto3" json:"page_size_clamped,omitempty"` // The requested limit exceeded the server maximum and was reduced to it
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*GetAccountResponse {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAccountsResponse) GetPageSizeClamped() bool {
+	if x != nil {
+		return x.PageSizeClamped
+	}
+	return false
+}
+
+type TransactionHistoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PageSize        int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`                     // Optional: results per page (default: 50)
+	PageToken       string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                   // Optional: next_page_token from a previous response
+	TimestampFormat string                 `protobuf:"bytes,4,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	Category        string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`                                      // Optional: only transactions in this category
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransactionHistoryRequest) Reset() {
+	*x = TransactionHistoryRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionHistoryRequest) ProtoMessage() {}
+
+func (x *TransactionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*TransactionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TransactionHistoryRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *TransactionHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *TransactionHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *TransactionHistoryRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+func (x *TransactionHistoryRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type Transaction struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId         string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FromAccountId         string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId           string                 `protobuf:"bytes,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	AmountCents           int64                  `protobuf:"varint,4,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency              string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Kind                  string                 `protobuf:"bytes,7,opt,name=kind,proto3" json:"kind,omitempty"`                                                                // "transfer", "adjustment", "opening_balance", "reversal" or "deposit"
+	Reason                string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`                                                            // Set for adjustments and reversals
+	ConvertedAmountCents  int64                  `protobuf:"varint,9,opt,name=converted_amount_cents,json=convertedAmountCents,proto3" json:"converted_amount_cents,omitempty"` // Cross-currency only: amount credited to the receiver
+	ConvertedCurrency     string                 `protobuf:"bytes,10,opt,name=converted_currency,json=convertedCurrency,proto3" json:"converted_currency,omitempty"`            // Cross-currency only
+	ExchangeRate          float64                `protobuf:"fixed64,11,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`                         // Cross-currency only
+	CreatedAtUnixMs       int64                  `protobuf:"varint,12,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	ReversedCents         int64                  `protobuf:"varint,13,opt,name=reversed_cents,json=reversedCents,proto3" json:"reversed_cents,omitempty"`                          // Transfers only: how much has been reversed so far
+	ReversesTransactionId string                 `protobuf:"bytes,14,opt,name=reverses_transaction_id,json=reversesTransactionId,proto3" json:"reverses_transaction_id,omitempty"` // Reversals only: the transfer being reversed
+	ExternalReference     string                 `protobuf:"bytes,15,opt,name=external_reference,json=externalReference,proto3" json:"external_reference,omitempty"`               // Deposits only: the payment processor's reference
+	FxRounding            string                 `protobuf:"bytes,16,opt,name=fx_rounding,json=fxRounding,proto3" json:"fx_rounding,omitempty"`                                    // Cross-currency only: "half-even", "half-up" or "floor"
+	FxRoundingAdjustment  float64                `protobuf:"fixed64,17,opt,name=fx_rounding_adjustment,json=fxRoundingAdjustment,proto3" json:"fx_rounding_adjustment,omitempty"`  // Cross-currency only: converted_amount_cents - amount_cents * exchange_rate
+	Category              string                 `protobuf:"bytes,18,opt,name=category,proto3" json:"category,omitempty"`                                                          // Transfers only: the category given with the transfer, if any
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_proto_ledger_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Transaction) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *Transaction) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *Transaction) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Transaction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Transaction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Transaction) GetConvertedAmountCents() int64 {
+	if x != nil {
+		return x.ConvertedAmountCents
+	}
+	return 0
+}
+
+func (x *Transaction) GetConvertedCurrency() string {
+	if x != nil {
+		return x.ConvertedCurrency
+	}
+	return ""
+}
+
+func (x *Transaction) GetExchangeRate() float64 {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return 0
+}
+
+func (x *Transaction) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *Transaction) GetReversedCents() int64 {
+	if x != nil {
+		return x.ReversedCents
+	}
+	return 0
+}
+
+func (x *Transaction) GetReversesTransactionId() string {
+	if x != nil {
+		return x.ReversesTransactionId
+	}
+	return ""
+}
+
+func (x *Transaction) GetExternalReference() string {
+	if x != nil {
+		return x.ExternalReference
+	}
+	return ""
+}
+
+func (x *Transaction) GetFxRounding() string {
+	if x != nil {
+		return x.FxRounding
+	}
+	return ""
+}
+
+func (x *Transaction) GetFxRoundingAdjustment() float64 {
+	if x != nil {
+		return x.FxRoundingAdjustment
+	}
+	return 0
+}
+
+func (x *Transaction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type TransactionHistoryResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Transactions    []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextPageToken   string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`        // Empty when there are no more pages
+	PageSizeClamped bool                   `protobuf:"varint,3,opt,name=page_size_clamped,json=pageSizeClamped,proto3" json:"page_size_clamped,omitempty"` // The requested page_size exceeded the server maximum and was reduced to it
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransactionHistoryResponse) Reset() {
+	*x = TransactionHistoryResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionHistoryResponse) ProtoMessage() {}
+
+func (x *TransactionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*TransactionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TransactionHistoryResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *TransactionHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *TransactionHistoryResponse) GetPageSizeClamped() bool {
+	if x != nil {
+		return x.PageSizeClamped
+	}
+	return false
+}
+
+type ReadEventsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	FromSeq         int64                  `protobuf:"varint,2,opt,name=from_seq,json=fromSeq,proto3" json:"from_seq,omitempty"`                        // Return events after this sequence number; 0 starts from the beginning
+	Limit           int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                           // Optional: default 100, max 1000
+	TimestampFormat string                 `protobuf:"bytes,4,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReadEventsRequest) Reset() {
+	*x = ReadEventsRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadEventsRequest) ProtoMessage() {}
+
+func (x *ReadEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadEventsRequest.ProtoReflect.Descriptor instead.
+func (*ReadEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReadEventsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ReadEventsRequest) GetFromSeq() int64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *ReadEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ReadEventsRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+type LedgerEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Seq             int64                  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"` // Gap-free per account, starting at 1
+	TransactionId   string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Kind            string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`                                      // The transaction's kind, e.g. "transfer" or "deposit"
+	DeltaCents      int64                  `protobuf:"varint,5,opt,name=delta_cents,json=deltaCents,proto3" json:"delta_cents,omitempty"`       // Negative for debits
+	BalanceAfter    int64                  `protobuf:"varint,6,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"` // Unset for batch transfer legs
+	HasBalanceAfter bool                   `protobuf:"varint,7,opt,name=has_balance_after,json=hasBalanceAfter,proto3" json:"has_balance_after,omitempty"`
+	Currency        string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LedgerEvent) Reset() {
+	*x = LedgerEvent{}
+	mi := &file_proto_ledger_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEvent) ProtoMessage() {}
+
+func (x *LedgerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEvent.ProtoReflect.Descriptor instead.
+func (*LedgerEvent) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *LedgerEvent) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *LedgerEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *LedgerEvent) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LedgerEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LedgerEvent) GetDeltaCents() int64 {
+	if x != nil {
+		return x.DeltaCents
+	}
+	return 0
+}
+
+func (x *LedgerEvent) GetBalanceAfter() int64 {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return 0
+}
+
+func (x *LedgerEvent) GetHasBalanceAfter() bool {
+	if x != nil {
+		return x.HasBalanceAfter
+	}
+	return false
+}
+
+func (x *LedgerEvent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LedgerEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ReadEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*LedgerEvent         `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	LastSeq       int64                  `protobuf:"varint,2,opt,name=last_seq,json=lastSeq,proto3" json:"last_seq,omitempty"` // Pass as from_seq to continue; equals from_seq when there are no new events
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadEventsResponse) Reset() {
+	*x = ReadEventsResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadEventsResponse) ProtoMessage() {}
+
+func (x *ReadEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadEventsResponse.ProtoReflect.Descriptor instead.
+func (*ReadEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReadEventsResponse) GetEvents() []*LedgerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ReadEventsResponse) GetLastSeq() int64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+type ExportAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"` // Optional: only export accounts in this currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAccountsRequest) Reset() {
+	*x = ExportAccountsRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAccountsRequest) ProtoMessage() {}
+
+func (x *ExportAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ExportAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportAccountsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ExportAccountsChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // CSV rows (id,balance_cents,currency,created_at); the first chunk starts with the header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAccountsChunk) Reset() {
+	*x = ExportAccountsChunk{}
+	mi := &file_proto_ledger_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAccountsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAccountsChunk) ProtoMessage() {}
+
+func (x *ExportAccountsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAccountsChunk.ProtoReflect.Descriptor instead.
+func (*ExportAccountsChunk) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExportAccountsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Optional: only transactions touching this account (required for non-admins)
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`                    // Optional: only transactions sending or receiving this currency
+	Since         string                 `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                          // Optional: RFC 3339, inclusive
+	Until         string                 `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`                          // Optional: RFC 3339, exclusive
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTransactionsRequest) Reset() {
+	*x = ExportTransactionsRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTransactionsRequest) ProtoMessage() {}
+
+func (x *ExportTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ExportTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportTransactionsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ExportTransactionsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExportTransactionsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ExportTransactionsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+type ExportTransactionsLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // One JSON object terminated by a newline
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTransactionsLine) Reset() {
+	*x = ExportTransactionsLine{}
+	mi := &file_proto_ledger_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTransactionsLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTransactionsLine) ProtoMessage() {}
+
+func (x *ExportTransactionsLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTransactionsLine.ProtoReflect.Descriptor instead.
+func (*ExportTransactionsLine) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportTransactionsLine) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type WatchBalanceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TimestampFormat string                 `protobuf:"bytes,2,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchBalanceRequest) Reset() {
+	*x = WatchBalanceRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBalanceRequest) ProtoMessage() {}
+
+func (x *WatchBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBalanceRequest.ProtoReflect.Descriptor instead.
+func (*WatchBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WatchBalanceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *WatchBalanceRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+type BalanceUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	BalanceCents  int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"` // Balance after this change
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Seq           int64                  `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`                                         // Ledger event sequence number; matches ReadEvents
+	Snapshot      bool                   `protobuf:"varint,5,opt,name=snapshot,proto3" json:"snapshot,omitempty"`                               // True for the first message, the balance when the watch started
+	DeltaCents    int64                  `protobuf:"varint,6,opt,name=delta_cents,json=deltaCents,proto3" json:"delta_cents,omitempty"`         // Signed change; 0 for the snapshot
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Empty for the snapshot
+	Kind          string                 `protobuf:"bytes,8,opt,name=kind,proto3" json:"kind,omitempty"`
+	At            string                 `protobuf:"bytes,9,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceUpdate) Reset() {
+	*x = BalanceUpdate{}
+	mi := &file_proto_ledger_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceUpdate) ProtoMessage() {}
+
+func (x *BalanceUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceUpdate.ProtoReflect.Descriptor instead.
+func (*BalanceUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BalanceUpdate) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *BalanceUpdate) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *BalanceUpdate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BalanceUpdate) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *BalanceUpdate) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *BalanceUpdate) GetDeltaCents() int64 {
+	if x != nil {
+		return x.DeltaCents
+	}
+	return 0
+}
+
+func (x *BalanceUpdate) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *BalanceUpdate) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BalanceUpdate) GetAt() string {
+	if x != nil {
+		return x.At
+	}
+	return ""
+}
+
+type GetAccountsByOwnerRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId         string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`                         // Non-admin callers may only pass their own ID
+	Limit           int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                           // Optional: limit results (default: 100)
+	Offset          int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                                         // Optional: pagination offset (default: 0)
+	TimestampFormat string                 `protobuf:"bytes,4,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetAccountsByOwnerRequest) Reset() {
+	*x = GetAccountsByOwnerRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountsByOwnerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountsByOwnerRequest) ProtoMessage() {}
+
+func (x *GetAccountsByOwnerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountsByOwnerRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountsByOwnerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetAccountsByOwnerRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GetAccountsByOwnerRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAccountsByOwnerRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetAccountsByOwnerRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+// InsufficientFundsDetail is attached to FAILED_PRECONDITION statuses when a
+// debit exceeds the available balance
+type InsufficientFundsDetail struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AccountId           string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	BalanceCents        int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
+	RequiredCents       int64                  `protobuf:"varint,3,opt,name=required_cents,json=requiredCents,proto3" json:"required_cents,omitempty"`
+	ShortfallCents      int64                  `protobuf:"varint,4,opt,name=shortfall_cents,json=shortfallCents,proto3" json:"shortfall_cents,omitempty"` // required_cents - (balance_cents + overdraft_limit_cents)
+	OverdraftLimitCents int64                  `protobuf:"varint,5,opt,name=overdraft_limit_cents,json=overdraftLimitCents,proto3" json:"overdraft_limit_cents,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InsufficientFundsDetail) Reset() {
+	*x = InsufficientFundsDetail{}
+	mi := &file_proto_ledger_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsufficientFundsDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsufficientFundsDetail) ProtoMessage() {}
+
+func (x *InsufficientFundsDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsufficientFundsDetail.ProtoReflect.Descriptor instead.
+func (*InsufficientFundsDetail) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *InsufficientFundsDetail) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *InsufficientFundsDetail) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *InsufficientFundsDetail) GetRequiredCents() int64 {
+	if x != nil {
+		return x.RequiredCents
+	}
+	return 0
+}
+
+func (x *InsufficientFundsDetail) GetShortfallCents() int64 {
+	if x != nil {
+		return x.ShortfallCents
+	}
+	return 0
+}
+
+func (x *InsufficientFundsDetail) GetOverdraftLimitCents() int64 {
+	if x != nil {
+		return x.OverdraftLimitCents
+	}
+	return 0
+}
+
+type AdjustBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	DeltaCents    int64                  `protobuf:"varint,2,opt,name=delta_cents,json=deltaCents,proto3" json:"delta_cents,omitempty"` // Signed: negative debits, positive credits
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                            // Required: recorded on the adjustment transaction
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustBalanceRequest) Reset() {
+	*x = AdjustBalanceRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustBalanceRequest) ProtoMessage() {}
+
+func (x *AdjustBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AdjustBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AdjustBalanceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetDeltaCents() int64 {
+	if x != nil {
+		return x.DeltaCents
+	}
+	return 0
+}
+
+func (x *AdjustBalanceRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AdjustBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	BalanceCents  int64                  `protobuf:"varint,3,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"` // Balance after the adjustment
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustBalanceResponse) Reset() {
+	*x = AdjustBalanceResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustBalanceResponse) ProtoMessage() {}
+
+func (x *AdjustBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustBalanceResponse.ProtoReflect.Descriptor instead.
+func (*AdjustBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AdjustBalanceResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceResponse) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *AdjustBalanceResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type BulkAdjustBalanceChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // CSV rows (account_id,delta_cents,reason); the first chunk starts with the header
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdjustBalanceChunk) Reset() {
+	*x = BulkAdjustBalanceChunk{}
+	mi := &file_proto_ledger_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAdjustBalanceChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAdjustBalanceChunk) ProtoMessage() {}
+
+func (x *BulkAdjustBalanceChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAdjustBalanceChunk.ProtoReflect.Descriptor instead.
+func (*BulkAdjustBalanceChunk) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BulkAdjustBalanceChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AdjustmentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Zero-based position of the row in the CSV, header excluded
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Empty if the adjustment failed
+	BalanceCents  int64                  `protobuf:"varint,4,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`   // Balance after the adjustment
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                                      // Why the adjustment failed; empty on success
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustmentResult) Reset() {
+	*x = AdjustmentResult{}
+	mi := &file_proto_ledger_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustmentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustmentResult) ProtoMessage() {}
+
+func (x *AdjustmentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustmentResult.ProtoReflect.Descriptor instead.
+func (*AdjustmentResult) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AdjustmentResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *AdjustmentResult) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AdjustmentResult) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AdjustmentResult) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *AdjustmentResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkAdjustBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       int64                  `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	Failed        int64                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Results       []*AdjustmentResult    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"` // One per row, in CSV order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdjustBalanceResponse) Reset() {
+	*x = BulkAdjustBalanceResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAdjustBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAdjustBalanceResponse) ProtoMessage() {}
+
+func (x *BulkAdjustBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAdjustBalanceResponse.ProtoReflect.Descriptor instead.
+func (*BulkAdjustBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BulkAdjustBalanceResponse) GetApplied() int64 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+func (x *BulkAdjustBalanceResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkAdjustBalanceResponse) GetResults() []*AdjustmentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ImportAccountRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Optional: auto-generated if not provided
+	BalanceCents  int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`             // Optional: defaults to the caller
+	AccountType   string                 `protobuf:"bytes,5,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"` // Optional: "standard" (default), "asset" or "liability"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAccountRecord) Reset() {
+	*x = ImportAccountRecord{}
+	mi := &file_proto_ledger_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAccountRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAccountRecord) ProtoMessage() {}
+
+func (x *ImportAccountRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAccountRecord.ProtoReflect.Descriptor instead.
+func (*ImportAccountRecord) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImportAccountRecord) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ImportAccountRecord) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *ImportAccountRecord) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ImportAccountRecord) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ImportAccountRecord) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+type ImportFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Zero-based position of the record in the stream
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportFailure) Reset() {
+	*x = ImportFailure{}
+	mi := &file_proto_ledger_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFailure) ProtoMessage() {}
+
+func (x *ImportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFailure.ProtoReflect.Descriptor instead.
+func (*ImportFailure) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ImportFailure) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ImportFailure) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       int64                  `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	Failed        int64                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Failures      []*ImportFailure       `protobuf:"bytes,3,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportAccountsResponse) Reset() {
+	*x = ImportAccountsResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAccountsResponse) ProtoMessage() {}
+
+func (x *ImportAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ImportAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ImportAccountsResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportAccountsResponse) GetFailures() []*ImportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type StatementEntry struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Transaction       *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	BalanceAfterCents *int64                 `protobuf:"varint,2,opt,name=balance_after_cents,json=balanceAfterCents,proto3,oneof" json:"balance_after_cents,omitempty"` // Unset for transactions recorded before snapshots were stored
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StatementEntry) Reset() {
+	*x = StatementEntry{}
+	mi := &file_proto_ledger_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementEntry) ProtoMessage() {}
+
+func (x *StatementEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementEntry.ProtoReflect.Descriptor instead.
+func (*StatementEntry) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *StatementEntry) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *StatementEntry) GetBalanceAfterCents() int64 {
+	if x != nil && x.BalanceAfterCents != nil {
+		return *x.BalanceAfterCents
+	}
+	return 0
+}
+
+type AccountStatementResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Entries         []*StatementEntry      `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken   string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`        // Empty when there are no more pages
+	PageSizeClamped bool                   `protobuf:"varint,4,opt,name=page_size_clamped,json=pageSizeClamped,proto3" json:"page_size_clamped,omitempty"` // See TransactionHistoryResponse
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AccountStatementResponse) Reset() {
+	*x = AccountStatementResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStatementResponse) ProtoMessage() {}
+
+func (x *AccountStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStatementResponse.ProtoReflect.Descriptor instead.
+func (*AccountStatementResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AccountStatementResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetEntries() []*StatementEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AccountStatementResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetPageSizeClamped() bool {
+	if x != nil {
+		return x.PageSizeClamped
+	}
+	return false
+}
+
+type BatchTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*TransferRequest     `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"` // Applied all-or-nothing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTransferRequest) Reset() {
+	*x = BatchTransferRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTransferRequest) ProtoMessage() {}
+
+func (x *BatchTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTransferRequest.ProtoReflect.Descriptor instead.
+func (*BatchTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *BatchTransferRequest) GetTransfers() []*TransferRequest {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+type BatchTransferResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TransactionIds []string               `protobuf:"bytes,1,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`                             // One per transfer, in request order
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                                                                   // Deprecated: always "SUCCESS"; use transfer_status
+	TransferStatus TransferStatus         `protobuf:"varint,3,opt,name=transfer_status,json=transferStatus,proto3,enum=ledger.TransferStatus" json:"transfer_status,omitempty"` // Shared by every transfer in the batch
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchTransferResponse) Reset() {
+	*x = BatchTransferResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTransferResponse) ProtoMessage() {}
+
+func (x *BatchTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTransferResponse.ProtoReflect.Descriptor instead.
+func (*BatchTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *BatchTransferResponse) GetTransactionIds() []string {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+func (x *BatchTransferResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchTransferResponse) GetTransferStatus() TransferStatus {
+	if x != nil {
+		return x.TransferStatus
+	}
+	return TransferStatus_TRANSFER_STATUS_UNSPECIFIED
+}
+
+type ConversionQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromCurrency  string                 `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	AmountCents   int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"` // In from_currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversionQuoteRequest) Reset() {
+	*x = ConversionQuoteRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionQuoteRequest) ProtoMessage() {}
+
+func (x *ConversionQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionQuoteRequest.ProtoReflect.Descriptor instead.
+func (*ConversionQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ConversionQuoteRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConversionQuoteRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConversionQuoteRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+type ConversionQuoteResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId              string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"` // Pass to CrossCurrencyTransfer to lock in the rate
+	FromCurrency         string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency           string                 `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	AmountCents          int64                  `protobuf:"varint,4,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	ConvertedAmountCents int64                  `protobuf:"varint,5,opt,name=converted_amount_cents,json=convertedAmountCents,proto3" json:"converted_amount_cents,omitempty"` // In to_currency
+	Rate                 float64                `protobuf:"fixed64,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	ExpiresAt            string                 `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ConversionQuoteResponse) Reset() {
+	*x = ConversionQuoteResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversionQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversionQuoteResponse) ProtoMessage() {}
+
+func (x *ConversionQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversionQuoteResponse.ProtoReflect.Descriptor instead.
+func (*ConversionQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ConversionQuoteResponse) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *ConversionQuoteResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConversionQuoteResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConversionQuoteResponse) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *ConversionQuoteResponse) GetConvertedAmountCents() int64 {
+	if x != nil {
+		return x.ConvertedAmountCents
+	}
+	return 0
+}
+
+func (x *ConversionQuoteResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ConversionQuoteResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type CrossCurrencyTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountId string                 `protobuf:"bytes,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   string                 `protobuf:"bytes,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	AmountCents   int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"` // In the sender's currency
+	QuoteId       string                 `protobuf:"bytes,4,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`              // Optional: use this quote's rate; rejected once expired
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrossCurrencyTransferRequest) Reset() {
+	*x = CrossCurrencyTransferRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrossCurrencyTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossCurrencyTransferRequest) ProtoMessage() {}
+
+func (x *CrossCurrencyTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossCurrencyTransferRequest.ProtoReflect.Descriptor instead.
+func (*CrossCurrencyTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CrossCurrencyTransferRequest) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *CrossCurrencyTransferRequest) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *CrossCurrencyTransferRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *CrossCurrencyTransferRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+type CrossCurrencyTransferResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId        string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ConvertedAmountCents int64                  `protobuf:"varint,2,opt,name=converted_amount_cents,json=convertedAmountCents,proto3" json:"converted_amount_cents,omitempty"` // Credited to the receiver in their currency
+	Rate                 float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Status               string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // Deprecated: always "SUCCESS"; use transfer_status
+	TransferStatus       TransferStatus         `protobuf:"varint,5,opt,name=transfer_status,json=transferStatus,proto3,enum=ledger.TransferStatus" json:"transfer_status,omitempty"`
+	Rounding             string                 `protobuf:"bytes,6,opt,name=rounding,proto3" json:"rounding,omitempty"`                                                 // Rounding mode applied to the converted amount
+	RoundingAdjustment   float64                `protobuf:"fixed64,7,opt,name=rounding_adjustment,json=roundingAdjustment,proto3" json:"rounding_adjustment,omitempty"` // converted_amount_cents - amount_cents * rate
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CrossCurrencyTransferResponse) Reset() {
+	*x = CrossCurrencyTransferResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrossCurrencyTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrossCurrencyTransferResponse) ProtoMessage() {}
+
+func (x *CrossCurrencyTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrossCurrencyTransferResponse.ProtoReflect.Descriptor instead.
+func (*CrossCurrencyTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CrossCurrencyTransferResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CrossCurrencyTransferResponse) GetConvertedAmountCents() int64 {
+	if x != nil {
+		return x.ConvertedAmountCents
+	}
+	return 0
+}
+
+func (x *CrossCurrencyTransferResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *CrossCurrencyTransferResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CrossCurrencyTransferResponse) GetTransferStatus() TransferStatus {
+	if x != nil {
+		return x.TransferStatus
+	}
+	return TransferStatus_TRANSFER_STATUS_UNSPECIFIED
+}
+
+func (x *CrossCurrencyTransferResponse) GetRounding() string {
+	if x != nil {
+		return x.Rounding
+	}
+	return ""
+}
+
+func (x *CrossCurrencyTransferResponse) GetRoundingAdjustment() float64 {
+	if x != nil {
+		return x.RoundingAdjustment
+	}
+	return 0
+}
+
+type GetServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoRequest) Reset() {
+	*x = GetServerInfoRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoRequest) ProtoMessage() {}
+
+func (x *GetServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{55}
+}
+
+type GetServerInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	UptimeSeconds int64                  `protobuf:"varint,2,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Features      []string               `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"` // e.g. "reflection", "cross_currency"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerInfoResponse) Reset() {
+	*x = GetServerInfoResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerInfoResponse) ProtoMessage() {}
+
+func (x *GetServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetServerInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *GetServerInfoResponse) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *GetServerInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type ListCurrenciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesRequest) Reset() {
+	*x = ListCurrenciesRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesRequest) ProtoMessage() {}
+
+func (x *ListCurrenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesRequest.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{57}
+}
+
+type Currency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`          // ISO 4217 alphabetic code
+	Exponent      int32                  `protobuf:"varint,2,opt,name=exponent,proto3" json:"exponent,omitempty"` // Minor-unit digits: 2 for USD cents, 0 for JPY
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Currency) Reset() {
+	*x = Currency{}
+	mi := &file_proto_ledger_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Currency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Currency) ProtoMessage() {}
+
+func (x *Currency) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Currency.ProtoReflect.Descriptor instead.
+func (*Currency) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *Currency) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Currency) GetExponent() int32 {
+	if x != nil {
+		return x.Exponent
+	}
+	return 0
+}
+
+func (x *Currency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListCurrenciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currencies    []*Currency            `protobuf:"bytes,1,rep,name=currencies,proto3" json:"currencies,omitempty"` // Ordered by code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCurrenciesResponse) Reset() {
+	*x = ListCurrenciesResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCurrenciesResponse) ProtoMessage() {}
+
+func (x *ListCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*ListCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListCurrenciesResponse) GetCurrencies() []*Currency {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+type ListAccountsByCurrencyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Currency        string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Limit           int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                           // Optional: limit results (default: 100)
+	Offset          int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`                                         // Optional: pagination offset (default: 0)
+	TimestampFormat string                 `protobuf:"bytes,4,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListAccountsByCurrencyRequest) Reset() {
+	*x = ListAccountsByCurrencyRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsByCurrencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsByCurrencyRequest) ProtoMessage() {}
+
+func (x *ListAccountsByCurrencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsByCurrencyRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsByCurrencyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListAccountsByCurrencyRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListAccountsByCurrencyRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAccountsByCurrencyRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListAccountsByCurrencyRequest) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+type ListAccountsByCurrencyResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Currency          string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Accounts          []*GetAccountResponse  `protobuf:"bytes,2,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Total             int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                                                    // Accounts in this currency
+	TotalBalanceCents int64                  `protobuf:"varint,4,opt,name=total_balance_cents,json=totalBalanceCents,proto3" json:"total_balance_cents,omitempty"` // Sum of all balances in this currency, not just this page
+	PageSizeClamped   bool                   `protobuf:"varint,5,opt,name=page_size_clamped,json=pageSizeClamped,proto3" json:"page_size_clamped,omitempty"`       // See ListAccountsResponse
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListAccountsByCurrencyResponse) Reset() {
+	*x = ListAccountsByCurrencyResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsByCurrencyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsByCurrencyResponse) ProtoMessage() {}
+
+func (x *ListAccountsByCurrencyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsByCurrencyResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsByCurrencyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListAccountsByCurrencyResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListAccountsByCurrencyResponse) GetAccounts() []*GetAccountResponse {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsByCurrencyResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAccountsByCurrencyResponse) GetTotalBalanceCents() int64 {
+	if x != nil {
+		return x.TotalBalanceCents
+	}
+	return 0
+}
+
+func (x *ListAccountsByCurrencyResponse) GetPageSizeClamped() bool {
+	if x != nil {
+		return x.PageSizeClamped
+	}
+	return false
+}
+
+type ReverseTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AmountCents   int64                  `protobuf:"varint,2,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"` // Optional: defaults to the full remaining amount
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                               // Required: recorded on the reversal transaction
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTransferRequest) Reset() {
+	*x = ReverseTransferRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransferRequest) ProtoMessage() {}
+
+func (x *ReverseTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransferRequest.ProtoReflect.Descriptor instead.
+func (*ReverseTransferRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ReverseTransferRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReverseTransferRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *ReverseTransferRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReverseTransferResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	ReversalTransactionId    string                 `protobuf:"bytes,1,opt,name=reversal_transaction_id,json=reversalTransactionId,proto3" json:"reversal_transaction_id,omitempty"`
+	OriginalTransactionId    string                 `protobuf:"bytes,2,opt,name=original_transaction_id,json=originalTransactionId,proto3" json:"original_transaction_id,omitempty"`
+	AmountCents              int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	RemainingReversibleCents int64                  `protobuf:"varint,4,opt,name=remaining_reversible_cents,json=remainingReversibleCents,proto3" json:"remaining_reversible_cents,omitempty"` // Still reversible on the original after this reversal
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *ReverseTransferResponse) Reset() {
+	*x = ReverseTransferResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransferResponse) ProtoMessage() {}
+
+func (x *ReverseTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransferResponse.ProtoReflect.Descriptor instead.
+func (*ReverseTransferResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ReverseTransferResponse) GetReversalTransactionId() string {
+	if x != nil {
+		return x.ReversalTransactionId
+	}
+	return ""
+}
+
+func (x *ReverseTransferResponse) GetOriginalTransactionId() string {
+	if x != nil {
+		return x.OriginalTransactionId
+	}
+	return ""
+}
+
+func (x *ReverseTransferResponse) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *ReverseTransferResponse) GetRemainingReversibleCents() int64 {
+	if x != nil {
+		return x.RemainingReversibleCents
+	}
+	return 0
+}
+
+type ReverseTransfersInWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                            // RFC 3339; inclusive
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                                // RFC 3339; exclusive
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // Optional: only transfers this account sent or received
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                        // Required: recorded on each reversal transaction
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTransfersInWindowRequest) Reset() {
+	*x = ReverseTransfersInWindowRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransfersInWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransfersInWindowRequest) ProtoMessage() {}
+
+func (x *ReverseTransfersInWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransfersInWindowRequest.ProtoReflect.Descriptor instead.
+func (*ReverseTransfersInWindowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ReverseTransfersInWindowRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ReverseTransfersInWindowRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ReverseTransfersInWindowRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ReverseTransfersInWindowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type WindowReversal struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId         string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ReversalTransactionId string                 `protobuf:"bytes,2,opt,name=reversal_transaction_id,json=reversalTransactionId,proto3" json:"reversal_transaction_id,omitempty"` // Empty when skipped
+	AmountCents           int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	SkipReason            string                 `protobuf:"bytes,4,opt,name=skip_reason,json=skipReason,proto3" json:"skip_reason,omitempty"` // Set when skipped: "insufficient_funds", "not_reversible" or "error"
+	Error                 string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                             // Set when skipped
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *WindowReversal) Reset() {
+	*x = WindowReversal{}
+	mi := &file_proto_ledger_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowReversal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowReversal) ProtoMessage() {}
+
+func (x *WindowReversal) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowReversal.ProtoReflect.Descriptor instead.
+func (*WindowReversal) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *WindowReversal) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *WindowReversal) GetReversalTransactionId() string {
+	if x != nil {
+		return x.ReversalTransactionId
+	}
+	return ""
+}
+
+func (x *WindowReversal) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *WindowReversal) GetSkipReason() string {
+	if x != nil {
+		return x.SkipReason
+	}
+	return ""
+}
+
+func (x *WindowReversal) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ReverseTransfersInWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*WindowReversal      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	ReversedCount int32                  `protobuf:"varint,2,opt,name=reversed_count,json=reversedCount,proto3" json:"reversed_count,omitempty"`
+	SkippedCount  int32                  `protobuf:"varint,3,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseTransfersInWindowResponse) Reset() {
+	*x = ReverseTransfersInWindowResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseTransfersInWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseTransfersInWindowResponse) ProtoMessage() {}
+
+func (x *ReverseTransfersInWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseTransfersInWindowResponse.ProtoReflect.Descriptor instead.
+func (*ReverseTransfersInWindowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReverseTransfersInWindowResponse) GetResults() []*WindowReversal {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ReverseTransfersInWindowResponse) GetReversedCount() int32 {
+	if x != nil {
+		return x.ReversedCount
+	}
+	return 0
+}
+
+func (x *ReverseTransfersInWindowResponse) GetSkippedCount() int32 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+type DepositRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AccountId         string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AmountCents       int64                  `protobuf:"varint,2,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency          string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`                                            // Optional: must match the account's currency when set
+	ExternalReference string                 `protobuf:"bytes,4,opt,name=external_reference,json=externalReference,proto3" json:"external_reference,omitempty"` // Optional: payment processor reference; a repeat returns the original deposit
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DepositRequest) Reset() {
+	*x = DepositRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositRequest) ProtoMessage() {}
+
+func (x *DepositRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositRequest.ProtoReflect.Descriptor instead.
+func (*DepositRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DepositRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DepositRequest) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *DepositRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DepositRequest) GetExternalReference() string {
+	if x != nil {
+		return x.ExternalReference
+	}
+	return ""
+}
+
+type DepositResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AmountCents   int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	BalanceCents  int64                  `protobuf:"varint,5,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"` // Balance right after this deposit was applied
+	Duplicate     bool                   `protobuf:"varint,6,opt,name=duplicate,proto3" json:"duplicate,omitempty"`                           // True when external_reference was already recorded and nothing new was credited
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DepositResponse) Reset() {
+	*x = DepositResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DepositResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepositResponse) ProtoMessage() {}
+
+func (x *DepositResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepositResponse.ProtoReflect.Descriptor instead.
+func (*DepositResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DepositResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *DepositResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DepositResponse) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *DepositResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DepositResponse) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *DepositResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type SetParentAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"` // Empty detaches the account from its parent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetParentAccountRequest) Reset() {
+	*x = SetParentAccountRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetParentAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetParentAccountRequest) ProtoMessage() {}
+
+func (x *SetParentAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetParentAccountRequest.ProtoReflect.Descriptor instead.
+func (*SetParentAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SetParentAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetParentAccountRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type SetParentAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetParentAccountResponse) Reset() {
+	*x = SetParentAccountResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetParentAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetParentAccountResponse) ProtoMessage() {}
+
+func (x *SetParentAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetParentAccountResponse.ProtoReflect.Descriptor instead.
+func (*SetParentAccountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SetParentAccountResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetParentAccountResponse) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type AggregateBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateBalanceRequest) Reset() {
+	*x = AggregateBalanceRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateBalanceRequest) ProtoMessage() {}
+
+func (x *AggregateBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AggregateBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *AggregateBalanceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type AggregateBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Balances      []*CurrencyBalance     `protobuf:"bytes,2,rep,name=balances,proto3" json:"balances,omitempty"`                              // One total per currency held in the hierarchy, ordered by currency
+	AccountCount  int32                  `protobuf:"varint,3,opt,name=account_count,json=accountCount,proto3" json:"account_count,omitempty"` // The account itself plus all its descendants
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregateBalanceResponse) Reset() {
+	*x = AggregateBalanceResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregateBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateBalanceResponse) ProtoMessage() {}
+
+func (x *AggregateBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateBalanceResponse.ProtoReflect.Descriptor instead.
+func (*AggregateBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *AggregateBalanceResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AggregateBalanceResponse) GetBalances() []*CurrencyBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *AggregateBalanceResponse) GetAccountCount() int32 {
+	if x != nil {
+		return x.AccountCount
+	}
+	return 0
+}
+
+type CurrencyBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	BalanceCents  int64                  `protobuf:"varint,2,opt,name=balance_cents,json=balanceCents,proto3" json:"balance_cents,omitempty"`
+	AccountCount  int32                  `protobuf:"varint,3,opt,name=account_count,json=accountCount,proto3" json:"account_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyBalance) Reset() {
+	*x = CurrencyBalance{}
+	mi := &file_proto_ledger_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyBalance) ProtoMessage() {}
+
+func (x *CurrencyBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyBalance.ProtoReflect.Descriptor instead.
+func (*CurrencyBalance) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CurrencyBalance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CurrencyBalance) GetBalanceCents() int64 {
+	if x != nil {
+		return x.BalanceCents
+	}
+	return 0
+}
+
+func (x *CurrencyBalance) GetAccountCount() int32 {
+	if x != nil {
+		return x.AccountCount
+	}
+	return 0
+}
+
+type DeadLetter struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NotificationId string                 `protobuf:"bytes,2,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	AccountId      string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`      // Last delivery error, or "queue full"
+	Attempts       int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"` // Delivery attempts made; 0 if it never reached a worker
+	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_proto_ledger_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DeadLetter) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Optional: results per page (default: 50)
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Optional: next_page_token from a previous response
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListDeadLettersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeadLettersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"` // Oldest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+func (x *ListDeadLettersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RetryDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"` // Optional: only these dead letters; empty retries the oldest
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`    // Optional: most dead letters to retry (default: 100)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadLettersRequest) Reset() {
+	*x = RetryDeadLettersRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadLettersRequest) ProtoMessage() {}
+
+func (x *RetryDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*RetryDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RetryDeadLettersRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *RetryDeadLettersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RetryDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Retried       int32                  `protobuf:"varint,1,opt,name=retried,proto3" json:"retried,omitempty"`
+	RetriedIds    []int64                `protobuf:"varint,2,rep,packed,name=retried_ids,json=retriedIds,proto3" json:"retried_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadLettersResponse) Reset() {
+	*x = RetryDeadLettersResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadLettersResponse) ProtoMessage() {}
+
+func (x *RetryDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*RetryDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RetryDeadLettersResponse) GetRetried() int32 {
+	if x != nil {
+		return x.Retried
+	}
+	return 0
+}
+
+func (x *RetryDeadLettersResponse) GetRetriedIds() []int64 {
+	if x != nil {
+		return x.RetriedIds
+	}
+	return nil
+}
+
+type RotateJWTSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewSecret     string                 `protobuf:"bytes,1,opt,name=new_secret,json=newSecret,proto3" json:"new_secret,omitempty"`           // At least 32 bytes
+	GraceSeconds  int64                  `protobuf:"varint,2,opt,name=grace_seconds,json=graceSeconds,proto3" json:"grace_seconds,omitempty"` // Optional: how long the outgoing secret stays valid (default: JWT_ROTATION_GRACE)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateJWTSecretRequest) Reset() {
+	*x = RotateJWTSecretRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateJWTSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateJWTSecretRequest) ProtoMessage() {}
+
+func (x *RotateJWTSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateJWTSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateJWTSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RotateJWTSecretRequest) GetNewSecret() string {
+	if x != nil {
+		return x.NewSecret
+	}
+	return ""
+}
+
+func (x *RotateJWTSecretRequest) GetGraceSeconds() int64 {
+	if x != nil {
+		return x.GraceSeconds
+	}
+	return 0
+}
+
+type RotateJWTSecretResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	PreviousValidUntil string                 `protobuf:"bytes,1,opt,name=previous_valid_until,json=previousValidUntil,proto3" json:"previous_valid_until,omitempty"` // RFC3339; empty if the outgoing secret never expires
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RotateJWTSecretResponse) Reset() {
+	*x = RotateJWTSecretResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateJWTSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateJWTSecretResponse) ProtoMessage() {}
+
+func (x *RotateJWTSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateJWTSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateJWTSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RotateJWTSecretResponse) GetPreviousValidUntil() string {
+	if x != nil {
+		return x.PreviousValidUntil
+	}
+	return ""
+}
+
+type GetTransferStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferStatusRequest) Reset() {
+	*x = GetTransferStatusRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferStatusRequest) ProtoMessage() {}
+
+func (x *GetTransferStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetTransferStatusRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type GetTransferStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status        TransferStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=ledger.TransferStatus" json:"status,omitempty"`
+	AmountCents   int64                  `protobuf:"varint,3,opt,name=amount_cents,json=amountCents,proto3" json:"amount_cents,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	ReversedCents int64                  `protobuf:"varint,5,opt,name=reversed_cents,json=reversedCents,proto3" json:"reversed_cents,omitempty"` // Reversed so far; a partial reversal leaves the transfer SETTLED
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferStatusResponse) Reset() {
+	*x = GetTransferStatusResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferStatusResponse) ProtoMessage() {}
+
+func (x *GetTransferStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetTransferStatusResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GetTransferStatusResponse) GetStatus() TransferStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransferStatus_TRANSFER_STATUS_UNSPECIFIED
+}
+
+func (x *GetTransferStatusResponse) GetAmountCents() int64 {
+	if x != nil {
+		return x.AmountCents
+	}
+	return 0
+}
+
+func (x *GetTransferStatusResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetTransferStatusResponse) GetReversedCents() int64 {
+	if x != nil {
+		return x.ReversedCents
+	}
+	return 0
+}
+
+func (x *GetTransferStatusResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetTransactionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId   string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	TimestampFormat string                 `protobuf:"bytes,2,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"` // Optional: see GetAccountRequest
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountResponse) ProtoMessage() {}
+func (*GetTransactionRequest) ProtoMessage() {}
 
-func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ledger_proto_msgTypes[11]
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,48 +5423,48 @@ func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
-func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ledger_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *DeleteAccountResponse) GetAccountId() string {
+func (x *GetTransactionRequest) GetTransactionId() string {
 	if x != nil {
-		return x.AccountId
+		return x.TransactionId
 	}
 	return ""
 }
 
-func (x *DeleteAccountResponse) GetStatus() string {
+func (x *GetTransactionRequest) GetTimestampFormat() string {
 	if x != nil {
-		return x.Status
+		return x.TimestampFormat
 	}
 	return ""
 }
 
-type ListAccountsRequest struct {
+type GetTransactionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`   // Optional: limit results (default: 100)
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Optional: pagination offset (default: 0)
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Reversed      bool                   `protobuf:"varint,2,opt,name=reversed,proto3" json:"reversed,omitempty"` // Reversed in full; a partial reversal shows only in transaction.reversed_cents
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAccountsRequest) Reset() {
-	*x = ListAccountsRequest{}
-	mi := &file_proto_ledger_proto_msgTypes[12]
+func (x *GetTransactionResponse) Reset() {
+	*x = GetTransactionResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAccountsRequest) String() string {
+func (x *GetTransactionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAccountsRequest) ProtoMessage() {}
+func (*GetTransactionResponse) ProtoMessage() {}
 
-func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ledger_proto_msgTypes[12]
+func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,48 +5475,248 @@ func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
-func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ledger_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *ListAccountsRequest) GetLimit() int32 {
+func (x *GetTransactionResponse) GetTransaction() *Transaction {
 	if x != nil {
-		return x.Limit
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *GetTransactionResponse) GetReversed() bool {
+	if x != nil {
+		return x.Reversed
+	}
+	return false
+}
+
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // Authenticated caller, or "system"
+	Operation     string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`            // e.g. "transfer", "create_account"
+	AccountIds    []string               `protobuf:"bytes,4,rep,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"`
+	TransactionId string                 `protobuf:"bytes,5,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Ledger transaction the operation recorded, if any
+	Outcome       string                 `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"`                                  // "success" or "failure"
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`                                      // Why a failed operation failed
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_proto_ledger_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
 	return 0
 }
 
-func (x *ListAccountsRequest) GetOffset() int32 {
+func (x *AuditEntry) GetActorId() string {
 	if x != nil {
-		return x.Offset
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAccountIds() []string {
+	if x != nil {
+		return x.AccountIds
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type QueryAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // Optional filters; all given filters must match
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Operation     string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`                           // Optional: RFC 3339, inclusive
+	Until         string                 `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`                           // Optional: RFC 3339, exclusive
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`    // Optional: results per page (default: 50)
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`  // Optional: next_page_token from a previous response
+	ReadsOnly     bool                   `protobuf:"varint,8,opt,name=reads_only,json=readsOnly,proto3" json:"reads_only,omitempty"` // Optional: only read-access entries of sensitive accounts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_proto_ledger_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogRequest) ProtoMessage() {}
+
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *QueryAuditLogRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
 	}
 	return 0
 }
 
-type ListAccountsResponse struct {
+func (x *QueryAuditLogRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetReadsOnly() bool {
+	if x != nil {
+		return x.ReadsOnly
+	}
+	return false
+}
+
+type QueryAuditLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accounts      []*GetAccountResponse  `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // Oldest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAccountsResponse) Reset() {
-	*x = ListAccountsResponse{}
-	mi := &file_proto_ledger_proto_msgTypes[13]
+func (x *QueryAuditLogResponse) Reset() {
+	*x = QueryAuditLogResponse{}
+	mi := &file_proto_ledger_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAccountsResponse) String() string {
+func (x *QueryAuditLogResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAccountsResponse) ProtoMessage() {}
+func (*QueryAuditLogResponse) ProtoMessage() {}
 
-func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ledger_proto_msgTypes[13]
+func (x *QueryAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ledger_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -779,57 +5727,96 @@ func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
-func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ledger_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use QueryAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ledger_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *ListAccountsResponse) GetAccounts() []*GetAccountResponse {
+func (x *QueryAuditLogResponse) GetEntries() []*AuditEntry {
 	if x != nil {
-		return x.Accounts
+		return x.Entries
 	}
 	return nil
 }
 
-func (x *ListAccountsResponse) GetTotal() int32 {
+func (x *QueryAuditLogResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Total
+		return x.NextPageToken
 	}
-	return 0
+	return ""
 }
 
 var File_proto_ledger_proto protoreflect.FileDescriptor
 
 const file_proto_ledger_proto_rawDesc = "" +
 	"\n" +
-	"\x12proto/ledger.proto\x12\x06ledger\"\x9c\x01\n" +
+	"\x12proto/ledger.proto\x12\x06ledger\"\xd4\x02\n" +
 	"\x0fTransferRequest\x12&\n" +
 	"\x0ffrom_account_id\x18\x01 \x01(\tR\rfromAccountId\x12\"\n" +
 	"\rto_account_id\x18\x02 \x01(\tR\vtoAccountId\x12!\n" +
 	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\x12\x1a\n" +
-	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"Q\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x129\n" +
+	"\x16expected_from_sequence\x18\x05 \x01(\x03H\x00R\x14expectedFromSequence\x88\x01\x01\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12#\n" +
+	"\rfrom_currency\x18\a \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\b \x01(\tR\n" +
+	"toCurrencyB\x19\n" +
+	"\x17_expected_from_sequence\"\xb7\x01\n" +
 	"\x10TransferResponse\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"/\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12?\n" +
+	"\x0ftransfer_status\x18\x03 \x01(\x0e2\x16.ledger.TransferStatusR\x0etransferStatus\x12#\n" +
+	"\rfrom_sequence\x18\x04 \x01(\x03R\ffromSequence\"/\n" +
 	"\x0eBalanceRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\"R\n" +
 	"\x0fBalanceResponse\x12#\n" +
 	"\rbalance_cents\x18\x01 \x01(\x03R\fbalanceCents\x12\x1a\n" +
-	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"v\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"H\n" +
+	"\x12BalanceAsOfRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x13\n" +
+	"\x05as_of\x18\x02 \x01(\tR\x04asOf\"\xad\x01\n" +
+	"\x13BalanceAsOfResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
+	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x13\n" +
+	"\x05as_of\x18\x04 \x01(\tR\x04asOf\x12!\n" +
+	"\ras_of_unix_ms\x18\x05 \x01(\x03R\n" +
+	"asOfUnixMs\"9\n" +
+	"\x16BatchGetBalanceRequest\x12\x1f\n" +
+	"\vaccount_ids\x18\x01 \x03(\tR\n" +
+	"accountIds\"p\n" +
+	"\x0eAccountBalance\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
+	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x92\x01\n" +
+	"\x17BatchGetBalanceResponse\x122\n" +
+	"\bbalances\x18\x01 \x03(\v2\x16.ledger.AccountBalanceR\bbalances\x12\"\n" +
+	"\rnot_found_ids\x18\x02 \x03(\tR\vnotFoundIds\x12\x1f\n" +
+	"\vinvalid_ids\x18\x03 \x03(\tR\n" +
+	"invalidIds\"\xb4\x01\n" +
 	"\x14CreateAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
 	"\x15initial_balance_cents\x18\x02 \x01(\x03R\x13initialBalanceCents\x12\x1a\n" +
-	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x8f\x01\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12!\n" +
+	"\faccount_type\x18\x05 \x01(\tR\vaccountType\"\xf4\x01\n" +
 	"\x15CreateAccountResponse\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
 	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12\x1a\n" +
 	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\"2\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x19\n" +
+	"\bowner_id\x18\x05 \x01(\tR\aownerId\x12%\n" +
+	"\x0eaccount_status\x18\x06 \x01(\tR\raccountStatus\x12!\n" +
+	"\faccount_type\x18\a \x01(\tR\vaccountType\"]\n" +
 	"\x11GetAccountRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"\xb2\x01\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12)\n" +
+	"\x10timestamp_format\x18\x02 \x01(\tR\x0ftimestampFormat\"\xfb\x03\n" +
 	"\x12GetAccountResponse\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
@@ -838,7 +5825,17 @@ const file_proto_ledger_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"Q\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\x12\x19\n" +
+	"\bowner_id\x18\x06 \x01(\tR\aownerId\x12+\n" +
+	"\x12created_at_unix_ms\x18\a \x01(\x03R\x0fcreatedAtUnixMs\x12+\n" +
+	"\x12updated_at_unix_ms\x18\b \x01(\x03R\x0fupdatedAtUnixMs\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\x12%\n" +
+	"\x0eaccount_status\x18\n" +
+	" \x01(\tR\raccountStatus\x12\x1a\n" +
+	"\bsequence\x18\v \x01(\x03R\bsequence\x12!\n" +
+	"\faccount_type\x18\f \x01(\tR\vaccountType\x121\n" +
+	"\x14notification_channel\x18\r \x01(\tR\x13notificationChannel\x12\x1c\n" +
+	"\tsensitive\x18\x0e \x01(\bR\tsensitive\"Q\n" +
 	"\x14UpdateAccountRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
@@ -854,23 +5851,429 @@ const file_proto_ledger_proto_rawDesc = "" +
 	"\x15DeleteAccountResponse\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"C\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"7\n" +
+	"\x16ActivateAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"_\n" +
+	"\x17ActivateAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0eaccount_status\x18\x02 \x01(\tR\raccountStatus\"[\n" +
+	" SetNotificationPreferenceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\"\\\n" +
+	"!SetNotificationPreferenceResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\"Y\n" +
+	"\x1aSetAccountSensitiveRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1c\n" +
+	"\tsensitive\x18\x02 \x01(\bR\tsensitive\"Z\n" +
+	"\x1bSetAccountSensitiveResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1c\n" +
+	"\tsensitive\x18\x02 \x01(\bR\tsensitive\"n\n" +
 	"\x13ListAccountsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\"d\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12)\n" +
+	"\x10timestamp_format\x18\x03 \x01(\tR\x0ftimestampFormat\"\x90\x01\n" +
 	"\x14ListAccountsResponse\x126\n" +
 	"\baccounts\x18\x01 \x03(\v2\x1a.ledger.GetAccountResponseR\baccounts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total2\x95\x04\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12*\n" +
+	"\x11page_size_clamped\x18\x03 \x01(\bR\x0fpageSizeClamped\"\xbd\x01\n" +
+	"\x19TransactionHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12)\n" +
+	"\x10timestamp_format\x18\x04 \x01(\tR\x0ftimestampFormat\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\"\xc2\x05\n" +
+	"\vTransaction\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x03 \x01(\tR\vtoAccountId\x12!\n" +
+	"\famount_cents\x18\x04 \x01(\x03R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x12\n" +
+	"\x04kind\x18\a \x01(\tR\x04kind\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x124\n" +
+	"\x16converted_amount_cents\x18\t \x01(\x03R\x14convertedAmountCents\x12-\n" +
+	"\x12converted_currency\x18\n" +
+	" \x01(\tR\x11convertedCurrency\x12#\n" +
+	"\rexchange_rate\x18\v \x01(\x01R\fexchangeRate\x12+\n" +
+	"\x12created_at_unix_ms\x18\f \x01(\x03R\x0fcreatedAtUnixMs\x12%\n" +
+	"\x0ereversed_cents\x18\r \x01(\x03R\rreversedCents\x126\n" +
+	"\x17reverses_transaction_id\x18\x0e \x01(\tR\x15reversesTransactionId\x12-\n" +
+	"\x12external_reference\x18\x0f \x01(\tR\x11externalReference\x12\x1f\n" +
+	"\vfx_rounding\x18\x10 \x01(\tR\n" +
+	"fxRounding\x124\n" +
+	"\x16fx_rounding_adjustment\x18\x11 \x01(\x01R\x14fxRoundingAdjustment\x12\x1a\n" +
+	"\bcategory\x18\x12 \x01(\tR\bcategory\"\xa9\x01\n" +
+	"\x1aTransactionHistoryResponse\x127\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x13.ledger.TransactionR\ftransactions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12*\n" +
+	"\x11page_size_clamped\x18\x03 \x01(\bR\x0fpageSizeClamped\"\x8e\x01\n" +
+	"\x11ReadEventsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x19\n" +
+	"\bfrom_seq\x18\x02 \x01(\x03R\afromSeq\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12)\n" +
+	"\x10timestamp_format\x18\x04 \x01(\tR\x0ftimestampFormat\"\xa6\x02\n" +
+	"\vLedgerEvent\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03seq\x18\x02 \x01(\x03R\x03seq\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vdelta_cents\x18\x05 \x01(\x03R\n" +
+	"deltaCents\x12#\n" +
+	"\rbalance_after\x18\x06 \x01(\x03R\fbalanceAfter\x12*\n" +
+	"\x11has_balance_after\x18\a \x01(\bR\x0fhasBalanceAfter\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\\\n" +
+	"\x12ReadEventsResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.ledger.LedgerEventR\x06events\x12\x19\n" +
+	"\blast_seq\x18\x02 \x01(\x03R\alastSeq\"3\n" +
+	"\x15ExportAccountsRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\")\n" +
+	"\x13ExportAccountsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x82\x01\n" +
+	"\x19ExportTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x04 \x01(\tR\x05until\",\n" +
+	"\x16ExportTransactionsLine\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"_\n" +
+	"\x13WatchBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12)\n" +
+	"\x10timestamp_format\x18\x02 \x01(\tR\x0ftimestampFormat\"\x89\x02\n" +
+	"\rBalanceUpdate\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
+	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x10\n" +
+	"\x03seq\x18\x04 \x01(\x03R\x03seq\x12\x1a\n" +
+	"\bsnapshot\x18\x05 \x01(\bR\bsnapshot\x12\x1f\n" +
+	"\vdelta_cents\x18\x06 \x01(\x03R\n" +
+	"deltaCents\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\x12\x12\n" +
+	"\x04kind\x18\b \x01(\tR\x04kind\x12\x0e\n" +
+	"\x02at\x18\t \x01(\tR\x02at\"\x8f\x01\n" +
+	"\x19GetAccountsByOwnerRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12)\n" +
+	"\x10timestamp_format\x18\x04 \x01(\tR\x0ftimestampFormat\"\xe1\x01\n" +
+	"\x17InsufficientFundsDetail\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
+	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12%\n" +
+	"\x0erequired_cents\x18\x03 \x01(\x03R\rrequiredCents\x12'\n" +
+	"\x0fshortfall_cents\x18\x04 \x01(\x03R\x0eshortfallCents\x122\n" +
+	"\x15overdraft_limit_cents\x18\x05 \x01(\x03R\x13overdraftLimitCents\"n\n" +
+	"\x14AdjustBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1f\n" +
+	"\vdelta_cents\x18\x02 \x01(\x03R\n" +
+	"deltaCents\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x9e\x01\n" +
+	"\x15AdjustBalanceResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12#\n" +
+	"\rbalance_cents\x18\x03 \x01(\x03R\fbalanceCents\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\",\n" +
+	"\x16BulkAdjustBalanceChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xa9\x01\n" +
+	"\x10AdjustmentResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12#\n" +
+	"\rbalance_cents\x18\x04 \x01(\x03R\fbalanceCents\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\x81\x01\n" +
+	"\x19BulkAdjustBalanceResponse\x12\x18\n" +
+	"\aapplied\x18\x01 \x01(\x03R\aapplied\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x03R\x06failed\x122\n" +
+	"\aresults\x18\x03 \x03(\v2\x18.ledger.AdjustmentResultR\aresults\"\xb3\x01\n" +
+	"\x13ImportAccountRecord\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12#\n" +
+	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\tR\aownerId\x12!\n" +
+	"\faccount_type\x18\x05 \x01(\tR\vaccountType\"Z\n" +
+	"\rImportFailure\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"}\n" +
+	"\x16ImportAccountsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x03R\acreated\x12\x16\n" +
+	"\x06failed\x18\x02 \x01(\x03R\x06failed\x121\n" +
+	"\bfailures\x18\x03 \x03(\v2\x15.ledger.ImportFailureR\bfailures\"\x94\x01\n" +
+	"\x0eStatementEntry\x125\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x13.ledger.TransactionR\vtransaction\x123\n" +
+	"\x13balance_after_cents\x18\x02 \x01(\x03H\x00R\x11balanceAfterCents\x88\x01\x01B\x16\n" +
+	"\x14_balance_after_cents\"\xbf\x01\n" +
+	"\x18AccountStatementResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x120\n" +
+	"\aentries\x18\x02 \x03(\v2\x16.ledger.StatementEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12*\n" +
+	"\x11page_size_clamped\x18\x04 \x01(\bR\x0fpageSizeClamped\"M\n" +
+	"\x14BatchTransferRequest\x125\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x17.ledger.TransferRequestR\ttransfers\"\x99\x01\n" +
+	"\x15BatchTransferResponse\x12'\n" +
+	"\x0ftransaction_ids\x18\x01 \x03(\tR\x0etransactionIds\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12?\n" +
+	"\x0ftransfer_status\x18\x03 \x01(\x0e2\x16.ledger.TransferStatusR\x0etransferStatus\"\x81\x01\n" +
+	"\x16ConversionQuoteRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\"\x86\x02\n" +
+	"\x17ConversionQuoteResponse\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\x12!\n" +
+	"\famount_cents\x18\x04 \x01(\x03R\vamountCents\x124\n" +
+	"\x16converted_amount_cents\x18\x05 \x01(\x03R\x14convertedAmountCents\x12\x12\n" +
+	"\x04rate\x18\x06 \x01(\x01R\x04rate\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\tR\texpiresAt\"\xa8\x01\n" +
+	"\x1cCrossCurrencyTransferRequest\x12&\n" +
+	"\x0ffrom_account_id\x18\x01 \x01(\tR\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x02 \x01(\tR\vtoAccountId\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\x12\x19\n" +
+	"\bquote_id\x18\x04 \x01(\tR\aquoteId\"\xb6\x02\n" +
+	"\x1dCrossCurrencyTransferResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x124\n" +
+	"\x16converted_amount_cents\x18\x02 \x01(\x03R\x14convertedAmountCents\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12?\n" +
+	"\x0ftransfer_status\x18\x05 \x01(\x0e2\x16.ledger.TransferStatusR\x0etransferStatus\x12\x1a\n" +
+	"\brounding\x18\x06 \x01(\tR\brounding\x12/\n" +
+	"\x13rounding_adjustment\x18\a \x01(\x01R\x12roundingAdjustment\"\x16\n" +
+	"\x14GetServerInfoRequest\"\x93\x01\n" +
+	"\x15GetServerInfoResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12%\n" +
+	"\x0euptime_seconds\x18\x02 \x01(\x03R\ruptimeSeconds\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\tR\tstartedAt\x12\x1a\n" +
+	"\bfeatures\x18\x04 \x03(\tR\bfeatures\"\x17\n" +
+	"\x15ListCurrenciesRequest\"N\n" +
+	"\bCurrency\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1a\n" +
+	"\bexponent\x18\x02 \x01(\x05R\bexponent\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"J\n" +
+	"\x16ListCurrenciesResponse\x120\n" +
+	"\n" +
+	"currencies\x18\x01 \x03(\v2\x10.ledger.CurrencyR\n" +
+	"currencies\"\x94\x01\n" +
+	"\x1dListAccountsByCurrencyRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12)\n" +
+	"\x10timestamp_format\x18\x04 \x01(\tR\x0ftimestampFormat\"\xe6\x01\n" +
+	"\x1eListAccountsByCurrencyResponse\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x126\n" +
+	"\baccounts\x18\x02 \x03(\v2\x1a.ledger.GetAccountResponseR\baccounts\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12.\n" +
+	"\x13total_balance_cents\x18\x04 \x01(\x03R\x11totalBalanceCents\x12*\n" +
+	"\x11page_size_clamped\x18\x05 \x01(\bR\x0fpageSizeClamped\"z\n" +
+	"\x16ReverseTransferRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\famount_cents\x18\x02 \x01(\x03R\vamountCents\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xea\x01\n" +
+	"\x17ReverseTransferResponse\x126\n" +
+	"\x17reversal_transaction_id\x18\x01 \x01(\tR\x15reversalTransactionId\x126\n" +
+	"\x17original_transaction_id\x18\x02 \x01(\tR\x15originalTransactionId\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\x12<\n" +
+	"\x1aremaining_reversible_cents\x18\x04 \x01(\x03R\x18remainingReversibleCents\"|\n" +
+	"\x1fReverseTransfersInWindowRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xc9\x01\n" +
+	"\x0eWindowReversal\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x126\n" +
+	"\x17reversal_transaction_id\x18\x02 \x01(\tR\x15reversalTransactionId\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\x12\x1f\n" +
+	"\vskip_reason\x18\x04 \x01(\tR\n" +
+	"skipReason\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xa0\x01\n" +
+	" ReverseTransfersInWindowResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.ledger.WindowReversalR\aresults\x12%\n" +
+	"\x0ereversed_count\x18\x02 \x01(\x05R\rreversedCount\x12#\n" +
+	"\rskipped_count\x18\x03 \x01(\x05R\fskippedCount\"\x9d\x01\n" +
+	"\x0eDepositRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12!\n" +
+	"\famount_cents\x18\x02 \x01(\x03R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12-\n" +
+	"\x12external_reference\x18\x04 \x01(\tR\x11externalReference\"\xd9\x01\n" +
+	"\x0fDepositResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12#\n" +
+	"\rbalance_cents\x18\x05 \x01(\x03R\fbalanceCents\x12\x1c\n" +
+	"\tduplicate\x18\x06 \x01(\bR\tduplicate\"U\n" +
+	"\x17SetParentAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"V\n" +
+	"\x18SetParentAccountResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"8\n" +
+	"\x17AggregateBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\x93\x01\n" +
+	"\x18AggregateBalanceResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x123\n" +
+	"\bbalances\x18\x02 \x03(\v2\x17.ledger.CurrencyBalanceR\bbalances\x12#\n" +
+	"\raccount_count\x18\x03 \x01(\x05R\faccountCount\"w\n" +
+	"\x0fCurrencyBalance\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12#\n" +
+	"\rbalance_cents\x18\x02 \x01(\x03R\fbalanceCents\x12#\n" +
+	"\raccount_count\x18\x03 \x01(\x05R\faccountCount\"\xd1\x01\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12'\n" +
+	"\x0fnotification_id\x18\x02 \x01(\tR\x0enotificationId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"T\n" +
+	"\x16ListDeadLettersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"x\n" +
+	"\x17ListDeadLettersResponse\x125\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x12.ledger.DeadLetterR\vdeadLetters\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"A\n" +
+	"\x17RetryDeadLettersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"U\n" +
+	"\x18RetryDeadLettersResponse\x12\x18\n" +
+	"\aretried\x18\x01 \x01(\x05R\aretried\x12\x1f\n" +
+	"\vretried_ids\x18\x02 \x03(\x03R\n" +
+	"retriedIds\"\\\n" +
+	"\x16RotateJWTSecretRequest\x12\x1d\n" +
+	"\n" +
+	"new_secret\x18\x01 \x01(\tR\tnewSecret\x12#\n" +
+	"\rgrace_seconds\x18\x02 \x01(\x03R\fgraceSeconds\"K\n" +
+	"\x17RotateJWTSecretResponse\x120\n" +
+	"\x14previous_valid_until\x18\x01 \x01(\tR\x12previousValidUntil\"A\n" +
+	"\x18GetTransferStatusRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xf7\x01\n" +
+	"\x19GetTransferStatusResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.ledger.TransferStatusR\x06status\x12!\n" +
+	"\famount_cents\x18\x03 \x01(\x03R\vamountCents\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12%\n" +
+	"\x0ereversed_cents\x18\x05 \x01(\x03R\rreversedCents\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"i\n" +
+	"\x15GetTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12)\n" +
+	"\x10timestamp_format\x18\x02 \x01(\tR\x0ftimestampFormat\"k\n" +
+	"\x16GetTransactionResponse\x125\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x13.ledger.TransactionR\vtransaction\x12\x1a\n" +
+	"\breversed\x18\x02 \x01(\bR\breversed\"\xec\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12\x1f\n" +
+	"\vaccount_ids\x18\x04 \x03(\tR\n" +
+	"accountIds\x12%\n" +
+	"\x0etransaction_id\x18\x05 \x01(\tR\rtransactionId\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"\xf5\x01\n" +
+	"\x14QueryAuditLogRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"reads_only\x18\b \x01(\bR\treadsOnly\"m\n" +
+	"\x15QueryAuditLogResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.ledger.AuditEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xa5\x01\n" +
+	"\x0eTransferStatus\x12\x1f\n" +
+	"\x1bTRANSFER_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TRANSFER_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17TRANSFER_STATUS_SETTLED\x10\x02\x12\x1c\n" +
+	"\x18TRANSFER_STATUS_REVERSED\x10\x03\x12\x1a\n" +
+	"\x16TRANSFER_STATUS_FAILED\x10\x042\x97\x1a\n" +
 	"\rLedgerService\x12?\n" +
 	"\bTransfer\x12\x17.ledger.TransferRequest\x1a\x18.ledger.TransferResponse\"\x00\x12?\n" +
 	"\n" +
-	"GetBalance\x12\x16.ledger.BalanceRequest\x1a\x17.ledger.BalanceResponse\"\x00\x12N\n" +
+	"GetBalance\x12\x16.ledger.BalanceRequest\x1a\x17.ledger.BalanceResponse\"\x00\x12T\n" +
+	"\x0fBatchGetBalance\x12\x1e.ledger.BatchGetBalanceRequest\x1a\x1f.ledger.BatchGetBalanceResponse\"\x00\x12K\n" +
+	"\x0eGetBalanceAsOf\x12\x1a.ledger.BalanceAsOfRequest\x1a\x1b.ledger.BalanceAsOfResponse\"\x00\x12N\n" +
 	"\rCreateAccount\x12\x1c.ledger.CreateAccountRequest\x1a\x1d.ledger.CreateAccountResponse\"\x00\x12E\n" +
 	"\n" +
 	"GetAccount\x12\x19.ledger.GetAccountRequest\x1a\x1a.ledger.GetAccountResponse\"\x00\x12N\n" +
 	"\rUpdateAccount\x12\x1c.ledger.UpdateAccountRequest\x1a\x1d.ledger.UpdateAccountResponse\"\x00\x12N\n" +
-	"\rDeleteAccount\x12\x1c.ledger.DeleteAccountRequest\x1a\x1d.ledger.DeleteAccountResponse\"\x00\x12K\n" +
-	"\fListAccounts\x12\x1b.ledger.ListAccountsRequest\x1a\x1c.ledger.ListAccountsResponse\"\x00B\x15Z\x13apex-ledger/pkg/apib\x06proto3"
+	"\rDeleteAccount\x12\x1c.ledger.DeleteAccountRequest\x1a\x1d.ledger.DeleteAccountResponse\"\x00\x12T\n" +
+	"\x0fActivateAccount\x12\x1e.ledger.ActivateAccountRequest\x1a\x1f.ledger.ActivateAccountResponse\"\x00\x12r\n" +
+	"\x19SetNotificationPreference\x12(.ledger.SetNotificationPreferenceRequest\x1a).ledger.SetNotificationPreferenceResponse\"\x00\x12`\n" +
+	"\x13SetAccountSensitive\x12\".ledger.SetAccountSensitiveRequest\x1a#.ledger.SetAccountSensitiveResponse\"\x00\x12K\n" +
+	"\fListAccounts\x12\x1b.ledger.ListAccountsRequest\x1a\x1c.ledger.ListAccountsResponse\"\x00\x12`\n" +
+	"\x15GetTransactionHistory\x12!.ledger.TransactionHistoryRequest\x1a\".ledger.TransactionHistoryResponse\"\x00\x12E\n" +
+	"\n" +
+	"ReadEvents\x12\x19.ledger.ReadEventsRequest\x1a\x1a.ledger.ReadEventsResponse\"\x00\x12P\n" +
+	"\x0eExportAccounts\x12\x1d.ledger.ExportAccountsRequest\x1a\x1b.ledger.ExportAccountsChunk\"\x000\x01\x12[\n" +
+	"\x12ExportTransactions\x12!.ledger.ExportTransactionsRequest\x1a\x1e.ledger.ExportTransactionsLine\"\x000\x01\x12F\n" +
+	"\fWatchBalance\x12\x1b.ledger.WatchBalanceRequest\x1a\x15.ledger.BalanceUpdate\"\x000\x01\x12W\n" +
+	"\x12GetAccountsByOwner\x12!.ledger.GetAccountsByOwnerRequest\x1a\x1c.ledger.ListAccountsResponse\"\x00\x12N\n" +
+	"\rAdjustBalance\x12\x1c.ledger.AdjustBalanceRequest\x1a\x1d.ledger.AdjustBalanceResponse\"\x00\x12Z\n" +
+	"\x11BulkAdjustBalance\x12\x1e.ledger.BulkAdjustBalanceChunk\x1a!.ledger.BulkAdjustBalanceResponse\"\x00(\x01\x12Q\n" +
+	"\x0eImportAccounts\x12\x1b.ledger.ImportAccountRecord\x1a\x1e.ledger.ImportAccountsResponse\"\x00(\x01\x12\\\n" +
+	"\x13GetAccountStatement\x12!.ledger.TransactionHistoryRequest\x1a .ledger.AccountStatementResponse\"\x00\x12N\n" +
+	"\rBatchTransfer\x12\x1c.ledger.BatchTransferRequest\x1a\x1d.ledger.BatchTransferResponse\"\x00\x12W\n" +
+	"\x12GetConversionQuote\x12\x1e.ledger.ConversionQuoteRequest\x1a\x1f.ledger.ConversionQuoteResponse\"\x00\x12f\n" +
+	"\x15CrossCurrencyTransfer\x12$.ledger.CrossCurrencyTransferRequest\x1a%.ledger.CrossCurrencyTransferResponse\"\x00\x12N\n" +
+	"\rGetServerInfo\x12\x1c.ledger.GetServerInfoRequest\x1a\x1d.ledger.GetServerInfoResponse\"\x00\x12Q\n" +
+	"\x0eListCurrencies\x12\x1d.ledger.ListCurrenciesRequest\x1a\x1e.ledger.ListCurrenciesResponse\"\x00\x12i\n" +
+	"\x16ListAccountsByCurrency\x12%.ledger.ListAccountsByCurrencyRequest\x1a&.ledger.ListAccountsByCurrencyResponse\"\x00\x12T\n" +
+	"\x0fReverseTransfer\x12\x1e.ledger.ReverseTransferRequest\x1a\x1f.ledger.ReverseTransferResponse\"\x00\x12o\n" +
+	"\x18ReverseTransfersInWindow\x12'.ledger.ReverseTransfersInWindowRequest\x1a(.ledger.ReverseTransfersInWindowResponse\"\x00\x12<\n" +
+	"\aDeposit\x12\x16.ledger.DepositRequest\x1a\x17.ledger.DepositResponse\"\x00\x12W\n" +
+	"\x10SetParentAccount\x12\x1f.ledger.SetParentAccountRequest\x1a .ledger.SetParentAccountResponse\"\x00\x12Z\n" +
+	"\x13GetAggregateBalance\x12\x1f.ledger.AggregateBalanceRequest\x1a .ledger.AggregateBalanceResponse\"\x00\x12T\n" +
+	"\x0fListDeadLetters\x12\x1e.ledger.ListDeadLettersRequest\x1a\x1f.ledger.ListDeadLettersResponse\"\x00\x12W\n" +
+	"\x10RetryDeadLetters\x12\x1f.ledger.RetryDeadLettersRequest\x1a .ledger.RetryDeadLettersResponse\"\x00\x12T\n" +
+	"\x0fRotateJWTSecret\x12\x1e.ledger.RotateJWTSecretRequest\x1a\x1f.ledger.RotateJWTSecretResponse\"\x00\x12Z\n" +
+	"\x11GetTransferStatus\x12 .ledger.GetTransferStatusRequest\x1a!.ledger.GetTransferStatusResponse\"\x00\x12Q\n" +
+	"\x0eGetTransaction\x12\x1d.ledger.GetTransactionRequest\x1a\x1e.ledger.GetTransactionResponse\"\x00\x12N\n" +
+	"\rQueryAuditLog\x12\x1c.ledger.QueryAuditLogRequest\x1a\x1d.ledger.QueryAuditLogResponse\"\x00B\x15Z\x13apex-ledger/pkg/apib\x06proto3"
 
 var (
 	file_proto_ledger_proto_rawDescOnce sync.Once
@@ -884,44 +6287,203 @@ func file_proto_ledger_proto_rawDescGZIP() []byte {
 	return file_proto_ledger_proto_rawDescData
 }
 
-var file_proto_ledger_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_ledger_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_ledger_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
 var file_proto_ledger_proto_goTypes = []any{
-	(*TransferRequest)(nil),       // 0: ledger.TransferRequest
-	(*TransferResponse)(nil),      // 1: ledger.TransferResponse
-	(*BalanceRequest)(nil),        // 2: ledger.BalanceRequest
-	(*BalanceResponse)(nil),       // 3: ledger.BalanceResponse
-	(*CreateAccountRequest)(nil),  // 4: ledger.CreateAccountRequest
-	(*CreateAccountResponse)(nil), // 5: ledger.CreateAccountResponse
-	(*GetAccountRequest)(nil),     // 6: ledger.GetAccountRequest
-	(*GetAccountResponse)(nil),    // 7: ledger.GetAccountResponse
-	(*UpdateAccountRequest)(nil),  // 8: ledger.UpdateAccountRequest
-	(*UpdateAccountResponse)(nil), // 9: ledger.UpdateAccountResponse
-	(*DeleteAccountRequest)(nil),  // 10: ledger.DeleteAccountRequest
-	(*DeleteAccountResponse)(nil), // 11: ledger.DeleteAccountResponse
-	(*ListAccountsRequest)(nil),   // 12: ledger.ListAccountsRequest
-	(*ListAccountsResponse)(nil),  // 13: ledger.ListAccountsResponse
+	(TransferStatus)(0),                       // 0: ledger.TransferStatus
+	(*TransferRequest)(nil),                   // 1: ledger.TransferRequest
+	(*TransferResponse)(nil),                  // 2: ledger.TransferResponse
+	(*BalanceRequest)(nil),                    // 3: ledger.BalanceRequest
+	(*BalanceResponse)(nil),                   // 4: ledger.BalanceResponse
+	(*BalanceAsOfRequest)(nil),                // 5: ledger.BalanceAsOfRequest
+	(*BalanceAsOfResponse)(nil),               // 6: ledger.BalanceAsOfResponse
+	(*BatchGetBalanceRequest)(nil),            // 7: ledger.BatchGetBalanceRequest
+	(*AccountBalance)(nil),                    // 8: ledger.AccountBalance
+	(*BatchGetBalanceResponse)(nil),           // 9: ledger.BatchGetBalanceResponse
+	(*CreateAccountRequest)(nil),              // 10: ledger.CreateAccountRequest
+	(*CreateAccountResponse)(nil),             // 11: ledger.CreateAccountResponse
+	(*GetAccountRequest)(nil),                 // 12: ledger.GetAccountRequest
+	(*GetAccountResponse)(nil),                // 13: ledger.GetAccountResponse
+	(*UpdateAccountRequest)(nil),              // 14: ledger.UpdateAccountRequest
+	(*UpdateAccountResponse)(nil),             // 15: ledger.UpdateAccountResponse
+	(*DeleteAccountRequest)(nil),              // 16: ledger.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),             // 17: ledger.DeleteAccountResponse
+	(*ActivateAccountRequest)(nil),            // 18: ledger.ActivateAccountRequest
+	(*ActivateAccountResponse)(nil),           // 19: ledger.ActivateAccountResponse
+	(*SetNotificationPreferenceRequest)(nil),  // 20: ledger.SetNotificationPreferenceRequest
+	(*SetNotificationPreferenceResponse)(nil), // 21: ledger.SetNotificationPreferenceResponse
+	(*SetAccountSensitiveRequest)(nil),        // 22: ledger.SetAccountSensitiveRequest
+	(*SetAccountSensitiveResponse)(nil),       // 23: ledger.SetAccountSensitiveResponse
+	(*ListAccountsRequest)(nil),               // 24: ledger.ListAccountsRequest
+	(*ListAccountsResponse)(nil),              // 25: ledger.ListAccountsResponse
+	(*TransactionHistoryRequest)(nil),         // 26: ledger.TransactionHistoryRequest
+	(*Transaction)(nil),                       // 27: ledger.Transaction
+	(*TransactionHistoryResponse)(nil),        // 28: ledger.TransactionHistoryResponse
+	(*ReadEventsRequest)(nil),                 // 29: ledger.ReadEventsRequest
+	(*LedgerEvent)(nil),                       // 30: ledger.LedgerEvent
+	(*ReadEventsResponse)(nil),                // 31: ledger.ReadEventsResponse
+	(*ExportAccountsRequest)(nil),             // 32: ledger.ExportAccountsRequest
+	(*ExportAccountsChunk)(nil),               // 33: ledger.ExportAccountsChunk
+	(*ExportTransactionsRequest)(nil),         // 34: ledger.ExportTransactionsRequest
+	(*ExportTransactionsLine)(nil),            // 35: ledger.ExportTransactionsLine
+	(*WatchBalanceRequest)(nil),               // 36: ledger.WatchBalanceRequest
+	(*BalanceUpdate)(nil),                     // 37: ledger.BalanceUpdate
+	(*GetAccountsByOwnerRequest)(nil),         // 38: ledger.GetAccountsByOwnerRequest
+	(*InsufficientFundsDetail)(nil),           // 39: ledger.InsufficientFundsDetail
+	(*AdjustBalanceRequest)(nil),              // 40: ledger.AdjustBalanceRequest
+	(*AdjustBalanceResponse)(nil),             // 41: ledger.AdjustBalanceResponse
+	(*BulkAdjustBalanceChunk)(nil),            // 42: ledger.BulkAdjustBalanceChunk
+	(*AdjustmentResult)(nil),                  // 43: ledger.AdjustmentResult
+	(*BulkAdjustBalanceResponse)(nil),         // 44: ledger.BulkAdjustBalanceResponse
+	(*ImportAccountRecord)(nil),               // 45: ledger.ImportAccountRecord
+	(*ImportFailure)(nil),                     // 46: ledger.ImportFailure
+	(*ImportAccountsResponse)(nil),            // 47: ledger.ImportAccountsResponse
+	(*StatementEntry)(nil),                    // 48: ledger.StatementEntry
+	(*AccountStatementResponse)(nil),          // 49: ledger.AccountStatementResponse
+	(*BatchTransferRequest)(nil),              // 50: ledger.BatchTransferRequest
+	(*BatchTransferResponse)(nil),             // 51: ledger.BatchTransferResponse
+	(*ConversionQuoteRequest)(nil),            // 52: ledger.ConversionQuoteRequest
+	(*ConversionQuoteResponse)(nil),           // 53: ledger.ConversionQuoteResponse
+	(*CrossCurrencyTransferRequest)(nil),      // 54: ledger.CrossCurrencyTransferRequest
+	(*CrossCurrencyTransferResponse)(nil),     // 55: ledger.CrossCurrencyTransferResponse
+	(*GetServerInfoRequest)(nil),              // 56: ledger.GetServerInfoRequest
+	(*GetServerInfoResponse)(nil),             // 57: ledger.GetServerInfoResponse
+	(*ListCurrenciesRequest)(nil),             // 58: ledger.ListCurrenciesRequest
+	(*Currency)(nil),                          // 59: ledger.Currency
+	(*ListCurrenciesResponse)(nil),            // 60: ledger.ListCurrenciesResponse
+	(*ListAccountsByCurrencyRequest)(nil),     // 61: ledger.ListAccountsByCurrencyRequest
+	(*ListAccountsByCurrencyResponse)(nil),    // 62: ledger.ListAccountsByCurrencyResponse
+	(*ReverseTransferRequest)(nil),            // 63: ledger.ReverseTransferRequest
+	(*ReverseTransferResponse)(nil),           // 64: ledger.ReverseTransferResponse
+	(*ReverseTransfersInWindowRequest)(nil),   // 65: ledger.ReverseTransfersInWindowRequest
+	(*WindowReversal)(nil),                    // 66: ledger.WindowReversal
+	(*ReverseTransfersInWindowResponse)(nil),  // 67: ledger.ReverseTransfersInWindowResponse
+	(*DepositRequest)(nil),                    // 68: ledger.DepositRequest
+	(*DepositResponse)(nil),                   // 69: ledger.DepositResponse
+	(*SetParentAccountRequest)(nil),           // 70: ledger.SetParentAccountRequest
+	(*SetParentAccountResponse)(nil),          // 71: ledger.SetParentAccountResponse
+	(*AggregateBalanceRequest)(nil),           // 72: ledger.AggregateBalanceRequest
+	(*AggregateBalanceResponse)(nil),          // 73: ledger.AggregateBalanceResponse
+	(*CurrencyBalance)(nil),                   // 74: ledger.CurrencyBalance
+	(*DeadLetter)(nil),                        // 75: ledger.DeadLetter
+	(*ListDeadLettersRequest)(nil),            // 76: ledger.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),           // 77: ledger.ListDeadLettersResponse
+	(*RetryDeadLettersRequest)(nil),           // 78: ledger.RetryDeadLettersRequest
+	(*RetryDeadLettersResponse)(nil),          // 79: ledger.RetryDeadLettersResponse
+	(*RotateJWTSecretRequest)(nil),            // 80: ledger.RotateJWTSecretRequest
+	(*RotateJWTSecretResponse)(nil),           // 81: ledger.RotateJWTSecretResponse
+	(*GetTransferStatusRequest)(nil),          // 82: ledger.GetTransferStatusRequest
+	(*GetTransferStatusResponse)(nil),         // 83: ledger.GetTransferStatusResponse
+	(*GetTransactionRequest)(nil),             // 84: ledger.GetTransactionRequest
+	(*GetTransactionResponse)(nil),            // 85: ledger.GetTransactionResponse
+	(*AuditEntry)(nil),                        // 86: ledger.AuditEntry
+	(*QueryAuditLogRequest)(nil),              // 87: ledger.QueryAuditLogRequest
+	(*QueryAuditLogResponse)(nil),             // 88: ledger.QueryAuditLogResponse
 }
 var file_proto_ledger_proto_depIdxs = []int32{
-	7,  // 0: ledger.ListAccountsResponse.accounts:type_name -> ledger.GetAccountResponse
-	0,  // 1: ledger.LedgerService.Transfer:input_type -> ledger.TransferRequest
-	2,  // 2: ledger.LedgerService.GetBalance:input_type -> ledger.BalanceRequest
-	4,  // 3: ledger.LedgerService.CreateAccount:input_type -> ledger.CreateAccountRequest
-	6,  // 4: ledger.LedgerService.GetAccount:input_type -> ledger.GetAccountRequest
-	8,  // 5: ledger.LedgerService.UpdateAccount:input_type -> ledger.UpdateAccountRequest
-	10, // 6: ledger.LedgerService.DeleteAccount:input_type -> ledger.DeleteAccountRequest
-	12, // 7: ledger.LedgerService.ListAccounts:input_type -> ledger.ListAccountsRequest
-	1,  // 8: ledger.LedgerService.Transfer:output_type -> ledger.TransferResponse
-	3,  // 9: ledger.LedgerService.GetBalance:output_type -> ledger.BalanceResponse
-	5,  // 10: ledger.LedgerService.CreateAccount:output_type -> ledger.CreateAccountResponse
-	7,  // 11: ledger.LedgerService.GetAccount:output_type -> ledger.GetAccountResponse
-	9,  // 12: ledger.LedgerService.UpdateAccount:output_type -> ledger.UpdateAccountResponse
-	11, // 13: ledger.LedgerService.DeleteAccount:output_type -> ledger.DeleteAccountResponse
-	13, // 14: ledger.LedgerService.ListAccounts:output_type -> ledger.ListAccountsResponse
-	8,  // [8:15] is the sub-list for method output_type
-	1,  // [1:8] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	0,  // 0: ledger.TransferResponse.transfer_status:type_name -> ledger.TransferStatus
+	8,  // 1: ledger.BatchGetBalanceResponse.balances:type_name -> ledger.AccountBalance
+	13, // 2: ledger.ListAccountsResponse.accounts:type_name -> ledger.GetAccountResponse
+	27, // 3: ledger.TransactionHistoryResponse.transactions:type_name -> ledger.Transaction
+	30, // 4: ledger.ReadEventsResponse.events:type_name -> ledger.LedgerEvent
+	43, // 5: ledger.BulkAdjustBalanceResponse.results:type_name -> ledger.AdjustmentResult
+	46, // 6: ledger.ImportAccountsResponse.failures:type_name -> ledger.ImportFailure
+	27, // 7: ledger.StatementEntry.transaction:type_name -> ledger.Transaction
+	48, // 8: ledger.AccountStatementResponse.entries:type_name -> ledger.StatementEntry
+	1,  // 9: ledger.BatchTransferRequest.transfers:type_name -> ledger.TransferRequest
+	0,  // 10: ledger.BatchTransferResponse.transfer_status:type_name -> ledger.TransferStatus
+	0,  // 11: ledger.CrossCurrencyTransferResponse.transfer_status:type_name -> ledger.TransferStatus
+	59, // 12: ledger.ListCurrenciesResponse.currencies:type_name -> ledger.Currency
+	13, // 13: ledger.ListAccountsByCurrencyResponse.accounts:type_name -> ledger.GetAccountResponse
+	66, // 14: ledger.ReverseTransfersInWindowResponse.results:type_name -> ledger.WindowReversal
+	74, // 15: ledger.AggregateBalanceResponse.balances:type_name -> ledger.CurrencyBalance
+	75, // 16: ledger.ListDeadLettersResponse.dead_letters:type_name -> ledger.DeadLetter
+	0,  // 17: ledger.GetTransferStatusResponse.status:type_name -> ledger.TransferStatus
+	27, // 18: ledger.GetTransactionResponse.transaction:type_name -> ledger.Transaction
+	86, // 19: ledger.QueryAuditLogResponse.entries:type_name -> ledger.AuditEntry
+	1,  // 20: ledger.LedgerService.Transfer:input_type -> ledger.TransferRequest
+	3,  // 21: ledger.LedgerService.GetBalance:input_type -> ledger.BalanceRequest
+	7,  // 22: ledger.LedgerService.BatchGetBalance:input_type -> ledger.BatchGetBalanceRequest
+	5,  // 23: ledger.LedgerService.GetBalanceAsOf:input_type -> ledger.BalanceAsOfRequest
+	10, // 24: ledger.LedgerService.CreateAccount:input_type -> ledger.CreateAccountRequest
+	12, // 25: ledger.LedgerService.GetAccount:input_type -> ledger.GetAccountRequest
+	14, // 26: ledger.LedgerService.UpdateAccount:input_type -> ledger.UpdateAccountRequest
+	16, // 27: ledger.LedgerService.DeleteAccount:input_type -> ledger.DeleteAccountRequest
+	18, // 28: ledger.LedgerService.ActivateAccount:input_type -> ledger.ActivateAccountRequest
+	20, // 29: ledger.LedgerService.SetNotificationPreference:input_type -> ledger.SetNotificationPreferenceRequest
+	22, // 30: ledger.LedgerService.SetAccountSensitive:input_type -> ledger.SetAccountSensitiveRequest
+	24, // 31: ledger.LedgerService.ListAccounts:input_type -> ledger.ListAccountsRequest
+	26, // 32: ledger.LedgerService.GetTransactionHistory:input_type -> ledger.TransactionHistoryRequest
+	29, // 33: ledger.LedgerService.ReadEvents:input_type -> ledger.ReadEventsRequest
+	32, // 34: ledger.LedgerService.ExportAccounts:input_type -> ledger.ExportAccountsRequest
+	34, // 35: ledger.LedgerService.ExportTransactions:input_type -> ledger.ExportTransactionsRequest
+	36, // 36: ledger.LedgerService.WatchBalance:input_type -> ledger.WatchBalanceRequest
+	38, // 37: ledger.LedgerService.GetAccountsByOwner:input_type -> ledger.GetAccountsByOwnerRequest
+	40, // 38: ledger.LedgerService.AdjustBalance:input_type -> ledger.AdjustBalanceRequest
+	42, // 39: ledger.LedgerService.BulkAdjustBalance:input_type -> ledger.BulkAdjustBalanceChunk
+	45, // 40: ledger.LedgerService.ImportAccounts:input_type -> ledger.ImportAccountRecord
+	26, // 41: ledger.LedgerService.GetAccountStatement:input_type -> ledger.TransactionHistoryRequest
+	50, // 42: ledger.LedgerService.BatchTransfer:input_type -> ledger.BatchTransferRequest
+	52, // 43: ledger.LedgerService.GetConversionQuote:input_type -> ledger.ConversionQuoteRequest
+	54, // 44: ledger.LedgerService.CrossCurrencyTransfer:input_type -> ledger.CrossCurrencyTransferRequest
+	56, // 45: ledger.LedgerService.GetServerInfo:input_type -> ledger.GetServerInfoRequest
+	58, // 46: ledger.LedgerService.ListCurrencies:input_type -> ledger.ListCurrenciesRequest
+	61, // 47: ledger.LedgerService.ListAccountsByCurrency:input_type -> ledger.ListAccountsByCurrencyRequest
+	63, // 48: ledger.LedgerService.ReverseTransfer:input_type -> ledger.ReverseTransferRequest
+	65, // 49: ledger.LedgerService.ReverseTransfersInWindow:input_type -> ledger.ReverseTransfersInWindowRequest
+	68, // 50: ledger.LedgerService.Deposit:input_type -> ledger.DepositRequest
+	70, // 51: ledger.LedgerService.SetParentAccount:input_type -> ledger.SetParentAccountRequest
+	72, // 52: ledger.LedgerService.GetAggregateBalance:input_type -> ledger.AggregateBalanceRequest
+	76, // 53: ledger.LedgerService.ListDeadLetters:input_type -> ledger.ListDeadLettersRequest
+	78, // 54: ledger.LedgerService.RetryDeadLetters:input_type -> ledger.RetryDeadLettersRequest
+	80, // 55: ledger.LedgerService.RotateJWTSecret:input_type -> ledger.RotateJWTSecretRequest
+	82, // 56: ledger.LedgerService.GetTransferStatus:input_type -> ledger.GetTransferStatusRequest
+	84, // 57: ledger.LedgerService.GetTransaction:input_type -> ledger.GetTransactionRequest
+	87, // 58: ledger.LedgerService.QueryAuditLog:input_type -> ledger.QueryAuditLogRequest
+	2,  // 59: ledger.LedgerService.Transfer:output_type -> ledger.TransferResponse
+	4,  // 60: ledger.LedgerService.GetBalance:output_type -> ledger.BalanceResponse
+	9,  // 61: ledger.LedgerService.BatchGetBalance:output_type -> ledger.BatchGetBalanceResponse
+	6,  // 62: ledger.LedgerService.GetBalanceAsOf:output_type -> ledger.BalanceAsOfResponse
+	11, // 63: ledger.LedgerService.CreateAccount:output_type -> ledger.CreateAccountResponse
+	13, // 64: ledger.LedgerService.GetAccount:output_type -> ledger.GetAccountResponse
+	15, // 65: ledger.LedgerService.UpdateAccount:output_type -> ledger.UpdateAccountResponse
+	17, // 66: ledger.LedgerService.DeleteAccount:output_type -> ledger.DeleteAccountResponse
+	19, // 67: ledger.LedgerService.ActivateAccount:output_type -> ledger.ActivateAccountResponse
+	21, // 68: ledger.LedgerService.SetNotificationPreference:output_type -> ledger.SetNotificationPreferenceResponse
+	23, // 69: ledger.LedgerService.SetAccountSensitive:output_type -> ledger.SetAccountSensitiveResponse
+	25, // 70: ledger.LedgerService.ListAccounts:output_type -> ledger.ListAccountsResponse
+	28, // 71: ledger.LedgerService.GetTransactionHistory:output_type -> ledger.TransactionHistoryResponse
+	31, // 72: ledger.LedgerService.ReadEvents:output_type -> ledger.ReadEventsResponse
+	33, // 73: ledger.LedgerService.ExportAccounts:output_type -> ledger.ExportAccountsChunk
+	35, // 74: ledger.LedgerService.ExportTransactions:output_type -> ledger.ExportTransactionsLine
+	37, // 75: ledger.LedgerService.WatchBalance:output_type -> ledger.BalanceUpdate
+	25, // 76: ledger.LedgerService.GetAccountsByOwner:output_type -> ledger.ListAccountsResponse
+	41, // 77: ledger.LedgerService.AdjustBalance:output_type -> ledger.AdjustBalanceResponse
+	44, // 78: ledger.LedgerService.BulkAdjustBalance:output_type -> ledger.BulkAdjustBalanceResponse
+	47, // 79: ledger.LedgerService.ImportAccounts:output_type -> ledger.ImportAccountsResponse
+	49, // 80: ledger.LedgerService.GetAccountStatement:output_type -> ledger.AccountStatementResponse
+	51, // 81: ledger.LedgerService.BatchTransfer:output_type -> ledger.BatchTransferResponse
+	53, // 82: ledger.LedgerService.GetConversionQuote:output_type -> ledger.ConversionQuoteResponse
+	55, // 83: ledger.LedgerService.CrossCurrencyTransfer:output_type -> ledger.CrossCurrencyTransferResponse
+	57, // 84: ledger.LedgerService.GetServerInfo:output_type -> ledger.GetServerInfoResponse
+	60, // 85: ledger.LedgerService.ListCurrencies:output_type -> ledger.ListCurrenciesResponse
+	62, // 86: ledger.LedgerService.ListAccountsByCurrency:output_type -> ledger.ListAccountsByCurrencyResponse
+	64, // 87: ledger.LedgerService.ReverseTransfer:output_type -> ledger.ReverseTransferResponse
+	67, // 88: ledger.LedgerService.ReverseTransfersInWindow:output_type -> ledger.ReverseTransfersInWindowResponse
+	69, // 89: ledger.LedgerService.Deposit:output_type -> ledger.DepositResponse
+	71, // 90: ledger.LedgerService.SetParentAccount:output_type -> ledger.SetParentAccountResponse
+	73, // 91: ledger.LedgerService.GetAggregateBalance:output_type -> ledger.AggregateBalanceResponse
+	77, // 92: ledger.LedgerService.ListDeadLetters:output_type -> ledger.ListDeadLettersResponse
+	79, // 93: ledger.LedgerService.RetryDeadLetters:output_type -> ledger.RetryDeadLettersResponse
+	81, // 94: ledger.LedgerService.RotateJWTSecret:output_type -> ledger.RotateJWTSecretResponse
+	83, // 95: ledger.LedgerService.GetTransferStatus:output_type -> ledger.GetTransferStatusResponse
+	85, // 96: ledger.LedgerService.GetTransaction:output_type -> ledger.GetTransactionResponse
+	88, // 97: ledger.LedgerService.QueryAuditLog:output_type -> ledger.QueryAuditLogResponse
+	59, // [59:98] is the sub-list for method output_type
+	20, // [20:59] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_proto_ledger_proto_init() }
@@ -929,18 +6491,21 @@ func file_proto_ledger_proto_init() {
 	if File_proto_ledger_proto != nil {
 		return
 	}
+	file_proto_ledger_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_ledger_proto_msgTypes[47].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ledger_proto_rawDesc), len(file_proto_ledger_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      1,
+			NumMessages:   88,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_ledger_proto_goTypes,
 		DependencyIndexes: file_proto_ledger_proto_depIdxs,
+		EnumInfos:         file_proto_ledger_proto_enumTypes,
 		MessageInfos:      file_proto_ledger_proto_msgTypes,
 	}.Build()
 	File_proto_ledger_proto = out.File
@@ -19,13 +19,45 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LedgerService_Transfer_FullMethodName      = "/ledger.LedgerService/Transfer"
-	LedgerService_GetBalance_FullMethodName    = "/ledger.LedgerService/GetBalance"
-	LedgerService_CreateAccount_FullMethodName = "/ledger.LedgerService/CreateAccount"
-	LedgerService_GetAccount_FullMethodName    = "/ledger.LedgerService/GetAccount"
-	LedgerService_UpdateAccount_FullMethodName = "/ledger.LedgerService/UpdateAccount"
-	LedgerService_DeleteAccount_FullMethodName = "/ledger.LedgerService/DeleteAccount"
-	LedgerService_ListAccounts_FullMethodName  = "/ledger.LedgerService/ListAccounts"
+	LedgerService_Transfer_FullMethodName                  = "/ledger.LedgerService/Transfer"
+	LedgerService_GetBalance_FullMethodName                = "/ledger.LedgerService/GetBalance"
+	LedgerService_BatchGetBalance_FullMethodName           = "/ledger.LedgerService/BatchGetBalance"
+	LedgerService_GetBalanceAsOf_FullMethodName            = "/ledger.LedgerService/GetBalanceAsOf"
+	LedgerService_CreateAccount_FullMethodName             = "/ledger.LedgerService/CreateAccount"
+	LedgerService_GetAccount_FullMethodName                = "/ledger.LedgerService/GetAccount"
+	LedgerService_UpdateAccount_FullMethodName             = "/ledger.LedgerService/UpdateAccount"
+	LedgerService_DeleteAccount_FullMethodName             = "/ledger.LedgerService/DeleteAccount"
+	LedgerService_ActivateAccount_FullMethodName           = "/ledger.LedgerService/ActivateAccount"
+	LedgerService_SetNotificationPreference_FullMethodName = "/ledger.LedgerService/SetNotificationPreference"
+	LedgerService_SetAccountSensitive_FullMethodName       = "/ledger.LedgerService/SetAccountSensitive"
+	LedgerService_ListAccounts_FullMethodName              = "/ledger.LedgerService/ListAccounts"
+	LedgerService_GetTransactionHistory_FullMethodName     = "/ledger.LedgerService/GetTransactionHistory"
+	LedgerService_ReadEvents_FullMethodName                = "/ledger.LedgerService/ReadEvents"
+	LedgerService_ExportAccounts_FullMethodName            = "/ledger.LedgerService/ExportAccounts"
+	LedgerService_ExportTransactions_FullMethodName        = "/ledger.LedgerService/ExportTransactions"
+	LedgerService_WatchBalance_FullMethodName              = "/ledger.LedgerService/WatchBalance"
+	LedgerService_GetAccountsByOwner_FullMethodName        = "/ledger.LedgerService/GetAccountsByOwner"
+	LedgerService_AdjustBalance_FullMethodName             = "/ledger.LedgerService/AdjustBalance"
+	LedgerService_BulkAdjustBalance_FullMethodName         = "/ledger.LedgerService/BulkAdjustBalance"
+	LedgerService_ImportAccounts_FullMethodName            = "/ledger.LedgerService/ImportAccounts"
+	LedgerService_GetAccountStatement_FullMethodName       = "/ledger.LedgerService/GetAccountStatement"
+	LedgerService_BatchTransfer_FullMethodName             = "/ledger.LedgerService/BatchTransfer"
+	LedgerService_GetConversionQuote_FullMethodName        = "/ledger.LedgerService/GetConversionQuote"
+	LedgerService_CrossCurrencyTransfer_FullMethodName     = "/ledger.LedgerService/CrossCurrencyTransfer"
+	LedgerService_GetServerInfo_FullMethodName             = "/ledger.LedgerService/GetServerInfo"
+	LedgerService_ListCurrencies_FullMethodName            = "/ledger.LedgerService/ListCurrencies"
+	LedgerService_ListAccountsByCurrency_FullMethodName    = "/ledger.LedgerService/ListAccountsByCurrency"
+	LedgerService_ReverseTransfer_FullMethodName           = "/ledger.LedgerService/ReverseTransfer"
+	LedgerService_ReverseTransfersInWindow_FullMethodName  = "/ledger.LedgerService/ReverseTransfersInWindow"
+	LedgerService_Deposit_FullMethodName                   = "/ledger.LedgerService/Deposit"
+	LedgerService_SetParentAccount_FullMethodName          = "/ledger.LedgerService/SetParentAccount"
+	LedgerService_GetAggregateBalance_FullMethodName       = "/ledger.LedgerService/GetAggregateBalance"
+	LedgerService_ListDeadLetters_FullMethodName           = "/ledger.LedgerService/ListDeadLetters"
+	LedgerService_RetryDeadLetters_FullMethodName          = "/ledger.LedgerService/RetryDeadLetters"
+	LedgerService_RotateJWTSecret_FullMethodName           = "/ledger.LedgerService/RotateJWTSecret"
+	LedgerService_GetTransferStatus_FullMethodName         = "/ledger.LedgerService/GetTransferStatus"
+	LedgerService_GetTransaction_FullMethodName            = "/ledger.LedgerService/GetTransaction"
+	LedgerService_QueryAuditLog_FullMethodName             = "/ledger.LedgerService/QueryAuditLog"
 )
 
 // LedgerServiceClient is the client API for LedgerService service.
@@ -36,6 +68,10 @@ type LedgerServiceClient interface {
 	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
 	// GetBalance provides real-time account status
 	GetBalance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	// BatchGetBalance retrieves the balances of several accounts in one call
+	BatchGetBalance(ctx context.Context, in *BatchGetBalanceRequest, opts ...grpc.CallOption) (*BatchGetBalanceResponse, error)
+	// GetBalanceAsOf reconstructs an account's balance at a past point in time
+	GetBalanceAsOf(ctx context.Context, in *BalanceAsOfRequest, opts ...grpc.CallOption) (*BalanceAsOfResponse, error)
 	// CRUD Operations
 	// CreateAccount creates a new account
 	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
@@ -45,8 +81,70 @@ type LedgerServiceClient interface {
 	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*UpdateAccountResponse, error)
 	// DeleteAccount deletes an account
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error)
+	// ActivateAccount makes a pending account active so it can transfer money (admin only)
+	ActivateAccount(ctx context.Context, in *ActivateAccountRequest, opts ...grpc.CallOption) (*ActivateAccountResponse, error)
+	// SetNotificationPreference chooses how an account's notifications are delivered
+	SetNotificationPreference(ctx context.Context, in *SetNotificationPreferenceRequest, opts ...grpc.CallOption) (*SetNotificationPreferenceResponse, error)
+	// SetAccountSensitive turns read auditing on or off for an account (admin only)
+	SetAccountSensitive(ctx context.Context, in *SetAccountSensitiveRequest, opts ...grpc.CallOption) (*SetAccountSensitiveResponse, error)
 	// ListAccounts retrieves all accounts
 	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// GetTransactionHistory returns an account's transactions, newest first
+	GetTransactionHistory(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistoryResponse, error)
+	// ReadEvents returns an account's balance-change events after a sequence number, oldest first
+	ReadEvents(ctx context.Context, in *ReadEventsRequest, opts ...grpc.CallOption) (*ReadEventsResponse, error)
+	// ExportAccounts streams account balances as CSV chunks
+	ExportAccounts(ctx context.Context, in *ExportAccountsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAccountsChunk], error)
+	// ExportTransactions streams transactions as JSON lines, oldest first
+	ExportTransactions(ctx context.Context, in *ExportTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTransactionsLine], error)
+	// WatchBalance streams an account's balance: a snapshot, then an update
+	// for every change until the client disconnects
+	WatchBalance(ctx context.Context, in *WatchBalanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BalanceUpdate], error)
+	// GetAccountsByOwner lists all accounts belonging to one owner
+	GetAccountsByOwner(ctx context.Context, in *GetAccountsByOwnerRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// AdjustBalance applies an audited balance correction (admin only)
+	AdjustBalance(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AdjustBalanceResponse, error)
+	// BulkAdjustBalance applies balance corrections uploaded as CSV chunks (admin only)
+	BulkAdjustBalance(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse], error)
+	// ImportAccounts bulk-creates accounts streamed by the client
+	ImportAccounts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportAccountRecord, ImportAccountsResponse], error)
+	// GetAccountStatement returns an account's transactions with the running balance, newest first
+	GetAccountStatement(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*AccountStatementResponse, error)
+	// BatchTransfer applies several transfers atomically
+	BatchTransfer(ctx context.Context, in *BatchTransferRequest, opts ...grpc.CallOption) (*BatchTransferResponse, error)
+	// GetConversionQuote prices a currency conversion and returns a short-lived quote
+	GetConversionQuote(ctx context.Context, in *ConversionQuoteRequest, opts ...grpc.CallOption) (*ConversionQuoteResponse, error)
+	// CrossCurrencyTransfer moves money between accounts in different currencies
+	CrossCurrencyTransfer(ctx context.Context, in *CrossCurrencyTransferRequest, opts ...grpc.CallOption) (*CrossCurrencyTransferResponse, error)
+	// GetServerInfo reports the server version and enabled features (no auth required)
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error)
+	// ListCurrencies lists the currencies accounts can be created in
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	// ListAccountsByCurrency lists one currency's accounts with the total balance held in it
+	ListAccountsByCurrency(ctx context.Context, in *ListAccountsByCurrencyRequest, opts ...grpc.CallOption) (*ListAccountsByCurrencyResponse, error)
+	// ReverseTransfer moves all or part of a prior transfer back to its sender (admin only)
+	ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*ReverseTransferResponse, error)
+	// ReverseTransfersInWindow reverses every transfer created in a time window, each on its own (admin only)
+	ReverseTransfersInWindow(ctx context.Context, in *ReverseTransfersInWindowRequest, opts ...grpc.CallOption) (*ReverseTransfersInWindowResponse, error)
+	// Deposit credits money arriving from outside the ledger, idempotently per external_reference (admin only)
+	Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error)
+	// SetParentAccount places an account under a parent for aggregate reporting (admin only)
+	SetParentAccount(ctx context.Context, in *SetParentAccountRequest, opts ...grpc.CallOption) (*SetParentAccountResponse, error)
+	// GetAggregateBalance sums an account's balance with those of all its descendants
+	GetAggregateBalance(ctx context.Context, in *AggregateBalanceRequest, opts ...grpc.CallOption) (*AggregateBalanceResponse, error)
+	// ListDeadLetters pages through notifications that could not be delivered (admin only)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	// RetryDeadLetters re-enqueues dead-lettered notifications for delivery (admin only)
+	RetryDeadLetters(ctx context.Context, in *RetryDeadLettersRequest, opts ...grpc.CallOption) (*RetryDeadLettersResponse, error)
+	// RotateJWTSecret makes a new secret primary for token validation, keeping
+	// the outgoing one valid for a grace period (admin only)
+	RotateJWTSecret(ctx context.Context, in *RotateJWTSecretRequest, opts ...grpc.CallOption) (*RotateJWTSecretResponse, error)
+	// GetTransferStatus reports where a transfer is in its lifecycle
+	GetTransferStatus(ctx context.Context, in *GetTransferStatusRequest, opts ...grpc.CallOption) (*GetTransferStatusResponse, error)
+	// GetTransaction retrieves one transaction of any kind by ID
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	// QueryAuditLog pages through the record of mutating operations (admin only)
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
 }
 
 type ledgerServiceClient struct {
@@ -77,6 +175,26 @@ func (c *ledgerServiceClient) GetBalance(ctx context.Context, in *BalanceRequest
 	return out, nil
 }
 
+func (c *ledgerServiceClient) BatchGetBalance(ctx context.Context, in *BatchGetBalanceRequest, opts ...grpc.CallOption) (*BatchGetBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetBalanceResponse)
+	err := c.cc.Invoke(ctx, LedgerService_BatchGetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetBalanceAsOf(ctx context.Context, in *BalanceAsOfRequest, opts ...grpc.CallOption) (*BalanceAsOfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceAsOfResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetBalanceAsOf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ledgerServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateAccountResponse)
@@ -87,239 +205,1267 @@ func (c *ledgerServiceClient) CreateAccount(ctx context.Context, in *CreateAccou
 	return out, nil
 }
 
-func (c *ledgerServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetAccountResponse)
-	err := c.cc.Invoke(ctx, LedgerService_GetAccount_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (c *ledgerServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*UpdateAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateAccountResponse)
+	err := c.cc.Invoke(ctx, LedgerService_UpdateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAccountResponse)
+	err := c.cc.Invoke(ctx, LedgerService_DeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ActivateAccount(ctx context.Context, in *ActivateAccountRequest, opts ...grpc.CallOption) (*ActivateAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ActivateAccountResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ActivateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetNotificationPreference(ctx context.Context, in *SetNotificationPreferenceRequest, opts ...grpc.CallOption) (*SetNotificationPreferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetNotificationPreferenceResponse)
+	err := c.cc.Invoke(ctx, LedgerService_SetNotificationPreference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetAccountSensitive(ctx context.Context, in *SetAccountSensitiveRequest, opts ...grpc.CallOption) (*SetAccountSensitiveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAccountSensitiveResponse)
+	err := c.cc.Invoke(ctx, LedgerService_SetAccountSensitive_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetTransactionHistory(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*TransactionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionHistoryResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetTransactionHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ReadEvents(ctx context.Context, in *ReadEventsRequest, opts ...grpc.CallOption) (*ReadEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadEventsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ReadEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ExportAccounts(ctx context.Context, in *ExportAccountsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportAccountsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[0], LedgerService_ExportAccounts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAccountsRequest, ExportAccountsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_ExportAccountsClient = grpc.ServerStreamingClient[ExportAccountsChunk]
+
+func (c *ledgerServiceClient) ExportTransactions(ctx context.Context, in *ExportTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportTransactionsLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[1], LedgerService_ExportTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportTransactionsRequest, ExportTransactionsLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_ExportTransactionsClient = grpc.ServerStreamingClient[ExportTransactionsLine]
+
+func (c *ledgerServiceClient) WatchBalance(ctx context.Context, in *WatchBalanceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BalanceUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[2], LedgerService_WatchBalance_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBalanceRequest, BalanceUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_WatchBalanceClient = grpc.ServerStreamingClient[BalanceUpdate]
+
+func (c *ledgerServiceClient) GetAccountsByOwner(ctx context.Context, in *GetAccountsByOwnerRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetAccountsByOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AdjustBalance(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AdjustBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustBalanceResponse)
+	err := c.cc.Invoke(ctx, LedgerService_AdjustBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) BulkAdjustBalance(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[3], LedgerService_BulkAdjustBalance_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_BulkAdjustBalanceClient = grpc.ClientStreamingClient[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse]
+
+func (c *ledgerServiceClient) ImportAccounts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportAccountRecord, ImportAccountsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[4], LedgerService_ImportAccounts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportAccountRecord, ImportAccountsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_ImportAccountsClient = grpc.ClientStreamingClient[ImportAccountRecord, ImportAccountsResponse]
+
+func (c *ledgerServiceClient) GetAccountStatement(ctx context.Context, in *TransactionHistoryRequest, opts ...grpc.CallOption) (*AccountStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountStatementResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetAccountStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) BatchTransfer(ctx context.Context, in *BatchTransferRequest, opts ...grpc.CallOption) (*BatchTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchTransferResponse)
+	err := c.cc.Invoke(ctx, LedgerService_BatchTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetConversionQuote(ctx context.Context, in *ConversionQuoteRequest, opts ...grpc.CallOption) (*ConversionQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConversionQuoteResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetConversionQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CrossCurrencyTransfer(ctx context.Context, in *CrossCurrencyTransferRequest, opts ...grpc.CallOption) (*CrossCurrencyTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrossCurrencyTransferResponse)
+	err := c.cc.Invoke(ctx, LedgerService_CrossCurrencyTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*GetServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetServerInfoResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListCurrencies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListAccountsByCurrency(ctx context.Context, in *ListAccountsByCurrencyRequest, opts ...grpc.CallOption) (*ListAccountsByCurrencyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsByCurrencyResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListAccountsByCurrency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*ReverseTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReverseTransferResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ReverseTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ReverseTransfersInWindow(ctx context.Context, in *ReverseTransfersInWindowRequest, opts ...grpc.CallOption) (*ReverseTransfersInWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReverseTransfersInWindowResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ReverseTransfersInWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DepositResponse)
+	err := c.cc.Invoke(ctx, LedgerService_Deposit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetParentAccount(ctx context.Context, in *SetParentAccountRequest, opts ...grpc.CallOption) (*SetParentAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetParentAccountResponse)
+	err := c.cc.Invoke(ctx, LedgerService_SetParentAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetAggregateBalance(ctx context.Context, in *AggregateBalanceRequest, opts ...grpc.CallOption) (*AggregateBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AggregateBalanceResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetAggregateBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RetryDeadLetters(ctx context.Context, in *RetryDeadLettersRequest, opts ...grpc.CallOption) (*RetryDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryDeadLettersResponse)
+	err := c.cc.Invoke(ctx, LedgerService_RetryDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RotateJWTSecret(ctx context.Context, in *RotateJWTSecretRequest, opts ...grpc.CallOption) (*RotateJWTSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RotateJWTSecretResponse)
+	err := c.cc.Invoke(ctx, LedgerService_RotateJWTSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetTransferStatus(ctx context.Context, in *GetTransferStatusRequest, opts ...grpc.CallOption) (*GetTransferStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferStatusResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetTransferStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, LedgerService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LedgerServiceServer is the server API for LedgerService service.
+// All implementations must embed UnimplementedLedgerServiceServer
+// for forward compatibility.
+type LedgerServiceServer interface {
+	// Transfer handles double-entry movements
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	// GetBalance provides real-time account status
+	GetBalance(context.Context, *BalanceRequest) (*BalanceResponse, error)
+	// BatchGetBalance retrieves the balances of several accounts in one call
+	BatchGetBalance(context.Context, *BatchGetBalanceRequest) (*BatchGetBalanceResponse, error)
+	// GetBalanceAsOf reconstructs an account's balance at a past point in time
+	GetBalanceAsOf(context.Context, *BalanceAsOfRequest) (*BalanceAsOfResponse, error)
+	// CRUD Operations
+	// CreateAccount creates a new account
+	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
+	// GetAccount retrieves full account details
+	GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error)
+	// UpdateAccount updates account information
+	UpdateAccount(context.Context, *UpdateAccountRequest) (*UpdateAccountResponse, error)
+	// DeleteAccount deletes an account
+	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
+	// ActivateAccount makes a pending account active so it can transfer money (admin only)
+	ActivateAccount(context.Context, *ActivateAccountRequest) (*ActivateAccountResponse, error)
+	// SetNotificationPreference chooses how an account's notifications are delivered
+	SetNotificationPreference(context.Context, *SetNotificationPreferenceRequest) (*SetNotificationPreferenceResponse, error)
+	// SetAccountSensitive turns read auditing on or off for an account (admin only)
+	SetAccountSensitive(context.Context, *SetAccountSensitiveRequest) (*SetAccountSensitiveResponse, error)
+	// ListAccounts retrieves all accounts
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// GetTransactionHistory returns an account's transactions, newest first
+	GetTransactionHistory(context.Context, *TransactionHistoryRequest) (*TransactionHistoryResponse, error)
+	// ReadEvents returns an account's balance-change events after a sequence number, oldest first
+	ReadEvents(context.Context, *ReadEventsRequest) (*ReadEventsResponse, error)
+	// ExportAccounts streams account balances as CSV chunks
+	ExportAccounts(*ExportAccountsRequest, grpc.ServerStreamingServer[ExportAccountsChunk]) error
+	// ExportTransactions streams transactions as JSON lines, oldest first
+	ExportTransactions(*ExportTransactionsRequest, grpc.ServerStreamingServer[ExportTransactionsLine]) error
+	// WatchBalance streams an account's balance: a snapshot, then an update
+	// for every change until the client disconnects
+	WatchBalance(*WatchBalanceRequest, grpc.ServerStreamingServer[BalanceUpdate]) error
+	// GetAccountsByOwner lists all accounts belonging to one owner
+	GetAccountsByOwner(context.Context, *GetAccountsByOwnerRequest) (*ListAccountsResponse, error)
+	// AdjustBalance applies an audited balance correction (admin only)
+	AdjustBalance(context.Context, *AdjustBalanceRequest) (*AdjustBalanceResponse, error)
+	// BulkAdjustBalance applies balance corrections uploaded as CSV chunks (admin only)
+	BulkAdjustBalance(grpc.ClientStreamingServer[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse]) error
+	// ImportAccounts bulk-creates accounts streamed by the client
+	ImportAccounts(grpc.ClientStreamingServer[ImportAccountRecord, ImportAccountsResponse]) error
+	// GetAccountStatement returns an account's transactions with the running balance, newest first
+	GetAccountStatement(context.Context, *TransactionHistoryRequest) (*AccountStatementResponse, error)
+	// BatchTransfer applies several transfers atomically
+	BatchTransfer(context.Context, *BatchTransferRequest) (*BatchTransferResponse, error)
+	// GetConversionQuote prices a currency conversion and returns a short-lived quote
+	GetConversionQuote(context.Context, *ConversionQuoteRequest) (*ConversionQuoteResponse, error)
+	// CrossCurrencyTransfer moves money between accounts in different currencies
+	CrossCurrencyTransfer(context.Context, *CrossCurrencyTransferRequest) (*CrossCurrencyTransferResponse, error)
+	// GetServerInfo reports the server version and enabled features (no auth required)
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error)
+	// ListCurrencies lists the currencies accounts can be created in
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	// ListAccountsByCurrency lists one currency's accounts with the total balance held in it
+	ListAccountsByCurrency(context.Context, *ListAccountsByCurrencyRequest) (*ListAccountsByCurrencyResponse, error)
+	// ReverseTransfer moves all or part of a prior transfer back to its sender (admin only)
+	ReverseTransfer(context.Context, *ReverseTransferRequest) (*ReverseTransferResponse, error)
+	// ReverseTransfersInWindow reverses every transfer created in a time window, each on its own (admin only)
+	ReverseTransfersInWindow(context.Context, *ReverseTransfersInWindowRequest) (*ReverseTransfersInWindowResponse, error)
+	// Deposit credits money arriving from outside the ledger, idempotently per external_reference (admin only)
+	Deposit(context.Context, *DepositRequest) (*DepositResponse, error)
+	// SetParentAccount places an account under a parent for aggregate reporting (admin only)
+	SetParentAccount(context.Context, *SetParentAccountRequest) (*SetParentAccountResponse, error)
+	// GetAggregateBalance sums an account's balance with those of all its descendants
+	GetAggregateBalance(context.Context, *AggregateBalanceRequest) (*AggregateBalanceResponse, error)
+	// ListDeadLetters pages through notifications that could not be delivered (admin only)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	// RetryDeadLetters re-enqueues dead-lettered notifications for delivery (admin only)
+	RetryDeadLetters(context.Context, *RetryDeadLettersRequest) (*RetryDeadLettersResponse, error)
+	// RotateJWTSecret makes a new secret primary for token validation, keeping
+	// the outgoing one valid for a grace period (admin only)
+	RotateJWTSecret(context.Context, *RotateJWTSecretRequest) (*RotateJWTSecretResponse, error)
+	// GetTransferStatus reports where a transfer is in its lifecycle
+	GetTransferStatus(context.Context, *GetTransferStatusRequest) (*GetTransferStatusResponse, error)
+	// GetTransaction retrieves one transaction of any kind by ID
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	// QueryAuditLog pages through the record of mutating operations (admin only)
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	mustEmbedUnimplementedLedgerServiceServer()
+}
+
+// UnimplementedLedgerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLedgerServiceServer struct{}
+
+func (UnimplementedLedgerServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetBalance(context.Context, *BalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) BatchGetBalance(context.Context, *BatchGetBalanceRequest) (*BatchGetBalanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetBalanceAsOf(context.Context, *BalanceAsOfRequest) (*BalanceAsOfResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBalanceAsOf not implemented")
+}
+func (UnimplementedLedgerServiceServer) CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) UpdateAccount(context.Context, *UpdateAccountRequest) (*UpdateAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) ActivateAccount(context.Context, *ActivateAccountRequest) (*ActivateAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ActivateAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) SetNotificationPreference(context.Context, *SetNotificationPreferenceRequest) (*SetNotificationPreferenceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetNotificationPreference not implemented")
+}
+func (UnimplementedLedgerServiceServer) SetAccountSensitive(context.Context, *SetAccountSensitiveRequest) (*SetAccountSensitiveResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetAccountSensitive not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetTransactionHistory(context.Context, *TransactionHistoryRequest) (*TransactionHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransactionHistory not implemented")
+}
+func (UnimplementedLedgerServiceServer) ReadEvents(context.Context, *ReadEventsRequest) (*ReadEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReadEvents not implemented")
+}
+func (UnimplementedLedgerServiceServer) ExportAccounts(*ExportAccountsRequest, grpc.ServerStreamingServer[ExportAccountsChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportAccounts not implemented")
+}
+func (UnimplementedLedgerServiceServer) ExportTransactions(*ExportTransactionsRequest, grpc.ServerStreamingServer[ExportTransactionsLine]) error {
+	return status.Error(codes.Unimplemented, "method ExportTransactions not implemented")
+}
+func (UnimplementedLedgerServiceServer) WatchBalance(*WatchBalanceRequest, grpc.ServerStreamingServer[BalanceUpdate]) error {
+	return status.Error(codes.Unimplemented, "method WatchBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetAccountsByOwner(context.Context, *GetAccountsByOwnerRequest) (*ListAccountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountsByOwner not implemented")
+}
+func (UnimplementedLedgerServiceServer) AdjustBalance(context.Context, *AdjustBalanceRequest) (*AdjustBalanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) BulkAdjustBalance(grpc.ClientStreamingServer[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse]) error {
+	return status.Error(codes.Unimplemented, "method BulkAdjustBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) ImportAccounts(grpc.ClientStreamingServer[ImportAccountRecord, ImportAccountsResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportAccounts not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetAccountStatement(context.Context, *TransactionHistoryRequest) (*AccountStatementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountStatement not implemented")
+}
+func (UnimplementedLedgerServiceServer) BatchTransfer(context.Context, *BatchTransferRequest) (*BatchTransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchTransfer not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetConversionQuote(context.Context, *ConversionQuoteRequest) (*ConversionQuoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConversionQuote not implemented")
+}
+func (UnimplementedLedgerServiceServer) CrossCurrencyTransfer(context.Context, *CrossCurrencyTransferRequest) (*CrossCurrencyTransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CrossCurrencyTransfer not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetServerInfo(context.Context, *GetServerInfoRequest) (*GetServerInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListAccountsByCurrency(context.Context, *ListAccountsByCurrencyRequest) (*ListAccountsByCurrencyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAccountsByCurrency not implemented")
+}
+func (UnimplementedLedgerServiceServer) ReverseTransfer(context.Context, *ReverseTransferRequest) (*ReverseTransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReverseTransfer not implemented")
+}
+func (UnimplementedLedgerServiceServer) ReverseTransfersInWindow(context.Context, *ReverseTransfersInWindowRequest) (*ReverseTransfersInWindowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReverseTransfersInWindow not implemented")
+}
+func (UnimplementedLedgerServiceServer) Deposit(context.Context, *DepositRequest) (*DepositResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedLedgerServiceServer) SetParentAccount(context.Context, *SetParentAccountRequest) (*SetParentAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetParentAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetAggregateBalance(context.Context, *AggregateBalanceRequest) (*AggregateBalanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAggregateBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedLedgerServiceServer) RetryDeadLetters(context.Context, *RetryDeadLettersRequest) (*RetryDeadLettersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetryDeadLetters not implemented")
+}
+func (UnimplementedLedgerServiceServer) RotateJWTSecret(context.Context, *RotateJWTSecretRequest) (*RotateJWTSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RotateJWTSecret not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetTransferStatus(context.Context, *GetTransferStatusRequest) (*GetTransferStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransferStatus not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedLedgerServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedLedgerServiceServer) mustEmbedUnimplementedLedgerServiceServer() {}
+func (UnimplementedLedgerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeLedgerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LedgerServiceServer will
+// result in compilation errors.
+type UnsafeLedgerServiceServer interface {
+	mustEmbedUnimplementedLedgerServiceServer()
+}
+
+func RegisterLedgerServiceServer(s grpc.ServiceRegistrar, srv LedgerServiceServer) {
+	// If the following call panics, it indicates UnimplementedLedgerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LedgerService_ServiceDesc, srv)
+}
+
+func _LedgerService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_Transfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetBalance(ctx, req.(*BalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_BatchGetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).BatchGetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_BatchGetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).BatchGetBalance(ctx, req.(*BatchGetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetBalanceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetBalanceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetBalanceAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetBalanceAsOf(ctx, req.(*BalanceAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).UpdateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_UpdateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_DeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ActivateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ActivateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ActivateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ActivateAccount(ctx, req.(*ActivateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_SetNotificationPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNotificationPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetNotificationPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SetNotificationPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetNotificationPreference(ctx, req.(*SetNotificationPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_SetAccountSensitive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAccountSensitiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetAccountSensitive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SetAccountSensitive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetAccountSensitive(ctx, req.(*SetAccountSensitiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetTransactionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetTransactionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetTransactionHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetTransactionHistory(ctx, req.(*TransactionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ReadEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ReadEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ReadEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ReadEvents(ctx, req.(*ReadEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ExportAccounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAccountsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServiceServer).ExportAccounts(m, &grpc.GenericServerStream[ExportAccountsRequest, ExportAccountsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_ExportAccountsServer = grpc.ServerStreamingServer[ExportAccountsChunk]
+
+func _LedgerService_ExportTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServiceServer).ExportTransactions(m, &grpc.GenericServerStream[ExportTransactionsRequest, ExportTransactionsLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_ExportTransactionsServer = grpc.ServerStreamingServer[ExportTransactionsLine]
+
+func _LedgerService_WatchBalance_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBalanceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServiceServer).WatchBalance(m, &grpc.GenericServerStream[WatchBalanceRequest, BalanceUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_WatchBalanceServer = grpc.ServerStreamingServer[BalanceUpdate]
+
+func _LedgerService_GetAccountsByOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountsByOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetAccountsByOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetAccountsByOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetAccountsByOwner(ctx, req.(*GetAccountsByOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AdjustBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AdjustBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_AdjustBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AdjustBalance(ctx, req.(*AdjustBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_BulkAdjustBalance_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LedgerServiceServer).BulkAdjustBalance(&grpc.GenericServerStream[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_BulkAdjustBalanceServer = grpc.ClientStreamingServer[BulkAdjustBalanceChunk, BulkAdjustBalanceResponse]
+
+func _LedgerService_ImportAccounts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LedgerServiceServer).ImportAccounts(&grpc.GenericServerStream[ImportAccountRecord, ImportAccountsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_ImportAccountsServer = grpc.ClientStreamingServer[ImportAccountRecord, ImportAccountsResponse]
+
+func _LedgerService_GetAccountStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetAccountStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetAccountStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetAccountStatement(ctx, req.(*TransactionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_BatchTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).BatchTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_BatchTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).BatchTransfer(ctx, req.(*BatchTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetConversionQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversionQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetConversionQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetConversionQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetConversionQuote(ctx, req.(*ConversionQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CrossCurrencyTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrossCurrencyTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CrossCurrencyTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CrossCurrencyTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CrossCurrencyTransfer(ctx, req.(*CrossCurrencyTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*UpdateAccountResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateAccountResponse)
-	err := c.cc.Invoke(ctx, LedgerService_UpdateAccount_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _LedgerService_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListCurrencies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*DeleteAccountResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteAccountResponse)
-	err := c.cc.Invoke(ctx, LedgerService_DeleteAccount_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _LedgerService_ListAccountsByCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsByCurrencyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListAccountsByCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListAccountsByCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListAccountsByCurrency(ctx, req.(*ListAccountsByCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListAccountsResponse)
-	err := c.cc.Invoke(ctx, LedgerService_ListAccounts_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _LedgerService_ReverseTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseTransferRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// LedgerServiceServer is the server API for LedgerService service.
-// All implementations must embed UnimplementedLedgerServiceServer
-// for forward compatibility.
-type LedgerServiceServer interface {
-	// Transfer handles double-entry movements
-	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
-	// GetBalance provides real-time account status
-	GetBalance(context.Context, *BalanceRequest) (*BalanceResponse, error)
-	// CRUD Operations
-	// CreateAccount creates a new account
-	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
-	// GetAccount retrieves full account details
-	GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error)
-	// UpdateAccount updates account information
-	UpdateAccount(context.Context, *UpdateAccountRequest) (*UpdateAccountResponse, error)
-	// DeleteAccount deletes an account
-	DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error)
-	// ListAccounts retrieves all accounts
-	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
-	mustEmbedUnimplementedLedgerServiceServer()
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ReverseTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ReverseTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ReverseTransfer(ctx, req.(*ReverseTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedLedgerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedLedgerServiceServer struct{}
-
-func (UnimplementedLedgerServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Transfer not implemented")
-}
-func (UnimplementedLedgerServiceServer) GetBalance(context.Context, *BalanceRequest) (*BalanceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetBalance not implemented")
-}
-func (UnimplementedLedgerServiceServer) CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method CreateAccount not implemented")
-}
-func (UnimplementedLedgerServiceServer) GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
-}
-func (UnimplementedLedgerServiceServer) UpdateAccount(context.Context, *UpdateAccountRequest) (*UpdateAccountResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateAccount not implemented")
-}
-func (UnimplementedLedgerServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*DeleteAccountResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteAccount not implemented")
-}
-func (UnimplementedLedgerServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListAccounts not implemented")
+func _LedgerService_ReverseTransfersInWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseTransfersInWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ReverseTransfersInWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ReverseTransfersInWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ReverseTransfersInWindow(ctx, req.(*ReverseTransfersInWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLedgerServiceServer) mustEmbedUnimplementedLedgerServiceServer() {}
-func (UnimplementedLedgerServiceServer) testEmbeddedByValue()                       {}
 
-// UnsafeLedgerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to LedgerServiceServer will
-// result in compilation errors.
-type UnsafeLedgerServiceServer interface {
-	mustEmbedUnimplementedLedgerServiceServer()
+func _LedgerService_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_Deposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).Deposit(ctx, req.(*DepositRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterLedgerServiceServer(s grpc.ServiceRegistrar, srv LedgerServiceServer) {
-	// If the following call panics, it indicates UnimplementedLedgerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _LedgerService_SetParentAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetParentAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&LedgerService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetParentAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SetParentAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetParentAccount(ctx, req.(*SetParentAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransferRequest)
+func _LedgerService_GetAggregateBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateBalanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).Transfer(ctx, in)
+		return srv.(LedgerServiceServer).GetAggregateBalance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_Transfer_FullMethodName,
+		FullMethod: LedgerService_GetAggregateBalance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).Transfer(ctx, req.(*TransferRequest))
+		return srv.(LedgerServiceServer).GetAggregateBalance(ctx, req.(*AggregateBalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BalanceRequest)
+func _LedgerService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).GetBalance(ctx, in)
+		return srv.(LedgerServiceServer).ListDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_GetBalance_FullMethodName,
+		FullMethod: LedgerService_ListDeadLetters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).GetBalance(ctx, req.(*BalanceRequest))
+		return srv.(LedgerServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateAccountRequest)
+func _LedgerService_RetryDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryDeadLettersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).CreateAccount(ctx, in)
+		return srv.(LedgerServiceServer).RetryDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_CreateAccount_FullMethodName,
+		FullMethod: LedgerService_RetryDeadLetters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+		return srv.(LedgerServiceServer).RetryDeadLetters(ctx, req.(*RetryDeadLettersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAccountRequest)
+func _LedgerService_RotateJWTSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateJWTSecretRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).GetAccount(ctx, in)
+		return srv.(LedgerServiceServer).RotateJWTSecret(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_GetAccount_FullMethodName,
+		FullMethod: LedgerService_RotateJWTSecret_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).GetAccount(ctx, req.(*GetAccountRequest))
+		return srv.(LedgerServiceServer).RotateJWTSecret(ctx, req.(*RotateJWTSecretRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAccountRequest)
+func _LedgerService_GetTransferStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).UpdateAccount(ctx, in)
+		return srv.(LedgerServiceServer).GetTransferStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_UpdateAccount_FullMethodName,
+		FullMethod: LedgerService_GetTransferStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+		return srv.(LedgerServiceServer).GetTransferStatus(ctx, req.(*GetTransferStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAccountRequest)
+func _LedgerService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).DeleteAccount(ctx, in)
+		return srv.(LedgerServiceServer).GetTransaction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_DeleteAccount_FullMethodName,
+		FullMethod: LedgerService_GetTransaction_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+		return srv.(LedgerServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListAccountsRequest)
+func _LedgerService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).ListAccounts(ctx, in)
+		return srv.(LedgerServiceServer).QueryAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_ListAccounts_FullMethodName,
+		FullMethod: LedgerService_QueryAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+		return srv.(LedgerServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -339,6 +1485,14 @@ var LedgerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBalance",
 			Handler:    _LedgerService_GetBalance_Handler,
 		},
+		{
+			MethodName: "BatchGetBalance",
+			Handler:    _LedgerService_BatchGetBalance_Handler,
+		},
+		{
+			MethodName: "GetBalanceAsOf",
+			Handler:    _LedgerService_GetBalanceAsOf_Handler,
+		},
 		{
 			MethodName: "CreateAccount",
 			Handler:    _LedgerService_CreateAccount_Handler,
@@ -355,11 +1509,137 @@ var LedgerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAccount",
 			Handler:    _LedgerService_DeleteAccount_Handler,
 		},
+		{
+			MethodName: "ActivateAccount",
+			Handler:    _LedgerService_ActivateAccount_Handler,
+		},
+		{
+			MethodName: "SetNotificationPreference",
+			Handler:    _LedgerService_SetNotificationPreference_Handler,
+		},
+		{
+			MethodName: "SetAccountSensitive",
+			Handler:    _LedgerService_SetAccountSensitive_Handler,
+		},
 		{
 			MethodName: "ListAccounts",
 			Handler:    _LedgerService_ListAccounts_Handler,
 		},
+		{
+			MethodName: "GetTransactionHistory",
+			Handler:    _LedgerService_GetTransactionHistory_Handler,
+		},
+		{
+			MethodName: "ReadEvents",
+			Handler:    _LedgerService_ReadEvents_Handler,
+		},
+		{
+			MethodName: "GetAccountsByOwner",
+			Handler:    _LedgerService_GetAccountsByOwner_Handler,
+		},
+		{
+			MethodName: "AdjustBalance",
+			Handler:    _LedgerService_AdjustBalance_Handler,
+		},
+		{
+			MethodName: "GetAccountStatement",
+			Handler:    _LedgerService_GetAccountStatement_Handler,
+		},
+		{
+			MethodName: "BatchTransfer",
+			Handler:    _LedgerService_BatchTransfer_Handler,
+		},
+		{
+			MethodName: "GetConversionQuote",
+			Handler:    _LedgerService_GetConversionQuote_Handler,
+		},
+		{
+			MethodName: "CrossCurrencyTransfer",
+			Handler:    _LedgerService_CrossCurrencyTransfer_Handler,
+		},
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _LedgerService_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _LedgerService_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "ListAccountsByCurrency",
+			Handler:    _LedgerService_ListAccountsByCurrency_Handler,
+		},
+		{
+			MethodName: "ReverseTransfer",
+			Handler:    _LedgerService_ReverseTransfer_Handler,
+		},
+		{
+			MethodName: "ReverseTransfersInWindow",
+			Handler:    _LedgerService_ReverseTransfersInWindow_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _LedgerService_Deposit_Handler,
+		},
+		{
+			MethodName: "SetParentAccount",
+			Handler:    _LedgerService_SetParentAccount_Handler,
+		},
+		{
+			MethodName: "GetAggregateBalance",
+			Handler:    _LedgerService_GetAggregateBalance_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _LedgerService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "RetryDeadLetters",
+			Handler:    _LedgerService_RetryDeadLetters_Handler,
+		},
+		{
+			MethodName: "RotateJWTSecret",
+			Handler:    _LedgerService_RotateJWTSecret_Handler,
+		},
+		{
+			MethodName: "GetTransferStatus",
+			Handler:    _LedgerService_GetTransferStatus_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _LedgerService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _LedgerService_QueryAuditLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAccounts",
+			Handler:       _LedgerService_ExportAccounts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportTransactions",
+			Handler:       _LedgerService_ExportTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBalance",
+			Handler:       _LedgerService_WatchBalance_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BulkAdjustBalance",
+			Handler:       _LedgerService_BulkAdjustBalance_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportAccounts",
+			Handler:       _LedgerService_ImportAccounts_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/ledger.proto",
 }